@@ -0,0 +1,196 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// apiCallStatKey identifies the calls a stat is kept for
+type apiCallStatKey struct {
+	ConnectionName string
+	TableName      string
+	Service        string
+	Operation      string
+	Region         string
+}
+
+// apiCallStat holds the counters of the AWS API calls made for a table, per service, operation and region
+type apiCallStat struct {
+	apiCallStatKey
+	// calls made by the plugin, each may have been retried
+	Calls int64
+	// calls which failed after all retries
+	Errors int64
+	// attempts which were throttled, and retried if retries were left
+	Throttles int64
+	Retries   int64
+	// time from the start of the call to its completion, including retries and rate limit waits
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	LastErrorCode string
+	FirstCallTime time.Time
+	LastCallTime  time.Time
+}
+
+// maxApiCallStats caps the number of stats kept, which grows with the tables, operations and regions
+// queried by each connection of a long running plugin process
+const maxApiCallStats = 10000
+
+// apiCallStatRegistry holds the API call stats of all connections served by the plugin process
+type apiCallStatRegistry struct {
+	mutex sync.Mutex
+	stats map[apiCallStatKey]*apiCallStat
+	// the number of stats kept, once reached the least recently called one is dropped for a new one
+	maxStats int
+}
+
+var apiCallStats = &apiCallStatRegistry{
+	stats:    map[apiCallStatKey]*apiCallStat{},
+	maxStats: maxApiCallStats,
+}
+
+// get returns the stat for a key, and must be called with the mutex held
+func (r *apiCallStatRegistry) get(key apiCallStatKey) *apiCallStat {
+	stat, ok := r.stats[key]
+	if !ok {
+		if len(r.stats) >= r.maxStats {
+			r.dropLeastRecentlyCalled()
+		}
+		stat = &apiCallStat{apiCallStatKey: key}
+		r.stats[key] = stat
+	}
+	return stat
+}
+
+// dropLeastRecentlyCalled removes the stat whose last call is the oldest, and must be called with the
+// mutex held. Stats which have only seen throttled attempts so far have no last call, and go first.
+func (r *apiCallStatRegistry) dropLeastRecentlyCalled() {
+	var oldestKey *apiCallStatKey
+	var oldest time.Time
+	for key, stat := range r.stats {
+		if oldestKey == nil || stat.LastCallTime.Before(oldest) {
+			key := key
+			oldestKey, oldest = &key, stat.LastCallTime
+		}
+	}
+	if oldestKey != nil {
+		delete(r.stats, *oldestKey)
+	}
+}
+
+// list returns a copy of the stats of a connection
+func (r *apiCallStatRegistry) list(connectionName string) []apiCallStat {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var stats []apiCallStat
+	for key, stat := range r.stats {
+		if key.ConnectionName == connectionName {
+			stats = append(stats, *stat)
+		}
+	}
+	return stats
+}
+
+// apiCallTableContextKey is the key of the request context value holding the name of the table a
+// call is made for, see withApiCallTable
+type apiCallTableContextKey struct{}
+
+// withApiCallTable returns a copy of the context of a request, which attributes its API call to the table
+// being queried. Sessions and service clients are shared by the tables of a connection, so the table is
+// set per request by the clients returned for a query, see withQueryContext.
+func withApiCallTable(ctx context.Context, d *plugin.QueryData) context.Context {
+	if d.Table == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, apiCallTableContextKey{}, d.Table.Name)
+}
+
+// installApiCallStatHandlers adds request handlers to a session, which count its API calls, latency,
+// retries, throttles and errors per table, and write a log line per call
+func installApiCallStatHandlers(handlers *request.Handlers, logger hclog.Logger, d *plugin.QueryData) {
+	connectionName := ""
+	if d.Connection != nil {
+		connectionName = d.Connection.Name
+	}
+
+	statKey := func(r *request.Request) apiCallStatKey {
+		// calls made without the clients of a query, e.g. by the credential providers, have no table
+		tableName, _ := r.Context().Value(apiCallTableContextKey{}).(string)
+		return apiCallStatKey{
+			ConnectionName: connectionName,
+			TableName:      tableName,
+			Service:        r.ClientInfo.ServiceName,
+			Operation:      r.Operation.Name,
+			Region:         aws.StringValue(r.Config.Region),
+		}
+	}
+
+	// runs after each failed attempt
+	handlers.Retry.PushBackNamed(request.NamedHandler{
+		Name: "steampipe.ApiCallStatRetryHandler",
+		Fn: func(r *request.Request) {
+			if !isThrottlingError(r.Error) {
+				return
+			}
+			apiCallStats.mutex.Lock()
+			defer apiCallStats.mutex.Unlock()
+			apiCallStats.get(statKey(r)).Throttles++
+		},
+	})
+
+	// runs once per call, after the last attempt
+	handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "steampipe.ApiCallStatCompleteHandler",
+		Fn: func(r *request.Request) {
+			key := statKey(r)
+			now := time.Now()
+			duration := now.Sub(r.Time)
+			errorCode := ""
+			if r.Error != nil {
+				errorCode = "Unknown"
+				if awsErr, ok := r.Error.(awserr.Error); ok {
+					errorCode = awsErr.Code()
+				}
+			}
+
+			apiCallStats.mutex.Lock()
+			stat := apiCallStats.get(key)
+			stat.Calls++
+			stat.Retries += int64(r.RetryCount)
+			stat.TotalDuration += duration
+			if duration > stat.MaxDuration {
+				stat.MaxDuration = duration
+			}
+			if errorCode != "" {
+				stat.Errors++
+				stat.LastErrorCode = errorCode
+			}
+			if stat.FirstCallTime.IsZero() {
+				stat.FirstCallTime = r.Time
+			}
+			stat.LastCallTime = now
+			calls := stat.Calls
+			apiCallStats.mutex.Unlock()
+
+			logger.Debug("aws_api_call",
+				"connection", key.ConnectionName,
+				"table", key.TableName,
+				"service", key.Service,
+				"operation", key.Operation,
+				"region", key.Region,
+				"duration_ms", duration.Milliseconds(),
+				"retries", r.RetryCount,
+				"error_code", errorCode,
+				"calls", calls,
+			)
+		},
+	})
+}
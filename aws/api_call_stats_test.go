@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestApiCallStatsPerTable(t *testing.T) {
+	connection := &plugin.Connection{Name: "test_api_call_stats_per_table"}
+
+	// a client shared by the tables, as the cached clients are, whose calls are denied
+	svc := sts.New(unit.Session, aws.NewConfig().WithMaxRetries(0))
+	svc.Handlers.Send.Clear()
+	svc.Handlers.Send.PushBack(func(r *request.Request) {
+		r.HTTPResponse = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+		r.Error = awserr.New("AccessDenied", "Access denied.", nil)
+	})
+	installApiCallStatHandlers(&svc.Handlers, hclog.NewNullLogger(), &plugin.QueryData{Connection: connection})
+
+	for _, tableName := range []string{"aws_table_one", "aws_table_two", "aws_table_two"} {
+		d := &plugin.QueryData{Connection: connection, Table: &plugin.Table{Name: tableName}}
+		querySvc := withQueryContext(newTestContext(), d, svc).(stsiface.STSAPI)
+		if _, err := querySvc.GetCallerIdentity(&sts.GetCallerIdentityInput{}); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	// a call made without the client of a query
+	if _, err := svc.GetCallerIdentity(&sts.GetCallerIdentityInput{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	stats := apiCallStats.list(connection.Name)
+	sort.Slice(stats, func(i, j int) bool { return stats[i].TableName < stats[j].TableName })
+
+	expected := map[string]int64{"": 1, "aws_table_one": 1, "aws_table_two": 2}
+	if len(stats) != len(expected) {
+		t.Fatalf("expected stats for %d tables, got %+v", len(expected), stats)
+	}
+	for _, stat := range stats {
+		if stat.Calls != expected[stat.TableName] || stat.Errors != expected[stat.TableName] {
+			t.Errorf("table %q: expected %d calls and errors, got %d calls and %d errors", stat.TableName, expected[stat.TableName], stat.Calls, stat.Errors)
+		}
+		if stat.Operation != "GetCallerIdentity" || stat.LastErrorCode != "AccessDenied" {
+			t.Errorf("table %q: unexpected stat %+v", stat.TableName, stat)
+		}
+	}
+}
+
+func TestApiCallStatRegistryCap(t *testing.T) {
+	registry := &apiCallStatRegistry{stats: map[apiCallStatKey]*apiCallStat{}, maxStats: 2}
+	start := time.Now()
+
+	for i, operation := range []string{"DescribeInstances", "DescribeVolumes", "DescribeInstances", "DescribeSnapshots"} {
+		stat := registry.get(apiCallStatKey{ConnectionName: "test", Service: "ec2", Operation: operation})
+		stat.Calls++
+		stat.LastCallTime = start.Add(time.Duration(i) * time.Second)
+	}
+
+	var operations []string
+	for _, stat := range registry.list("test") {
+		operations = append(operations, stat.Operation)
+	}
+	sort.Strings(operations)
+
+	// DescribeVolumes is the least recently called when DescribeSnapshots is first called
+	if len(operations) != 2 || operations[0] != "DescribeInstances" || operations[1] != "DescribeSnapshots" {
+		t.Errorf("expected the stats of DescribeInstances and DescribeSnapshots, got %v", operations)
+	}
+}
@@ -106,7 +106,7 @@ func getCommonColumns(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	plugin.Logger(ctx).Trace("getCommonColumns", "region", region)
 
 	var commonColumnData *awsCommonColumnData
-	getCallerIdentityCached := plugin.HydrateFunc(getCallerIdentity).WithCache()
+	getCallerIdentityCached := plugin.HydrateFunc(getCallerIdentity).WithCache(getCallerIdentityCacheKey)
 	getCallerIdentityData, err := getCallerIdentityCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -123,13 +123,28 @@ func getCommonColumns(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	return commonColumnData, nil
 }
 
+// cache key for getCommonColumns, so cached values are not shared across regions or organization accounts
+func getCommonColumnsCacheKey(_ context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return scopedCacheKey(d, "getCommonColumns-"+d.KeyColumnQualString(matrixKeyRegion)), nil
+}
+
+// cache key for getCallerIdentity, so cached values are not shared across organization accounts
+func getCallerIdentityCacheKey(_ context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	return scopedCacheKey(d, "getCallerIdentity"), nil
+}
+
 func getCallerIdentity(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	cacheKey := "GetCallerIdentity"
+	cacheKey := scopedCacheKey(d, "GetCallerIdentity")
 
 	// if found in cache, return the result
 	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.(*sts.GetCallerIdentityOutput), nil
 	}
+	cachedCallerIdentity := &sts.GetCallerIdentityOutput{}
+	if diskCacheGet(ctx, d, cacheKey, cachedCallerIdentity) {
+		d.ConnectionManager.Cache.Set(cacheKey, cachedCallerIdentity)
+		return cachedCallerIdentity, nil
+	}
 
 	// get the service connection for the service
 	stsSvc, err := StsService(ctx, d)
@@ -145,5 +160,6 @@ func getCallerIdentity(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 
 	// save to extension cache
 	d.ConnectionManager.Cache.Set(cacheKey, callerIdentity)
+	diskCacheSet(ctx, d, cacheKey, callerIdentity, callerIdentityDiskCacheTTL)
 	return callerIdentity, nil
 }
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/connection"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestGetCommonColumns(t *testing.T) {
+	ctx := newTestContext()
+
+	for _, testCase := range []struct {
+		name      string
+		partition string
+		region    string
+		expected  *awsCommonColumnData
+	}{
+		{
+			name:      "regional",
+			partition: "aws",
+			region:    "us-east-1",
+			expected:  &awsCommonColumnData{Partition: "aws", Region: "us-east-1", AccountId: "123456789012"},
+		},
+		{
+			name:      "global",
+			partition: "aws",
+			expected:  &awsCommonColumnData{Partition: "aws", Region: "global", AccountId: "123456789012"},
+		},
+		{
+			name:      "china partition",
+			partition: "aws-cn",
+			region:    "cn-north-1",
+			expected:  &awsCommonColumnData{Partition: "aws-cn", Region: "cn-north-1", AccountId: "123456789012"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeSts := &fakeSTS{Identity: newFakeCallerIdentity(testCase.partition, "123456789012")}
+			defer registerServiceClient("sts", fakeSts)()
+
+			// a new cache for each run, so the caller identity is not cached yet
+			d := &plugin.QueryData{
+				Connection:        newTestConnection("test_common_columns", "AKIDCONNECTIONONE", "http://localhost", []string{"us-east-1"}),
+				ConnectionManager: connection.NewManager(),
+				KeyColumnQuals:    plugin.KeyColumnEqualsQualMap{},
+			}
+			if testCase.region != "" {
+				d.KeyColumnQuals[matrixKeyRegion] = proto.NewQualValue(testCase.region)
+			}
+
+			// the caller identity is cached for the connection, so STS is only called once
+			for i := 0; i < 2; i++ {
+				actual, err := getCommonColumns(ctx, d, &plugin.HydrateData{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(actual, testCase.expected) {
+					t.Errorf("expected %+v, got %+v", testCase.expected, actual)
+				}
+			}
+			if calls := fakeSts.GetCallerIdentityCalls(); calls != 1 {
+				t.Errorf("expected 1 GetCallerIdentity call, got %d", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package aws
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// validateConfig checks the connection config for settings which can never work, so the
+// connection fails to load with a descriptive error instead of failing (or crashing the
+// plugin) when it is first queried
+func validateConfig(awsConfig awsConfig) error {
+	var problems []string
+
+	if awsConfig.AccessKey != nil && awsConfig.SecretKey == nil {
+		problems = append(problems, "partial credentials, \"access_key\" is set without \"secret_key\"")
+	} else if awsConfig.SecretKey != nil && awsConfig.AccessKey == nil {
+		problems = append(problems, "partial credentials, \"secret_key\" is set without \"access_key\"")
+	} else if awsConfig.SessionToken != nil && awsConfig.AccessKey == nil {
+		problems = append(problems, "partial credentials, \"session_token\" is set without \"access_key\" and \"secret_key\"")
+	}
+
+	if awsConfig.Profile != nil {
+		if err := validateProfile(*awsConfig.Profile); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if _, err := resolveCredentialSource(awsConfig); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if _, err := parseRateLimits(awsConfig.RateLimits); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if _, err := newHttpClient(awsConfig); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if awsConfig.CaBundlePath != nil {
+		if _, err := loadCABundle(*awsConfig.CaBundlePath); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if awsConfig.CacheDir != nil {
+		if err := validateDiskCacheDir(*awsConfig.CacheDir); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if mode, filename := getHttpRecordReplayMode(awsConfig); mode != "" {
+		if _, err := getRecordReplayTransport(mode, filename); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	regions, err := getConfigRegions(awsConfig)
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("failed to load the default region from the AWS config: %s", err.Error()))
+	}
+	problems = append(problems, validateRegions(regions)...)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid connection config: %s. Edit your connection configuration file and then restart Steampipe", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateRegions checks that each regions entry matches a region, and that all
+// regions are in the partition of the connection's default region
+func validateRegions(regions []string) []string {
+	if len(regions) == 0 {
+		return nil
+	}
+
+	// an invalid default region is reported below, check the others against the aws partition
+	partition, ok := getPartitionForRegion(getDefaultRegionFromList(regions))
+	if !ok {
+		partition = endpoints.AwsPartition()
+	}
+
+	var invalidPatterns, otherPartitionRegions []string
+	for _, pattern := range regions {
+		// regions which are not known to the SDK yet are valid, if they match the naming of a partition
+		if !isRegionPattern(pattern) {
+			regionPartition, ok := getPartitionForRegion(pattern)
+			if !ok {
+				invalidPatterns = append(invalidPatterns, pattern)
+			} else if regionPartition.ID() != partition.ID() {
+				otherPartitionRegions = append(otherPartitionRegions, pattern)
+			}
+			continue
+		}
+
+		// patterns are matched against the regions of the connection's partition only
+		matchesPartition, matchesOtherPartition := false, false
+		for _, p := range endpoints.DefaultPartitions() {
+			for _, region := range getPartitionRegions(p) {
+				if ok, _ := path.Match(pattern, region); ok {
+					if p.ID() == partition.ID() {
+						matchesPartition = true
+					} else {
+						matchesOtherPartition = true
+					}
+				}
+			}
+		}
+		if !matchesPartition && matchesOtherPartition {
+			otherPartitionRegions = append(otherPartitionRegions, pattern)
+		} else if !matchesPartition {
+			invalidPatterns = append(invalidPatterns, pattern)
+		}
+	}
+
+	var problems []string
+	if len(invalidPatterns) > 0 {
+		problems = append(problems, fmt.Sprintf("invalid \"regions\": %s", strings.Join(invalidPatterns, ", ")))
+	}
+	if len(otherPartitionRegions) > 0 {
+		problems = append(problems, fmt.Sprintf("\"regions\" mixes partitions, %s not in the %s partition of the default region %s, use a separate connection per partition", strings.Join(otherPartitionRegions, ", "), partition.ID(), getDefaultRegionFromList(regions)))
+	}
+	return problems
+}
+
+// getConfigRegions returns the regions entries of the connection config, or the
+// default region of the AWS SDK config if the connection config has none
+func getConfigRegions(awsConfig awsConfig) ([]string, error) {
+	if awsConfig.Regions != nil {
+		return awsConfig.Regions, nil
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sess.Config != nil && sess.Config.Region != nil && *sess.Config.Region != "" {
+		return []string{*sess.Config.Region}, nil
+	}
+	return nil, nil
+}
+
+// getDefaultRegionFromList returns the region which the connection makes its non-regional calls
+// in. This is the first regions entry, or if that is a wildcard, the region targeted by the
+// global services (like IAM, s3, Route53, etc..) of the partition it matches.
+func getDefaultRegionFromList(regions []string) string {
+	if len(regions) == 0 || regions[0] == "" {
+		return "us-east-1"
+	}
+	region := regions[0]
+	if !isRegionPattern(region) {
+		return region
+	}
+
+	for _, knownRegion := range getKnownRegions() {
+		if ok, _ := path.Match(region, knownRegion); ok {
+			partition, _ := getPartitionForRegion(knownRegion)
+			return getPartitionGlobalRegion(partition)
+		}
+	}
+	return "us-east-1"
+}
+
+// validateProfile checks that a profile is defined in the shared config or credentials file
+func validateProfile(profile string) error {
+	_, found, err := loadProfileSettings(profile)
+	if err != nil {
+		return fmt.Errorf("failed to read profile %q: %s", profile, err.Error())
+	}
+	if !found {
+		configFile, credentialsFile := getSharedConfigFiles()
+		return fmt.Errorf("profile %q is not defined in %s or %s", profile, configFile, credentialsFile)
+	}
+	return nil
+}
+
+// getSharedConfigFiles returns the shared config and credentials files of the AWS CLI and SDKs
+func getSharedConfigFiles() (string, string) {
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = defaults.SharedConfigFilename()
+	}
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = defaults.SharedCredentialsFilename()
+	}
+	return configFile, credentialsFile
+}
+
+// loadProfileSettings returns the settings of a profile, from its [<profile>] or [profile <profile>]
+// sections in the shared config and credentials files. Settings of the credentials file take precedence.
+func loadProfileSettings(profile string) (map[string]string, bool, error) {
+	configFile, credentialsFile := getSharedConfigFiles()
+	isProfileSection := func(section string) bool {
+		if strings.HasPrefix(section, "profile ") {
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+		}
+		return section == profile
+	}
+
+	settings := map[string]string{}
+	found := false
+	for _, filename := range []string{configFile, credentialsFile} {
+		fileSettings, fileFound, err := readIniSections(filename, isProfileSection)
+		if err != nil {
+			return nil, false, err
+		}
+		for key, value := range fileSettings {
+			settings[key] = value
+		}
+		found = found || fileFound
+	}
+	return settings, found, nil
+}
+
+// readIniSections returns the settings of the sections of an ini file with a matching name, and whether
+// there were any. Nested settings, e.g. of s3 in the AWS config file, are skipped. A missing file has no sections.
+func readIniSections(filename string, matches func(section string) bool) (map[string]string, bool, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer file.Close()
+
+	settings := map[string]string{}
+	found, inSection := false, false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		rawLine := scanner.Text()
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			inSection = matches(strings.TrimSpace(line[1 : len(line)-1]))
+			found = found || inSection
+			continue
+		}
+		if !inSection || strings.HasPrefix(rawLine, " ") || strings.HasPrefix(rawLine, "\t") {
+			continue
+		}
+		if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
+			settings[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return settings, found, scanner.Err()
+}
@@ -6,11 +6,58 @@ import (
 )
 
 type awsConfig struct {
-	Regions      []string `cty:"regions"`
-	Profile      *string  `cty:"profile"`
-	AccessKey    *string  `cty:"access_key"`
-	SecretKey    *string  `cty:"secret_key"`
-	SessionToken *string  `cty:"session_token"`
+	Regions         []string `cty:"regions"`
+	Profile         *string  `cty:"profile"`
+	AccessKey       *string  `cty:"access_key"`
+	SecretKey       *string  `cty:"secret_key"`
+	SessionToken    *string  `cty:"session_token"`
+	AssumeRoleArn   *string  `cty:"assume_role_arn"`
+	AssumeRoleChain []string `cty:"assume_role_chain"`
+	ExternalId      *string  `cty:"external_id"`
+	RoleSessionName *string  `cty:"role_session_name"`
+	DurationSeconds *int     `cty:"duration_seconds"`
+	MfaSerial       *string  `cty:"mfa_serial"`
+	MfaTokenCommand *string  `cty:"mfa_token_command"`
+
+	SSOSession           *string `cty:"sso_session"`
+	SSOStartUrl          *string `cty:"sso_start_url"`
+	SSORegion            *string `cty:"sso_region"`
+	SSOAccountId         *string `cty:"sso_account_id"`
+	SSORoleName          *string `cty:"sso_role_name"`
+	WebIdentityTokenFile *string `cty:"web_identity_token_file"`
+	WebIdentityRoleArn   *string `cty:"web_identity_role_arn"`
+	CredentialProcess    *string `cty:"credential_process"`
+
+	OrganizationRoleName   *string  `cty:"organization_role_name"`
+	OrganizationAccountIds []string `cty:"organization_account_ids"`
+	OrganizationUnitIds    []string `cty:"organization_unit_ids"`
+
+	EndpointUrl          *string  `cty:"endpoint_url"`
+	Endpoints            []string `cty:"endpoints"`
+	S3ForcePathStyle     *bool    `cty:"s3_force_path_style"`
+	UseFIPSEndpoint      *bool    `cty:"use_fips_endpoint"`
+	UseDualStackEndpoint *bool    `cty:"use_dualstack_endpoint"`
+
+	MaxErrorRetryAttempts *int `cty:"max_error_retry_attempts"`
+	MinErrorRetryDelay    *int `cty:"min_error_retry_delay"`
+	MaxErrorRetryDelay    *int `cty:"max_error_retry_delay"`
+
+	IgnoreErrorCodes []string `cty:"ignore_error_codes"`
+
+	RateLimits []string `cty:"rate_limits"`
+
+	HttpsProxy     *string  `cty:"https_proxy"`
+	NoProxy        []string `cty:"no_proxy"`
+	CaBundlePath   *string  `cty:"ca_bundle_path"`
+	ConnectTimeout *int     `cty:"connect_timeout"`
+	ReadTimeout    *int     `cty:"read_timeout"`
+	MaxIdleConns   *int     `cty:"max_idle_conns"`
+
+	CacheDir *string `cty:"cache_dir"`
+
+	// hidden options to record or replay the HTTP traffic, see recordReplayTransport
+	HttpMode        *string `cty:"http_mode"`
+	HttpFixtureFile *string `cty:"http_fixture_file"`
 }
 
 var ConfigSchema = map[string]*schema.Attribute{
@@ -30,6 +77,124 @@ var ConfigSchema = map[string]*schema.Attribute{
 	"session_token": {
 		Type: schema.TypeString,
 	},
+	"assume_role_arn": {
+		Type: schema.TypeString,
+	},
+	"assume_role_chain": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"external_id": {
+		Type: schema.TypeString,
+	},
+	"role_session_name": {
+		Type: schema.TypeString,
+	},
+	"duration_seconds": {
+		Type: schema.TypeInt,
+	},
+	"mfa_serial": {
+		Type: schema.TypeString,
+	},
+	"mfa_token_command": {
+		Type: schema.TypeString,
+	},
+	"sso_session": {
+		Type: schema.TypeString,
+	},
+	"sso_start_url": {
+		Type: schema.TypeString,
+	},
+	"sso_region": {
+		Type: schema.TypeString,
+	},
+	"sso_account_id": {
+		Type: schema.TypeString,
+	},
+	"sso_role_name": {
+		Type: schema.TypeString,
+	},
+	"web_identity_token_file": {
+		Type: schema.TypeString,
+	},
+	"web_identity_role_arn": {
+		Type: schema.TypeString,
+	},
+	"credential_process": {
+		Type: schema.TypeString,
+	},
+	"organization_role_name": {
+		Type: schema.TypeString,
+	},
+	"organization_account_ids": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"organization_unit_ids": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"endpoint_url": {
+		Type: schema.TypeString,
+	},
+	"endpoints": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"s3_force_path_style": {
+		Type: schema.TypeBool,
+	},
+	"use_fips_endpoint": {
+		Type: schema.TypeBool,
+	},
+	"use_dualstack_endpoint": {
+		Type: schema.TypeBool,
+	},
+	"max_error_retry_attempts": {
+		Type: schema.TypeInt,
+	},
+	"min_error_retry_delay": {
+		Type: schema.TypeInt,
+	},
+	"max_error_retry_delay": {
+		Type: schema.TypeInt,
+	},
+	"ignore_error_codes": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"rate_limits": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"https_proxy": {
+		Type: schema.TypeString,
+	},
+	"no_proxy": {
+		Type: schema.TypeList,
+		Elem: &schema.Attribute{Type: schema.TypeString},
+	},
+	"ca_bundle_path": {
+		Type: schema.TypeString,
+	},
+	"connect_timeout": {
+		Type: schema.TypeInt,
+	},
+	"read_timeout": {
+		Type: schema.TypeInt,
+	},
+	"max_idle_conns": {
+		Type: schema.TypeInt,
+	},
+	"cache_dir": {
+		Type: schema.TypeString,
+	},
+	"http_mode": {
+		Type: schema.TypeString,
+	},
+	"http_fixture_file": {
+		Type: schema.TypeString,
+	},
 }
 
 func ConfigInstance() interface{} {
@@ -2,6 +2,10 @@ package aws
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/costexplorer"
@@ -131,15 +135,9 @@ func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costex
 	logger := plugin.Logger(ctx)
 	logger.Trace("streamCostAndUsage")
 
-	// Create session
-	svc, err := CostExplorerService(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
 	// List call
 	for {
-		output, err := svc.GetCostAndUsage(params)
+		output, err := getCostAndUsage(ctx, d, params)
 		if err != nil {
 			logger.Error("streamCostAndUsage", "err", err)
 			return nil, err
@@ -160,6 +158,68 @@ func streamCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costex
 	return nil, nil
 }
 
+// getCostAndUsage calls GetCostAndUsage, or returns the response cached on disk for the same
+// input, since each call is charged
+func getCostAndUsage(ctx context.Context, d *plugin.QueryData, params *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error) {
+	cacheKey, err := costExplorerCacheKey(d, "GetCostAndUsage", params)
+	if err != nil {
+		return nil, err
+	}
+	output := &costexplorer.GetCostAndUsageOutput{}
+	if diskCacheGet(ctx, d, cacheKey, output) {
+		return output, nil
+	}
+
+	// Create session
+	svc, err := CostExplorerService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err = svc.GetCostAndUsage(params)
+	if err != nil {
+		return nil, err
+	}
+	diskCacheSet(ctx, d, cacheKey, output, costExplorerDiskCacheTTL)
+	return output, nil
+}
+
+// getCostForecast calls GetCostForecast, or returns the response cached on disk for the same input
+func getCostForecast(ctx context.Context, d *plugin.QueryData, params *costexplorer.GetCostForecastInput) (*costexplorer.GetCostForecastOutput, error) {
+	cacheKey, err := costExplorerCacheKey(d, "GetCostForecast", params)
+	if err != nil {
+		return nil, err
+	}
+	output := &costexplorer.GetCostForecastOutput{}
+	if diskCacheGet(ctx, d, cacheKey, output) {
+		return output, nil
+	}
+
+	// Create session
+	svc, err := CostExplorerService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err = svc.GetCostForecast(params)
+	if err != nil {
+		return nil, err
+	}
+	diskCacheSet(ctx, d, cacheKey, output, costExplorerDiskCacheTTL)
+	return output, nil
+}
+
+// costExplorerCacheKey returns the cache key of a Cost Explorer call, which identifies the
+// account and the input, including the page token
+func costExplorerCacheKey(d *plugin.QueryData, operation string, params interface{}) (string, error) {
+	input, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(input)
+	return scopedCacheKey(d, fmt.Sprintf("%s-%s", operation, hex.EncodeToString(hash[:]))), nil
+}
+
 func buildCEMetricRows(ctx context.Context, costUsageData *costexplorer.GetCostAndUsageOutput, _ map[string]*proto.QualValue) []CEMetricRow {
 	logger := plugin.Logger(ctx)
 	logger.Trace("buildCEMetricRows")
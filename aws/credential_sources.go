@@ -0,0 +1,321 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/ssocreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sso"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// Types of the source of a connection's base credentials, before assume_role_arn and
+// organization_role_name are applied, as shown by the aws_plugin_connection table
+const (
+	credentialSourceAccessKey         = "access_key"
+	credentialSourceSSO               = "sso"
+	credentialSourceWebIdentity       = "web_identity"
+	credentialSourceCredentialProcess = "credential_process"
+	// credentials the SDK resolves from a profile, e.g. keys in the credentials file or a source_profile chain
+	credentialSourceProfile     = "profile"
+	credentialSourceEnvironment = "environment"
+	// the default credential chain of the SDK, e.g. the default profile or an instance role
+	credentialSourceDefault = "default"
+)
+
+// credentialSource is where the base credentials of a connection come from. SSO, web identity
+// and credential_process sources are resolved by the plugin, from the connection config or from
+// the profile, so their errors can say what to do. The others are left to the SDK.
+type credentialSource struct {
+	Type string
+	// profile the source was read from, if any
+	Profile string
+
+	// sso_session of IAM Identity Center profiles, which the start URL and region are read from
+	SSOSession   string
+	SSOStartURL  string
+	SSORegion    string
+	SSOAccountId string
+	SSORoleName  string
+
+	WebIdentityTokenFile string
+	WebIdentityRoleArn   string
+	RoleSessionName      string
+
+	CredentialProcess string
+}
+
+// resolveCredentialSource returns the credential source of a connection, in the order the SDK
+// resolves credentials: the connection config, its profile, environment variables, then the
+// default profile
+func resolveCredentialSource(awsConfig awsConfig) (*credentialSource, error) {
+	var configSources []string
+	if awsConfig.AccessKey != nil {
+		configSources = append(configSources, credentialSourceAccessKey)
+	}
+	if awsConfig.SSOSession != nil || awsConfig.SSOStartUrl != nil || awsConfig.SSORegion != nil || awsConfig.SSOAccountId != nil || awsConfig.SSORoleName != nil {
+		configSources = append(configSources, credentialSourceSSO)
+	}
+	if awsConfig.WebIdentityTokenFile != nil || awsConfig.WebIdentityRoleArn != nil {
+		configSources = append(configSources, credentialSourceWebIdentity)
+	}
+	if awsConfig.CredentialProcess != nil {
+		configSources = append(configSources, credentialSourceCredentialProcess)
+	}
+	if len(configSources) > 1 {
+		return nil, fmt.Errorf("only one credential source can be set, found: %s", strings.Join(configSources, ", "))
+	}
+
+	if len(configSources) == 1 {
+		source := &credentialSource{Type: configSources[0]}
+		switch source.Type {
+		case credentialSourceSSO:
+			source.SSOSession = aws.StringValue(awsConfig.SSOSession)
+			source.SSOStartURL = aws.StringValue(awsConfig.SSOStartUrl)
+			source.SSORegion = aws.StringValue(awsConfig.SSORegion)
+			source.SSOAccountId = aws.StringValue(awsConfig.SSOAccountId)
+			source.SSORoleName = aws.StringValue(awsConfig.SSORoleName)
+		case credentialSourceWebIdentity:
+			source.WebIdentityTokenFile = aws.StringValue(awsConfig.WebIdentityTokenFile)
+			source.WebIdentityRoleArn = aws.StringValue(awsConfig.WebIdentityRoleArn)
+			source.RoleSessionName = aws.StringValue(awsConfig.RoleSessionName)
+		case credentialSourceCredentialProcess:
+			source.CredentialProcess = aws.StringValue(awsConfig.CredentialProcess)
+		}
+		return source, validateCredentialSource(source)
+	}
+
+	if awsConfig.Profile != nil {
+		return resolveProfileCredentialSource(*awsConfig.Profile, credentialSourceProfile)
+	}
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		return &credentialSource{Type: credentialSourceEnvironment}, nil
+	}
+	if tokenFile := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"); tokenFile != "" {
+		source := &credentialSource{
+			Type:                 credentialSourceWebIdentity,
+			WebIdentityTokenFile: tokenFile,
+			WebIdentityRoleArn:   os.Getenv("AWS_ROLE_ARN"),
+			RoleSessionName:      os.Getenv("AWS_ROLE_SESSION_NAME"),
+		}
+		return source, validateCredentialSource(source)
+	}
+
+	profile := os.Getenv("AWS_PROFILE")
+	if profile == "" {
+		profile = "default"
+	}
+	return resolveProfileCredentialSource(profile, credentialSourceDefault)
+}
+
+// resolveProfileCredentialSource returns the SSO, web identity or credential_process source of
+// a profile, or the given type if the credentials of the profile are left to the SDK
+func resolveProfileCredentialSource(profile string, sdkSourceType string) (*credentialSource, error) {
+	settings, _, err := loadProfileSettings(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile %q: %s", profile, err.Error())
+	}
+	source := &credentialSource{Type: sdkSourceType, Profile: profile}
+
+	switch {
+	// roles assumed from a source_profile or credential_source are left to the SDK
+	case settings["role_arn"] != "" && settings["web_identity_token_file"] == "":
+	case settings["sso_session"] != "" || settings["sso_start_url"] != "":
+		source.Type = credentialSourceSSO
+		source.SSOSession = settings["sso_session"]
+		source.SSOStartURL = settings["sso_start_url"]
+		source.SSORegion = settings["sso_region"]
+		source.SSOAccountId = settings["sso_account_id"]
+		source.SSORoleName = settings["sso_role_name"]
+	case settings["web_identity_token_file"] != "":
+		source.Type = credentialSourceWebIdentity
+		source.WebIdentityTokenFile = settings["web_identity_token_file"]
+		source.WebIdentityRoleArn = settings["role_arn"]
+		source.RoleSessionName = settings["role_session_name"]
+	case settings["credential_process"] != "":
+		source.Type = credentialSourceCredentialProcess
+		source.CredentialProcess = settings["credential_process"]
+	}
+	return source, validateCredentialSource(source)
+}
+
+// validateCredentialSource checks that a credential source has the settings it needs, and
+// reads the start URL and region of SSO sources from their sso_session
+func validateCredentialSource(source *credentialSource) error {
+	describe := "the connection config"
+	if source.Profile != "" {
+		describe = fmt.Sprintf("profile %q", source.Profile)
+	}
+
+	var missing []string
+	switch source.Type {
+	case credentialSourceSSO:
+		if source.SSOSession != "" {
+			configFile, _ := getSharedConfigFiles()
+			settings, found, err := readIniSections(configFile, func(section string) bool {
+				return section == "sso-session "+source.SSOSession
+			})
+			if err != nil {
+				return fmt.Errorf("failed to read sso_session %q: %s", source.SSOSession, err.Error())
+			}
+			if !found {
+				return fmt.Errorf("%s uses sso_session %q, which is not defined in %s", describe, source.SSOSession, configFile)
+			}
+			// settings of the connection config take precedence over the session's
+			if source.SSOStartURL == "" {
+				source.SSOStartURL = settings["sso_start_url"]
+			}
+			if source.SSORegion == "" {
+				source.SSORegion = settings["sso_region"]
+			}
+		}
+		if source.SSOStartURL == "" {
+			missing = append(missing, "sso_start_url")
+		}
+		if source.SSORegion == "" {
+			missing = append(missing, "sso_region")
+		}
+		if source.SSOAccountId == "" {
+			missing = append(missing, "sso_account_id")
+		}
+		if source.SSORoleName == "" {
+			missing = append(missing, "sso_role_name")
+		}
+	case credentialSourceWebIdentity:
+		if source.WebIdentityTokenFile == "" {
+			missing = append(missing, "web_identity_token_file")
+		}
+		if source.WebIdentityRoleArn == "" {
+			missing = append(missing, "web_identity_role_arn")
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%s uses %s credentials, missing: %s", describe, source.Type, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// getCredentialSourceCredentials returns the credentials of an SSO, web identity or
+// credential_process source, or nil if the SDK resolves the connection's credentials
+func getCredentialSourceCredentials(ctx context.Context, d *plugin.QueryData) (*credentials.Credentials, error) {
+	cacheKey := connectionCacheKey(d, "CredentialSourceCredentials")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*credentials.Credentials), nil
+	}
+
+	awsConfig := GetConfig(d.Connection)
+	source, err := resolveCredentialSource(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds *credentials.Credentials
+	switch source.Type {
+	case credentialSourceSSO:
+		sess, err := newCredentialSourceSession(ctx, d, source.SSORegion)
+		if err != nil {
+			return nil, err
+		}
+		// the token of an sso_session is cached by the session name, instead of the start URL
+		cacheName := source.SSOStartURL
+		if source.SSOSession != "" {
+			cacheName = source.SSOSession
+		}
+		creds = credentials.NewCredentials(&describedCredentialsProvider{
+			creds:    ssocreds.NewCredentialsWithClient(sso.New(sess), source.SSOAccountId, source.SSORoleName, cacheName),
+			describe: source.describeSSOError,
+		})
+
+	case credentialSourceWebIdentity:
+		sess, err := newCredentialSourceSession(ctx, d, GetDefaultAwsRegion(d))
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewCredentials(&describedCredentialsProvider{
+			creds: credentials.NewCredentials(stscreds.NewWebIdentityRoleProvider(sts.New(sess), source.WebIdentityRoleArn, source.RoleSessionName, source.WebIdentityTokenFile)),
+			describe: func(err error) string {
+				return fmt.Sprintf("failed to assume %s with the web identity token in %s", source.WebIdentityRoleArn, source.WebIdentityTokenFile)
+			},
+		})
+
+	case credentialSourceCredentialProcess:
+		creds = credentials.NewCredentials(&describedCredentialsProvider{
+			creds: processcreds.NewCredentials(source.CredentialProcess),
+			describe: func(err error) string {
+				return fmt.Sprintf("credential_process %q failed", source.CredentialProcess)
+			},
+		})
+
+	default:
+		// cache the nil credentials too, so the profile is read once
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, creds)
+	return creds, nil
+}
+
+// newCredentialSourceSession returns an unsigned session, for the SSO and STS calls which
+// exchange a token for credentials
+func newCredentialSourceSession(ctx context.Context, d *plugin.QueryData, region string) (*session.Session, error) {
+	sessionOptions := session.Options{
+		Config: aws.Config{
+			Region:      aws.String(region),
+			Credentials: credentials.AnonymousCredentials,
+		},
+	}
+	return newHttpSession(ctx, d, sessionOptions)
+}
+
+func (source *credentialSource) describeSSOError(err error) string {
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ssocreds.ErrCodeSSOProviderInvalidToken {
+		switch {
+		case source.Profile != "":
+			return fmt.Sprintf("SSO token expired or missing, run aws sso login --profile %s", source.Profile)
+		case source.SSOSession != "":
+			return fmt.Sprintf("SSO token expired or missing, run aws sso login --sso-session %s", source.SSOSession)
+		default:
+			return fmt.Sprintf("SSO token expired or missing, run aws sso login for %s", source.SSOStartURL)
+		}
+	}
+	return fmt.Sprintf("failed to get SSO credentials for role %s in account %s", source.SSORoleName, source.SSOAccountId)
+}
+
+// describedCredentialsProvider wraps the credentials of an SDK provider, and replaces its errors,
+// e.g. "the SSO session has expired or is invalid", with errors which say what to do about them.
+// The error code of the SDK is kept.
+type describedCredentialsProvider struct {
+	creds    *credentials.Credentials
+	describe func(err error) string
+}
+
+func (p *describedCredentialsProvider) Retrieve() (credentials.Value, error) {
+	value, err := p.creds.Get()
+	if err != nil {
+		code := "CredentialSourceError"
+		if awsErr, ok := err.(awserr.Error); ok {
+			code = awsErr.Code()
+		}
+		return credentials.Value{}, awserr.New(code, p.describe(err), err)
+	}
+	return value, nil
+}
+
+func (p *describedCredentialsProvider) IsExpired() bool {
+	return p.creds.IsExpired()
+}
+
+func (p *describedCredentialsProvider) ExpiresAt() time.Time {
+	expiresAt, _ := p.creds.ExpiresAt()
+	return expiresAt
+}
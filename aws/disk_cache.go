@@ -0,0 +1,204 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// TTLs of the values kept in the disk cache. The in-memory cache of the connection is checked
+// first, so these only apply across plugin restarts.
+const (
+	// regions are rarely launched, and opt-in changes need a new query anyway
+	regionCatalogueDiskCacheTTL = 24 * time.Hour
+	callerIdentityDiskCacheTTL  = 12 * time.Hour
+	// IAM generates a new credential report at most every 4 hours, when one is requested
+	credentialReportDiskCacheTTL = 1 * time.Hour
+	// Cost Explorer data is refreshed at least once every 24 hours, and each call costs $0.01
+	costExplorerDiskCacheTTL = 6 * time.Hour
+)
+
+var diskCacheUnsafeChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// diskCacheEntry is the content of a disk cache file
+type diskCacheEntry struct {
+	Key     string          `json:"key"`
+	Expires time.Time       `json:"expires"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// getDiskCacheDir returns the directory of the disk cache of a connection, or "" if cache_dir
+// is not set. The values of each connection are kept in their own directory.
+func getDiskCacheDir(d *plugin.QueryData) (string, error) {
+	awsConfig := GetConfig(d.Connection)
+	if awsConfig.CacheDir == nil || d.Connection == nil {
+		return "", nil
+	}
+	cacheDir, err := expandDiskCacheDir(*awsConfig.CacheDir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, diskCacheUnsafeChars.ReplaceAllString(d.Connection.Name, "_")), nil
+}
+
+// expandDiskCacheDir expands a leading ~ of cache_dir to the home directory
+func expandDiskCacheDir(cacheDir string) (string, error) {
+	if cacheDir != "~" && !strings.HasPrefix(cacheDir, "~/") {
+		return cacheDir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand cache_dir %q: %s", cacheDir, err.Error())
+	}
+	return filepath.Join(home, strings.TrimPrefix(cacheDir, "~")), nil
+}
+
+// validateDiskCacheDir checks that cache_dir is not a file. A missing directory is created when
+// the first value is cached.
+func validateDiskCacheDir(cacheDir string) error {
+	cacheDir, err := expandDiskCacheDir(cacheDir)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(cacheDir); err == nil && !info.IsDir() {
+		return fmt.Errorf("cache_dir %q is not a directory", cacheDir)
+	}
+	return nil
+}
+
+// getDiskCacheFile returns the file of a cache key. The name is a hash of the key, the
+// connection config and the access key ID of the connection's credentials, so values cached
+// before the config was changed, or for other credentials, e.g. of another AWS_PROFILE or after
+// the keys of the profile were changed, are not used.
+func getDiskCacheFile(ctx context.Context, d *plugin.QueryData, cacheDir string, key string) (string, error) {
+	config, err := json.Marshal(GetConfig(d.Connection))
+	if err != nil {
+		return "", err
+	}
+	accessKeyId, err := getDiskCacheAccessKeyId(ctx, d)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(config)
+	hash.Write([]byte{0})
+	hash.Write([]byte(accessKeyId))
+	hash.Write([]byte{0})
+	hash.Write([]byte(key))
+	return filepath.Join(cacheDir, hex.EncodeToString(hash.Sum(nil))+".json"), nil
+}
+
+// getDiskCacheAccessKeyId returns the access key ID of the credentials the values are fetched
+// with. Temporary credentials, e.g. of an assumed role or SSO, get a new access key ID when they
+// are renewed, so their values are only reused until then.
+func getDiskCacheAccessKeyId(ctx context.Context, d *plugin.QueryData) (string, error) {
+	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
+	if err != nil {
+		return "", err
+	}
+	creds, err := sess.Config.Credentials.GetWithContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	return creds.AccessKeyID, nil
+}
+
+// diskCacheGet decodes the value cached on disk under a key into value, and returns false if
+// the disk cache is not enabled, or the value is missing or expired. Failures to read the cache
+// are logged and treated as a miss.
+func diskCacheGet(ctx context.Context, d *plugin.QueryData, key string, value interface{}) bool {
+	cacheDir, err := getDiskCacheDir(d)
+	if err != nil || cacheDir == "" {
+		return false
+	}
+	filename, err := getDiskCacheFile(ctx, d, cacheDir, key)
+	if err != nil {
+		plugin.Logger(ctx).Warn("diskCacheGet", "key", key, "credentials_error", err)
+		return false
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			plugin.Logger(ctx).Warn("diskCacheGet", "key", key, "read_error", err)
+		}
+		return false
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil || entry.Key != key {
+		plugin.Logger(ctx).Warn("diskCacheGet", "key", key, "invalid_entry", filename)
+		return false
+	}
+	if !time.Now().Before(entry.Expires) {
+		os.Remove(filename)
+		return false
+	}
+	if err := json.Unmarshal(entry.Value, value); err != nil {
+		plugin.Logger(ctx).Warn("diskCacheGet", "key", key, "decode_error", err)
+		return false
+	}
+	return true
+}
+
+// diskCacheSet caches a value on disk under a key for the given TTL, if the disk cache is
+// enabled. The value is encoded as JSON, so only its exported fields are kept. Failures to
+// write the cache are logged, since the value is still in the in-memory cache.
+func diskCacheSet(ctx context.Context, d *plugin.QueryData, key string, value interface{}, ttl time.Duration) {
+	cacheDir, err := getDiskCacheDir(d)
+	if err != nil || cacheDir == "" {
+		return
+	}
+	if err := writeDiskCacheEntry(ctx, d, cacheDir, key, value, ttl); err != nil {
+		plugin.Logger(ctx).Warn("diskCacheSet", "key", key, "write_error", err)
+	}
+}
+
+func writeDiskCacheEntry(ctx context.Context, d *plugin.QueryData, cacheDir string, key string, value interface{}, ttl time.Duration) error {
+	filename, err := getDiskCacheFile(ctx, d, cacheDir, key)
+	if err != nil {
+		return err
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(diskCacheEntry{
+		Key:     key,
+		Expires: time.Now().Add(ttl),
+		Value:   encodedValue,
+	})
+	if err != nil {
+		return err
+	}
+
+	// the cache may hold account details, so it is only readable by the user
+	if err := os.MkdirAll(cacheDir, 0700); err != nil {
+		return err
+	}
+	// write to a temporary file first, so other plugin processes never read a partial entry
+	file, err := ioutil.TempFile(cacheDir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), filename)
+}
@@ -0,0 +1,122 @@
+package aws
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// name of the filter of a tags column, a tag:<key> filter is added per tag
+const ec2TagFilter = "tag"
+
+// maximum number of values of an EC2 filter, a call with more fails with FilterLimitExceeded
+const ec2MaxFilterValues = 200
+
+// ec2FilterColumn maps the = and in quals of a column to a filter of an EC2 Describe* call,
+// so the call only returns the matching resources. Postgres still checks the quals, so a
+// filter may return more resources than the quals match, but never fewer.
+type ec2FilterColumn struct {
+	// name of the column
+	Column string
+	// name of the filter, e.g. vpc-id, or ec2TagFilter for the tags column
+	Filter string
+	// type of the column. String, bool, int and IP address values are passed to the filter as strings.
+	Type proto.ColumnType
+}
+
+// ec2FilterKeyColumns returns the optional key columns of a list config, so the quals of the
+// filter columns are passed to the list function
+func ec2FilterKeyColumns(filterColumns []ec2FilterColumn) []*plugin.KeyColumn {
+	var keyColumns []*plugin.KeyColumn
+	for _, filterColumn := range filterColumns {
+		keyColumns = append(keyColumns, &plugin.KeyColumn{Name: filterColumn.Column, Require: plugin.Optional})
+	}
+	return keyColumns
+}
+
+// buildEc2Filters returns the filters of the quals of the filter columns, in the order of the
+// filter columns. Quals of a tags column, e.g. tags = '{"env": "prod"}', add a tag:<key> filter
+// per tag. Quals on a single tag, e.g. tags ->> 'env' = 'prod', can't be pushed down: Steampipe
+// only passes the quals of a column to the plugin, not those of an expression on it, so these
+// queries list all the resources and Postgres filters the rows. An in qual with more values
+// than EC2 accepts is not passed to the filter either, Postgres checks it.
+func buildEc2Filters(equalQuals plugin.KeyColumnEqualsQualMap, filterColumns []ec2FilterColumn) []*ec2.Filter {
+	filters := make([]*ec2.Filter, 0)
+	for _, filterColumn := range filterColumns {
+		value := equalQuals[filterColumn.Column]
+		if value == nil {
+			continue
+		}
+
+		if filterColumn.Filter == ec2TagFilter {
+			filters = append(filters, ec2TagFilters(value)...)
+			continue
+		}
+
+		var values []*string
+		if listValue := value.GetListValue(); listValue != nil {
+			for _, listItem := range listValue.Values {
+				if filterValue, ok := ec2FilterValue(listItem, filterColumn.Type); ok {
+					values = append(values, aws.String(filterValue))
+				}
+			}
+		} else if filterValue, ok := ec2FilterValue(value, filterColumn.Type); ok {
+			values = append(values, aws.String(filterValue))
+		}
+		if len(values) > 0 && len(values) <= ec2MaxFilterValues {
+			filters = append(filters, &ec2.Filter{
+				Name:   aws.String(filterColumn.Filter),
+				Values: values,
+			})
+		}
+	}
+	return filters
+}
+
+// ec2FilterValue returns the filter value of a qual value, or false if the type is not supported
+func ec2FilterValue(value *proto.QualValue, columnType proto.ColumnType) (string, bool) {
+	switch columnType {
+	case proto.ColumnType_BOOL:
+		return strconv.FormatBool(value.GetBoolValue()), true
+	case proto.ColumnType_INT:
+		return strconv.FormatInt(value.GetInt64Value(), 10), true
+	case proto.ColumnType_STRING:
+		return value.GetStringValue(), true
+	case proto.ColumnType_IPADDR:
+		return value.GetInetValue().GetAddr(), true
+	case proto.ColumnType_CIDR:
+		return value.GetInetValue().GetCidr(), true
+	}
+	return "", false
+}
+
+// ec2TagFilters returns a tag:<key> filter per tag of a tags qual. Tags without a string
+// value are skipped, since the filter can't match them.
+func ec2TagFilters(value *proto.QualValue) []*ec2.Filter {
+	var tags map[string]interface{}
+	if err := json.Unmarshal([]byte(value.GetJsonbValue()), &tags); err != nil {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var filters []*ec2.Filter
+	for _, key := range keys {
+		if tagValue, ok := tags[key].(string); ok {
+			filters = append(filters, &ec2.Filter{
+				Name:   aws.String(ec2TagFilter + ":" + key),
+				Values: []*string{aws.String(tagValue)},
+			})
+		}
+	}
+	return filters
+}
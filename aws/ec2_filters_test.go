@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestBuildEc2Filters(t *testing.T) {
+	var manyStates []*proto.QualValue
+	for i := 0; i <= ec2MaxFilterValues; i++ {
+		manyStates = append(manyStates, proto.NewQualValue(fmt.Sprintf("state-%d", i)))
+	}
+
+	filterColumns := []ec2FilterColumn{
+		{Column: "state", Filter: "status", Type: proto.ColumnType_STRING},
+		{Column: "encrypted", Filter: "encrypted", Type: proto.ColumnType_BOOL},
+		{Column: "size", Filter: "size", Type: proto.ColumnType_INT},
+		{Column: "private_ip_address", Filter: "private-ip-address", Type: proto.ColumnType_IPADDR},
+		{Column: "cidr_block", Filter: "cidr-block", Type: proto.ColumnType_CIDR},
+		{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		quals    plugin.KeyColumnEqualsQualMap
+		expected map[string][]string
+	}{
+		{
+			name:     "no quals",
+			quals:    plugin.KeyColumnEqualsQualMap{},
+			expected: map[string][]string{},
+		},
+		{
+			name: "typed quals",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"encrypted":          proto.NewQualValue(true),
+				"size":               proto.NewQualValue(int64(100)),
+				"private_ip_address": {Value: &proto.QualValue_InetValue{InetValue: &proto.Inet{Addr: "10.0.0.1"}}},
+				"cidr_block":         {Value: &proto.QualValue_InetValue{InetValue: &proto.Inet{Cidr: "10.0.0.0/24"}}},
+			},
+			expected: map[string][]string{
+				"encrypted":          {"true"},
+				"size":               {"100"},
+				"private-ip-address": {"10.0.0.1"},
+				"cidr-block":         {"10.0.0.0/24"},
+			},
+		},
+		{
+			name: "in qual",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"state": {Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: []*proto.QualValue{
+					proto.NewQualValue("available"),
+					proto.NewQualValue("in-use"),
+				}}}},
+			},
+			expected: map[string][]string{
+				"status": {"available", "in-use"},
+			},
+		},
+		{
+			name: "in qual over the filter value limit",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"state":     {Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: manyStates}}},
+				"encrypted": proto.NewQualValue(true),
+			},
+			expected: map[string][]string{
+				"encrypted": {"true"},
+			},
+		},
+		{
+			name: "tags qual",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"tags": {Value: &proto.QualValue_JsonbValue{JsonbValue: `{"env": "prod", "team": "web", "count": 1}`}},
+			},
+			expected: map[string][]string{
+				// values which aren't strings can't match a tag
+				"tag:env":  {"prod"},
+				"tag:team": {"web"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := ec2FiltersByName(buildEc2Filters(testCase.quals, filterColumns))
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected filters %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/connection"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// The fakes implement the calls the tests need, and embed the SDK interface of their service,
+// so any other call panics. Register them for a test with registerServiceClient, e.g.
+//
+//	defer registerServiceClient("ec2", &fakeEC2{Regions: []string{"us-east-1"}})()
+//
+// The hydrate functions of a query run concurrently, so the fakes lock their recorded inputs.
+
+// fakeEC2 answers DescribeRegions, so the region matrix can be built, and DescribeInstances
+type fakeEC2 struct {
+	ec2iface.EC2API
+
+	Regions   []string
+	Instances []*ec2.Instance
+
+	mutex                   sync.Mutex
+	describeInstancesInputs []*ec2.DescribeInstancesInput
+}
+
+func (f *fakeEC2) DescribeRegions(input *ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	output := &ec2.DescribeRegionsOutput{}
+	for _, region := range f.Regions {
+		output.Regions = append(output.Regions, &ec2.Region{
+			RegionName:  aws.String(region),
+			OptInStatus: aws.String("opt-in-not-required"),
+		})
+	}
+	return output, nil
+}
+
+// DescribeInstancesPages returns a reservation per instance, in pages of MaxResults instances
+func (f *fakeEC2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	f.mutex.Lock()
+	f.describeInstancesInputs = append(f.describeInstancesInputs, input)
+	f.mutex.Unlock()
+
+	pageSize := int(aws.Int64Value(input.MaxResults))
+	if pageSize == 0 {
+		pageSize = len(f.Instances)
+	}
+	for start := 0; start < len(f.Instances); start += pageSize {
+		end := start + pageSize
+		if end > len(f.Instances) {
+			end = len(f.Instances)
+		}
+		page := &ec2.DescribeInstancesOutput{}
+		for _, instance := range f.Instances[start:end] {
+			page.Reservations = append(page.Reservations, &ec2.Reservation{Instances: []*ec2.Instance{instance}})
+		}
+		if !fn(page, end == len(f.Instances)) {
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeEC2) DescribeInstancesInputs() []*ec2.DescribeInstancesInput {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]*ec2.DescribeInstancesInput{}, f.describeInstancesInputs...)
+}
+
+// fakeIAM answers ListUsers and GetUser, and GetAccountAuthorizationDetails and the role calls
+// it replaces from AuthorizationDetails. It counts the calls per operation.
+type fakeIAM struct {
+	iamiface.IAMAPI
+
+	Users []*iam.User
+
+	AuthorizationDetails *iam.GetAccountAuthorizationDetailsOutput
+	// returned by GetAccountAuthorizationDetails instead, e.g. AccessDenied
+	AuthorizationDetailsError error
+
+	mutex sync.Mutex
+	calls map[string]int
+}
+
+func (f *fakeIAM) called(operation string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[operation]++
+}
+
+func (f *fakeIAM) Calls(operation string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.calls[operation]
+}
+
+func (f *fakeIAM) GetAccountAuthorizationDetailsPages(input *iam.GetAccountAuthorizationDetailsInput, fn func(*iam.GetAccountAuthorizationDetailsOutput, bool) bool) error {
+	f.called("GetAccountAuthorizationDetails")
+	if f.AuthorizationDetailsError != nil {
+		return f.AuthorizationDetailsError
+	}
+	fn(f.AuthorizationDetails, true)
+	return nil
+}
+
+func (f *fakeIAM) role(name *string) (*iam.RoleDetail, error) {
+	for _, role := range f.AuthorizationDetails.RoleDetailList {
+		if aws.StringValue(role.RoleName) == aws.StringValue(name) {
+			return role, nil
+		}
+	}
+	return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "The role cannot be found.", nil)
+}
+
+func (f *fakeIAM) ListRolesPages(input *iam.ListRolesInput, fn func(*iam.ListRolesOutput, bool) bool) error {
+	f.called("ListRoles")
+	output := &iam.ListRolesOutput{}
+	for _, role := range f.AuthorizationDetails.RoleDetailList {
+		output.Roles = append(output.Roles, &iam.Role{
+			Arn:                      role.Arn,
+			AssumeRolePolicyDocument: role.AssumeRolePolicyDocument,
+			CreateDate:               role.CreateDate,
+			Path:                     role.Path,
+			RoleId:                   role.RoleId,
+			RoleName:                 role.RoleName,
+		})
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *fakeIAM) ListAttachedRolePolicies(input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	f.called("ListAttachedRolePolicies")
+	role, err := f.role(input.RoleName)
+	if err != nil {
+		return nil, err
+	}
+	return &iam.ListAttachedRolePoliciesOutput{AttachedPolicies: role.AttachedManagedPolicies}, nil
+}
+
+func (f *fakeIAM) ListRolePolicies(input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	f.called("ListRolePolicies")
+	role, err := f.role(input.RoleName)
+	if err != nil {
+		return nil, err
+	}
+	output := &iam.ListRolePoliciesOutput{}
+	for _, policy := range role.RolePolicyList {
+		output.PolicyNames = append(output.PolicyNames, policy.PolicyName)
+	}
+	return output, nil
+}
+
+func (f *fakeIAM) GetRolePolicy(input *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	f.called("GetRolePolicy")
+	role, err := f.role(input.RoleName)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range role.RolePolicyList {
+		if aws.StringValue(policy.PolicyName) == aws.StringValue(input.PolicyName) {
+			return &iam.GetRolePolicyOutput{RoleName: role.RoleName, PolicyName: policy.PolicyName, PolicyDocument: policy.PolicyDocument}, nil
+		}
+	}
+	return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "The role policy cannot be found.", nil)
+}
+
+func (f *fakeIAM) ListUsersPages(input *iam.ListUsersInput, fn func(*iam.ListUsersOutput, bool) bool) error {
+	fn(&iam.ListUsersOutput{Users: f.Users}, true)
+	return nil
+}
+
+func (f *fakeIAM) GetUser(input *iam.GetUserInput) (*iam.GetUserOutput, error) {
+	for _, user := range f.Users {
+		if aws.StringValue(user.UserName) == aws.StringValue(input.UserName) {
+			return &iam.GetUserOutput{User: user}, nil
+		}
+	}
+	return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "The user cannot be found.", nil)
+}
+
+// fakeS3 answers ListBuckets, HeadBucket and GetBucketLocation
+type fakeS3 struct {
+	s3iface.S3API
+
+	Buckets []*s3.Bucket
+	// location constraint of each bucket, which S3 leaves empty for us-east-1
+	BucketRegions map[string]string
+	// HeadBucket responses have no x-amz-bucket-region header, as for some S3 compatible endpoints
+	NoBucketRegionHeader bool
+
+	mutex sync.Mutex
+	calls map[string]int
+}
+
+func (f *fakeS3) called(operation string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[operation]++
+}
+
+func (f *fakeS3) Calls(operation string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.calls[operation]
+}
+
+// HeadBucketRequest returns a request which is answered without calling S3, like S3 answers
+// a client of the default region: with a redirect for buckets in other regions
+func (f *fakeS3) HeadBucketRequest(input *s3.HeadBucketInput) (*request.Request, *s3.HeadBucketOutput) {
+	f.called("HeadBucket")
+	req, output := s3.New(unit.Session).HeadBucketRequest(input)
+	req.Handlers.Send.Clear()
+	req.Handlers.Send.PushBack(func(r *request.Request) {
+		response := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+		region := f.BucketRegions[aws.StringValue(input.Bucket)]
+		if region == "" {
+			region = "us-east-1"
+		} else {
+			response.StatusCode = http.StatusMovedPermanently
+		}
+		if !f.NoBucketRegionHeader {
+			response.Header.Set("X-Amz-Bucket-Region", region)
+		}
+		r.HTTPResponse = response
+	})
+	return req, output
+}
+
+func (f *fakeS3) ListBuckets(input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
+	f.called("ListBuckets")
+	return &s3.ListBucketsOutput{Buckets: f.Buckets}, nil
+}
+
+func (f *fakeS3) GetBucketLocation(input *s3.GetBucketLocationInput) (*s3.GetBucketLocationOutput, error) {
+	f.called("GetBucketLocation")
+	output := &s3.GetBucketLocationOutput{}
+	if region := f.BucketRegions[aws.StringValue(input.Bucket)]; region != "" {
+		output.LocationConstraint = aws.String(region)
+	}
+	return output, nil
+}
+
+// fakeOrganizations answers ListAccounts, and the calls which read the service control policies
+// of an account, from the parent of each target and the policies attached to it
+type fakeOrganizations struct {
+	organizationsiface.OrganizationsAPI
+
+	Organization *organizations.Organization
+	// returned by DescribeOrganization instead, e.g. AWSOrganizationsNotInUseException
+	OrganizationError error
+	Roots             []*organizations.Root
+	Parents           map[string]*organizations.Parent
+	Policies          map[string][]*organizations.Policy
+	Accounts          []*organizations.Account
+	// returned by ListAccounts instead, e.g. AccessDeniedException
+	AccountsError error
+}
+
+func (f *fakeOrganizations) ListAccountsPages(input *organizations.ListAccountsInput, fn func(*organizations.ListAccountsOutput, bool) bool) error {
+	if f.AccountsError != nil {
+		return f.AccountsError
+	}
+	fn(&organizations.ListAccountsOutput{Accounts: f.Accounts}, true)
+	return nil
+}
+
+func (f *fakeOrganizations) DescribeOrganization(input *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error) {
+	if f.OrganizationError != nil {
+		return nil, f.OrganizationError
+	}
+	return &organizations.DescribeOrganizationOutput{Organization: f.Organization}, nil
+}
+
+func (f *fakeOrganizations) ListParents(input *organizations.ListParentsInput) (*organizations.ListParentsOutput, error) {
+	output := &organizations.ListParentsOutput{}
+	if parent, ok := f.Parents[aws.StringValue(input.ChildId)]; ok {
+		output.Parents = append(output.Parents, parent)
+	}
+	return output, nil
+}
+
+func (f *fakeOrganizations) ListRootsPages(input *organizations.ListRootsInput, fn func(*organizations.ListRootsOutput, bool) bool) error {
+	fn(&organizations.ListRootsOutput{Roots: f.Roots}, true)
+	return nil
+}
+
+func (f *fakeOrganizations) ListPoliciesForTargetPages(input *organizations.ListPoliciesForTargetInput, fn func(*organizations.ListPoliciesForTargetOutput, bool) bool) error {
+	output := &organizations.ListPoliciesForTargetOutput{}
+	for _, policy := range f.Policies[aws.StringValue(input.TargetId)] {
+		output.Policies = append(output.Policies, policy.PolicySummary)
+	}
+	fn(output, true)
+	return nil
+}
+
+func (f *fakeOrganizations) DescribePolicy(input *organizations.DescribePolicyInput) (*organizations.DescribePolicyOutput, error) {
+	for _, policies := range f.Policies {
+		for _, policy := range policies {
+			if aws.StringValue(policy.PolicySummary.Id) == aws.StringValue(input.PolicyId) {
+				return &organizations.DescribePolicyOutput{Policy: policy}, nil
+			}
+		}
+	}
+	return nil, awserr.New(organizations.ErrCodePolicyNotFoundException, "The policy cannot be found.", nil)
+}
+
+// fakeSTS answers GetCallerIdentity, and counts the calls
+type fakeSTS struct {
+	stsiface.STSAPI
+
+	Identity *sts.GetCallerIdentityOutput
+
+	mutex                  sync.Mutex
+	getCallerIdentityCalls int
+}
+
+func (f *fakeSTS) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.getCallerIdentityCalls++
+	return f.Identity, nil
+}
+
+func (f *fakeSTS) GetCallerIdentityCalls() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.getCallerIdentityCalls
+}
+
+func newFakeCallerIdentity(partition string, accountId string) *sts.GetCallerIdentityOutput {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(accountId),
+		Arn:     aws.String("arn:" + partition + ":iam::" + accountId + ":user/test"),
+		UserId:  aws.String("AIDATESTUSER"),
+	}
+}
+
+// newFakeTableTestPlugin returns the plugin with a connection in us-east-1, for queries
+// against fakes registered with registerServiceClient
+func newFakeTableTestPlugin(t *testing.T, connectionName string) *plugin.Plugin {
+	config := `regions = ["us-east-1"]` + "\n"
+	config += `access_key = "` + scrubbedAccessKeyId + `"` + "\n"
+	config += `secret_key = "` + scrubbedSecretOrToken + `"` + "\n"
+
+	p := Plugin(context.Background())
+	p.Logger = hclog.NewNullLogger()
+	p.ConnectionManager = connection.NewManager()
+	p.SchemaMode = plugin.SchemaModeStatic
+	if err := p.SetConnectionConfig(connectionName, config); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
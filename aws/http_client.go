@@ -0,0 +1,206 @@
+package aws
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// a hung connection or TLS handshake fails the attempt after this, so it can be retried
+	defaultHttpConnectTimeout = 10 * time.Second
+	// time to wait for the response headers of a request, once it is sent
+	defaultHttpReadTimeout = 60 * time.Second
+)
+
+// newHttpSession creates a session with the HTTP client of the connection, see getHttpClient. The SDK
+// loads the CA bundle of a session (AWS_CA_BUNDLE or ca_bundle of the profile) into the transport of
+// the session's HTTP client, so the session is created with a client of its own, which only takes the
+// CA bundle, and is given the shared client of the connection once created. The shared client has the
+// CA bundle already.
+//
+// The rate limiter, the HTTP record/replay handler and the API call stats are installed on every
+// session, so they also apply to the STS and SSO calls of the credential providers built from it.
+func newHttpSession(ctx context.Context, d *plugin.QueryData, options session.Options) (*session.Session, error) {
+	httpClient, err := getHttpClient(d)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Config.HTTPClient = &http.Client{}
+	sess, err := session.NewSessionWithOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	sess.Config.HTTPClient = httpClient
+
+	// limit the request rate of each service, across the sessions of all regions
+	limiters, err := getRateLimiters(d)
+	if err != nil {
+		return nil, err
+	}
+	sess.Handlers.Sign.PushFrontNamed(rateLimitHandler(limiters))
+
+	// record or replay the HTTP traffic, e.g. for the table tests
+	if httpMode, httpFixtureFile := getHttpRecordReplayMode(GetConfig(d.Connection)); httpMode != "" {
+		transport, err := getRecordReplayTransport(httpMode, httpFixtureFile)
+		if err != nil {
+			return nil, err
+		}
+		sess.Handlers.Send.PushFrontNamed(transport.SendHandler())
+	}
+
+	// count the API calls of the session, see the aws_plugin_api_call_stat table
+	installApiCallStatHandlers(&sess.Handlers, plugin.Logger(ctx), d)
+
+	return sess, nil
+}
+
+// getHttpClient returns the HTTP client of a connection, which is shared by all its sessions, so
+// they share its connection pool
+func getHttpClient(d *plugin.QueryData) (*http.Client, error) {
+	cacheKey := connectionCacheKey(d, "HttpClient")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*http.Client), nil
+	}
+
+	awsConfig := GetConfig(d.Connection)
+	httpClient, err := newHttpClient(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	caBundle, err := getCABundle(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if caBundle != nil {
+		rootCAs := x509.NewCertPool()
+		rootCAs.AppendCertsFromPEM(caBundle)
+		httpClient.Transport.(*http.Transport).TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, httpClient)
+	return httpClient, nil
+}
+
+// getCABundle returns the PEM certificates of the CA bundle of a connection, or nil if it has none.
+// As for the SDK, ca_bundle_path of the connection config takes precedence over AWS_CA_BUNDLE, then
+// ca_bundle of the profile in the AWS config file.
+func getCABundle(awsConfig awsConfig) ([]byte, error) {
+	if awsConfig.CaBundlePath != nil {
+		return loadCABundle(*awsConfig.CaBundlePath)
+	}
+	if path := os.Getenv("AWS_CA_BUNDLE"); path != "" {
+		return loadCABundle(path)
+	}
+
+	profile := os.Getenv("AWS_PROFILE")
+	if awsConfig.Profile != nil {
+		profile = *awsConfig.Profile
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	settings, _, err := loadProfileSettings(profile)
+	if err != nil {
+		return nil, err
+	}
+	if path := settings["ca_bundle"]; path != "" {
+		return loadCABundle(path)
+	}
+	return nil, nil
+}
+
+// newHttpClient returns an HTTP client with the proxy, timeouts and connection pool of the
+// connection config. The CA bundle is added by getHttpClient.
+func newHttpClient(awsConfig awsConfig) (*http.Client, error) {
+	connectTimeout := defaultHttpConnectTimeout
+	if awsConfig.ConnectTimeout != nil {
+		if *awsConfig.ConnectTimeout < 0 {
+			return nil, fmt.Errorf("connect_timeout must be >= 0")
+		}
+		connectTimeout = time.Duration(*awsConfig.ConnectTimeout) * time.Second
+	}
+	readTimeout := defaultHttpReadTimeout
+	if awsConfig.ReadTimeout != nil {
+		if *awsConfig.ReadTimeout < 0 {
+			return nil, fmt.Errorf("read_timeout must be >= 0")
+		}
+		readTimeout = time.Duration(*awsConfig.ReadTimeout) * time.Second
+	}
+
+	proxy, err := getProxyFunc(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// start from the defaults of the standard library, e.g. for HTTP/2 and idle connections
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.DialContext = (&net.Dialer{
+		Timeout:   connectTimeout,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+	transport.ResponseHeaderTimeout = readTimeout
+	if awsConfig.MaxIdleConns != nil {
+		if *awsConfig.MaxIdleConns < 0 {
+			return nil, fmt.Errorf("max_idle_conns must be >= 0")
+		}
+		// all requests of a session go to the same host, so keep as many connections per host
+		transport.MaxIdleConns = *awsConfig.MaxIdleConns
+		transport.MaxIdleConnsPerHost = *awsConfig.MaxIdleConns
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// getProxyFunc returns the proxy settings of the environment, e.g. HTTPS_PROXY and NO_PROXY,
+// overridden by https_proxy and no_proxy in the connection config
+func getProxyFunc(awsConfig awsConfig) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if awsConfig.HttpsProxy != nil {
+		proxyConfig.HTTPSProxy = *awsConfig.HttpsProxy
+		// the proxy URL is checked here, instead of failing each request
+		proxyUrl := *awsConfig.HttpsProxy
+		if !strings.Contains(proxyUrl, "://") {
+			proxyUrl = "http://" + proxyUrl
+		}
+		if parsed, err := url.Parse(proxyUrl); err != nil || parsed.Host == "" {
+			return nil, fmt.Errorf("invalid https_proxy %q, expected a URL like http://proxy.example.com:3128", *awsConfig.HttpsProxy)
+		}
+	}
+	if awsConfig.NoProxy != nil {
+		proxyConfig.NoProxy = strings.Join(awsConfig.NoProxy, ",")
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// loadCABundle reads the PEM certificates of a CA bundle, e.g. ca_bundle_path
+func loadCABundle(path string) ([]byte, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %s", err.Error())
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s has no PEM certificates", path)
+	}
+	return pem, nil
+}
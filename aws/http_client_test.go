@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// setTestEnv sets (or with an empty value, unsets) environment variables, and returns a function
+// which restores them
+func setTestEnv(t *testing.T, env map[string]string) func() {
+	previous := map[string]*string{}
+	for key, value := range env {
+		if old, ok := os.LookupEnv(key); ok {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		var err error
+		if value == "" {
+			err = os.Unsetenv(key)
+		} else {
+			err = os.Setenv(key, value)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return func() {
+		for key, value := range previous {
+			if value == nil {
+				os.Unsetenv(key)
+			} else {
+				os.Setenv(key, *value)
+			}
+		}
+	}
+}
+
+// writeTestFile writes a file in a temporary directory, and returns its path
+func writeTestFile(t *testing.T, dir string, name string, content string) string {
+	filename := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+// newTestCertificatePEM returns a self-signed CA certificate in PEM
+func newTestCertificatePEM(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Steampipe Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGetProxyFunc(t *testing.T) {
+	for _, testCase := range []struct {
+		name   string
+		env    map[string]string
+		config awsConfig
+		url    string
+		// the proxy URL, empty for a direct connection
+		expected string
+		err      string
+	}{
+		{
+			name:     "no proxy",
+			url:      "https://ec2.us-east-1.amazonaws.com/",
+			expected: "",
+		},
+		{
+			name:     "environment",
+			env:      map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			url:      "https://ec2.us-east-1.amazonaws.com/",
+			expected: "http://env-proxy:3128",
+		},
+		{
+			name:     "connection config overrides the environment",
+			env:      map[string]string{"HTTPS_PROXY": "http://env-proxy:3128"},
+			config:   awsConfig{HttpsProxy: aws.String("config-proxy:8080")},
+			url:      "https://ec2.us-east-1.amazonaws.com/",
+			expected: "http://config-proxy:8080",
+		},
+		{
+			name:     "no_proxy",
+			config:   awsConfig{HttpsProxy: aws.String("http://config-proxy:8080"), NoProxy: []string{".internal", "s3.us-east-1.amazonaws.com"}},
+			url:      "https://s3.us-east-1.amazonaws.com/",
+			expected: "",
+		},
+		{
+			name:     "no_proxy of other hosts",
+			config:   awsConfig{HttpsProxy: aws.String("http://config-proxy:8080"), NoProxy: []string{".internal", "s3.us-east-1.amazonaws.com"}},
+			url:      "https://ec2.us-east-1.amazonaws.com/",
+			expected: "http://config-proxy:8080",
+		},
+		{
+			name:   "invalid https_proxy",
+			config: awsConfig{HttpsProxy: aws.String("http://")},
+			err:    "invalid https_proxy",
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			env := map[string]string{"HTTPS_PROXY": "", "https_proxy": "", "NO_PROXY": "", "no_proxy": "", "REQUEST_METHOD": ""}
+			for key, value := range testCase.env {
+				env[key] = value
+			}
+			defer setTestEnv(t, env)()
+
+			proxy, err := getProxyFunc(testCase.config)
+			if testCase.err != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.err) {
+					t.Fatalf("expected error %q, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, testCase.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxyUrl, err := proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := ""
+			if proxyUrl != nil {
+				actual = proxyUrl.String()
+			}
+			if actual != testCase.expected {
+				t.Errorf("expected proxy %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLoadCABundle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steampipe-ca-bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, testCase := range []struct {
+		name string
+		path string
+		err  string
+	}{
+		{"certificate", writeTestFile(t, dir, "ca.pem", newTestCertificatePEM(t)), ""},
+		{"invalid PEM", writeTestFile(t, dir, "invalid.pem", "-----BEGIN CERTIFICATE-----\nnot a certificate\n-----END CERTIFICATE-----\n"), "has no PEM certificates"},
+		{"missing file", filepath.Join(dir, "missing.pem"), "failed to read CA bundle"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			caBundle, err := loadCABundle(testCase.path)
+			if testCase.err != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.err) {
+					t.Fatalf("expected error %q, got %v", testCase.err, err)
+				}
+				return
+			}
+			if err != nil || len(caBundle) == 0 {
+				t.Fatalf("expected the certificates, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetHttpClientPerConnection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "steampipe-http-client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer setTestEnv(t, map[string]string{
+		"AWS_CA_BUNDLE":               "",
+		"AWS_PROFILE":                 "",
+		"AWS_CONFIG_FILE":             filepath.Join(dir, "config"),
+		"AWS_SHARED_CREDENTIALS_FILE": filepath.Join(dir, "credentials"),
+	})()
+
+	connectionOne := newTestConnection("test_http_client_one", "AKIDCONNECTIONONE", "http://localhost", []string{"us-east-1"})
+	connectionTwo := newTestConnection("test_http_client_two", "AKIDCONNECTIONTWO", "http://localhost", []string{"us-east-1"})
+	config := connectionTwo.Config.(awsConfig)
+	config.CaBundlePath = aws.String(writeTestFile(t, dir, "ca.pem", newTestCertificatePEM(t)))
+	connectionTwo.Config = config
+
+	clientOne, err := getHttpClient(getConnectionQueryData(connectionOne))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientTwo, err := getHttpClient(getConnectionQueryData(connectionTwo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the sessions of a connection share its client
+	if again, _ := getHttpClient(getConnectionQueryData(connectionOne)); again != clientOne {
+		t.Error("expected the client of the connection to be reused")
+	}
+	if clientOne == clientTwo {
+		t.Error("expected a client per connection")
+	}
+
+	if transport := clientOne.Transport.(*http.Transport); transport.TLSClientConfig != nil && transport.TLSClientConfig.RootCAs != nil {
+		t.Error("expected the system CAs for a connection without a CA bundle")
+	}
+	if transport := clientTwo.Transport.(*http.Transport); transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Error("expected the CAs of ca_bundle_path")
+	}
+
+	// a session is given the client of its connection
+	sess, err := getSession(newTestContext(), getConnectionQueryData(connectionTwo), "us-east-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.Config.HTTPClient != clientTwo {
+		t.Error("expected the session to use the client of its connection")
+	}
+}
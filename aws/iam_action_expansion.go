@@ -0,0 +1,209 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+// iamActionCatalogue is the list of IAM actions of the parliament permission data, used to
+// expand the wildcards and NotAction of policy statements into concrete actions
+type iamActionCatalogue struct {
+	// lower case actions of each service prefix, sorted
+	actionsByPrefix map[string][]string
+	// access level by lower case action, e.g. Write or Permissions management
+	accessLevels map[string]string
+	prefixes     []string
+}
+
+// iamExpandedAction is a concrete action granted or denied by a policy
+type iamExpandedAction struct {
+	Action      string
+	AccessLevel string
+	Effect      string
+}
+
+var (
+	iamActionCatalogueOnce     sync.Once
+	iamActionCatalogueInstance *iamActionCatalogue
+)
+
+// getIamActionCatalogue returns the catalogue of the parliament permission data, which is built
+// on first use
+func getIamActionCatalogue() *iamActionCatalogue {
+	iamActionCatalogueOnce.Do(func() {
+		iamActionCatalogueInstance = newIamActionCatalogue(getParliamentIamPermissions())
+	})
+	return iamActionCatalogueInstance
+}
+
+func newIamActionCatalogue(permissions ParliamentPermissions) *iamActionCatalogue {
+	catalogue := &iamActionCatalogue{
+		actionsByPrefix: map[string][]string{},
+		accessLevels:    map[string]string{},
+	}
+	for _, service := range permissions {
+		prefix := strings.ToLower(service.Prefix)
+		for _, privilege := range service.Privileges {
+			action := prefix + ":" + strings.ToLower(privilege.Privilege)
+			if _, ok := catalogue.accessLevels[action]; ok {
+				continue
+			}
+			catalogue.accessLevels[action] = privilege.AccessLevel
+			catalogue.actionsByPrefix[prefix] = append(catalogue.actionsByPrefix[prefix], action)
+		}
+	}
+	for prefix, actions := range catalogue.actionsByPrefix {
+		sort.Strings(actions)
+		catalogue.prefixes = append(catalogue.prefixes, prefix)
+	}
+	sort.Strings(catalogue.prefixes)
+	return catalogue
+}
+
+// accessLevel returns the access level of an action, or "" if it is not in the catalogue
+func (catalogue *iamActionCatalogue) accessLevel(action string) string {
+	return catalogue.accessLevels[strings.ToLower(action)]
+}
+
+// matchingActions returns the actions of the catalogue matching an action pattern, sorted. The
+// service prefix of most patterns has no wildcard, so only the actions of that service are matched.
+func (catalogue *iamActionCatalogue) matchingActions(pattern string) []string {
+	pattern = strings.ToLower(pattern)
+	prefixes := catalogue.prefixes
+	if i := strings.Index(pattern, ":"); i >= 0 && !strings.ContainsAny(pattern[:i], "*?") {
+		prefixes = []string{pattern[:i]}
+	}
+
+	var actions []string
+	for _, prefix := range prefixes {
+		for _, action := range catalogue.actionsByPrefix[prefix] {
+			if iamWildcardMatch(pattern, action, false) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// expandActions returns the concrete actions of the Action element of a statement, sorted and
+// without duplicates. Actions without wildcards are kept as they are, also when they are not
+// in the catalogue, e.g. actions launched after the permission data was generated.
+func (catalogue *iamActionCatalogue) expandActions(patterns []string) []string {
+	var actions []string
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, "*?") {
+			actions = append(actions, catalogue.matchingActions(pattern)...)
+		} else {
+			actions = append(actions, strings.ToLower(pattern))
+		}
+	}
+	actions = uniqueStrings(actions)
+	sort.Strings(actions)
+	return actions
+}
+
+// expandNotActions returns the actions of the catalogue which match none of the NotAction
+// patterns of a statement, sorted
+func (catalogue *iamActionCatalogue) expandNotActions(patterns []string) []string {
+	excluded := map[string]bool{}
+	for _, action := range catalogue.expandActions(patterns) {
+		excluded[action] = true
+	}
+
+	var actions []string
+	for _, prefix := range catalogue.prefixes {
+		for _, action := range catalogue.actionsByPrefix[prefix] {
+			if !excluded[action] {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// expandStatementActions returns the concrete actions of the Action or NotAction element of a
+// statement
+func (catalogue *iamActionCatalogue) expandStatementActions(statement Statement) []string {
+	if statement.NotAction != nil {
+		return catalogue.expandNotActions(statement.NotAction)
+	}
+	return catalogue.expandActions(statement.Action)
+}
+
+// expandPolicyActions returns the concrete actions allowed or denied by the statements of
+// policies, sorted by action and effect and without duplicates
+func (catalogue *iamActionCatalogue) expandPolicyActions(policies []Policy) []iamExpandedAction {
+	seen := map[iamExpandedAction]bool{}
+	expanded := []iamExpandedAction{}
+	for _, policy := range policies {
+		for _, statement := range policy.Statements {
+			for _, action := range catalogue.expandStatementActions(statement) {
+				expandedAction := iamExpandedAction{
+					Action:      action,
+					AccessLevel: catalogue.accessLevel(action),
+					Effect:      statement.Effect,
+				}
+				if seen[expandedAction] {
+					continue
+				}
+				seen[expandedAction] = true
+				expanded = append(expanded, expandedAction)
+			}
+		}
+	}
+	sort.Slice(expanded, func(i, j int) bool {
+		if expanded[i].Action != expanded[j].Action {
+			return expanded[i].Action < expanded[j].Action
+		}
+		return expanded[i].Effect < expanded[j].Effect
+	})
+	return expanded
+}
+
+//// TRANSFORM FUNCTIONS
+
+// policyToExpandedActions returns the concrete actions of a canonical policy
+func policyToExpandedActions(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.Value == nil {
+		return nil, nil
+	}
+	return getIamActionCatalogue().expandPolicyActions([]Policy{d.Value.(Policy)}), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// getIamPrincipalPolicies returns the inline policies of a user, group or role, as returned by
+// the hydrate functions of their inline_policies columns, and the documents of their attached
+// managed policies, in canonical form
+func getIamPrincipalPolicies(ctx context.Context, d *plugin.QueryData, inlinePolicies []map[string]interface{}, attachedPolicyArns []string) ([]Policy, error) {
+	var policies []Policy
+	for _, inlinePolicy := range inlinePolicies {
+		if inlinePolicy["PolicyDocument"] == nil {
+			continue
+		}
+		document, err := json.Marshal(inlinePolicy["PolicyDocument"])
+		if err != nil {
+			return nil, err
+		}
+		policy, err := canonicalPolicy(string(document))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy.(Policy))
+	}
+
+	for _, policyArn := range attachedPolicyArns {
+		policy, err := getIamManagedPolicy(ctx, d, policyArn)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
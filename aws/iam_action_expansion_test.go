@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIamActionCatalogueExpansion(t *testing.T) {
+	catalogue := newIamActionCatalogue(ParliamentPermissions{
+		{Prefix: "s3", Privileges: []ParliamentPrivilege{
+			{AccessLevel: "Read", Privilege: "GetObject"},
+			{AccessLevel: "Read", Privilege: "GetObjectAcl"},
+			{AccessLevel: "Write", Privilege: "PutObject"},
+			{AccessLevel: "Permissions management", Privilege: "PutBucketPolicy"},
+		}},
+		{Prefix: "ec2", Privileges: []ParliamentPrivilege{
+			{AccessLevel: "List", Privilege: "DescribeInstances"},
+			{AccessLevel: "Write", Privilege: "RunInstances"},
+		}},
+	})
+
+	for _, testCase := range []struct {
+		name      string
+		statement string
+		expected  []string
+	}{
+		{
+			name:      "service wildcard",
+			statement: `{"Effect": "Allow", "Action": "s3:*", "Resource": "*"}`,
+			expected:  []string{"s3:getobject", "s3:getobjectacl", "s3:putbucketpolicy", "s3:putobject"},
+		},
+		{
+			name:      "action wildcards",
+			statement: `{"Effect": "Allow", "Action": ["S3:Get*", "ec2:Describe*", "s3:GetObject"], "Resource": "*"}`,
+			expected:  []string{"ec2:describeinstances", "s3:getobject", "s3:getobjectacl"},
+		},
+		{
+			name:      "wildcard in the service prefix",
+			statement: `{"Effect": "Allow", "Action": "*:Put*", "Resource": "*"}`,
+			expected:  []string{"s3:putbucketpolicy", "s3:putobject"},
+		},
+		{
+			name:      "action missing from the catalogue",
+			statement: `{"Effect": "Allow", "Action": "lambda:InvokeFunction", "Resource": "*"}`,
+			expected:  []string{"lambda:invokefunction"},
+		},
+		{
+			name:      "not action",
+			statement: `{"Effect": "Allow", "NotAction": ["s3:*", "ec2:RunInstances"], "Resource": "*"}`,
+			expected:  []string{"ec2:describeinstances"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			policies, err := parseIamPolicyDocuments(`{"Version": "2012-10-17", "Statement": ` + testCase.statement + `}`)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := catalogue.expandStatementActions(policies[0].Statements[0])
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+
+	t.Run("policy actions", func(t *testing.T) {
+		policies, err := parseIamPolicyDocuments(`[
+			{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:Put*", "Resource": "*"}, {"Effect": "Deny", "Action": "s3:PutBucketPolicy", "Resource": "*"}]},
+			{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:PutObject", "Resource": "*"}}
+		]`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []iamExpandedAction{
+			{Action: "s3:putbucketpolicy", AccessLevel: "Permissions management", Effect: "Allow"},
+			{Action: "s3:putbucketpolicy", AccessLevel: "Permissions management", Effect: "Deny"},
+			{Action: "s3:putobject", AccessLevel: "Write", Effect: "Allow"},
+		}
+		if actual := catalogue.expandPolicyActions(policies); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %v, got %v", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// Scopes of the IAM authorization details of an account. AWS managed policies are loaded
+// separately, since they are only needed by aws_iam_policy and make up most of the response.
+const (
+	iamAuthorizationDetailsLocal      = "local"
+	iamAuthorizationDetailsAwsManaged = "aws_managed"
+)
+
+// same as the default TTL of the Steampipe query cache, so changes show up in the next query
+// which is not served from it
+const iamAuthorizationDetailsTTL = 5 * time.Minute
+
+// the rows of a query are hydrated in parallel, the first one loads the details and the others
+// wait for it, instead of all of them paging through GetAccountAuthorizationDetails
+var iamAuthorizationDetailsLocks sync.Map
+
+// iamAuthorizationDetails is a snapshot of the users, groups, roles and managed policies of an
+// account, with their inline policies, attached policies and group memberships, loaded with
+// GetAccountAuthorizationDetails. The IAM tables hydrate their rows from it, instead of making
+// several calls per user, group, role or policy.
+//
+// A nil snapshot has no entries, so the tables fall back to the calls per item.
+type iamAuthorizationDetails struct {
+	users    map[string]*iam.UserDetail
+	groups   map[string]*iam.GroupDetail
+	roles    map[string]*iam.RoleDetail
+	policies map[string]*iam.ManagedPolicyDetail
+}
+
+// getIamAuthorizationDetails returns the snapshot of the given scope for the account of the
+// connection, loading it on first use. It returns nil if the credentials are not allowed to call
+// GetAccountAuthorizationDetails.
+func getIamAuthorizationDetails(ctx context.Context, d *plugin.QueryData, scope string) (*iamAuthorizationDetails, error) {
+	cacheKey := scopedCacheKey(d, "GetAccountAuthorizationDetails-"+scope)
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*iamAuthorizationDetails), nil
+	}
+
+	lock, _ := iamAuthorizationDetailsLocks.LoadOrStore(cacheKey, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// loaded while waiting for the lock
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*iamAuthorizationDetails), nil
+	}
+
+	details, err := loadIamAuthorizationDetails(ctx, d, scope)
+	if err != nil {
+		if !isIamAccessDeniedError(err) {
+			return nil, err
+		}
+		// cache the denial too, so it is not retried for each row
+		plugin.Logger(ctx).Warn("getIamAuthorizationDetails", "scope", scope, "falling back to calls per item", err)
+		details = nil
+	}
+
+	d.ConnectionManager.Cache.SetWithTTL(cacheKey, details, iamAuthorizationDetailsTTL)
+	return details, nil
+}
+
+func loadIamAuthorizationDetails(ctx context.Context, d *plugin.QueryData, scope string) (*iamAuthorizationDetails, error) {
+	svc, err := IAMService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &iam.GetAccountAuthorizationDetailsInput{
+		MaxItems: aws.Int64(1000),
+	}
+	if scope == iamAuthorizationDetailsAwsManaged {
+		input.Filter = aws.StringSlice([]string{iam.EntityTypeAwsmanagedPolicy})
+	} else {
+		input.Filter = aws.StringSlice([]string{iam.EntityTypeUser, iam.EntityTypeRole, iam.EntityTypeGroup, iam.EntityTypeLocalManagedPolicy})
+	}
+
+	details := &iamAuthorizationDetails{
+		users:    map[string]*iam.UserDetail{},
+		groups:   map[string]*iam.GroupDetail{},
+		roles:    map[string]*iam.RoleDetail{},
+		policies: map[string]*iam.ManagedPolicyDetail{},
+	}
+	err = svc.GetAccountAuthorizationDetailsPages(input, func(page *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) bool {
+		for _, user := range page.UserDetailList {
+			details.users[*user.UserName] = user
+		}
+		for _, group := range page.GroupDetailList {
+			details.groups[*group.GroupName] = group
+		}
+		for _, role := range page.RoleDetailList {
+			details.roles[*role.RoleName] = role
+		}
+		for _, policy := range page.Policies {
+			details.policies[*policy.Arn] = policy
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// getIamPolicyAuthorizationDetails returns the snapshot which has the given managed policy
+func getIamPolicyAuthorizationDetails(ctx context.Context, d *plugin.QueryData, policyArn string) (*iamAuthorizationDetails, error) {
+	if strings.Contains(policyArn, ":iam::aws:policy/") {
+		return getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsAwsManaged)
+	}
+	return getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+}
+
+func isIamAccessDeniedError(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return helpers.StringSliceContains([]string{"AccessDenied", "AccessDeniedException"}, awsErr.Code())
+	}
+	return false
+}
+
+// user returns the details of a user, or nil if the user is not in the snapshot, e.g. when
+// it was created after the snapshot was loaded
+func (details *iamAuthorizationDetails) user(name string) *iam.UserDetail {
+	if details == nil {
+		return nil
+	}
+	return details.users[name]
+}
+
+func (details *iamAuthorizationDetails) group(name string) *iam.GroupDetail {
+	if details == nil {
+		return nil
+	}
+	return details.groups[name]
+}
+
+func (details *iamAuthorizationDetails) role(name string) *iam.RoleDetail {
+	if details == nil {
+		return nil
+	}
+	return details.roles[name]
+}
+
+func (details *iamAuthorizationDetails) policy(arn string) *iam.ManagedPolicyDetail {
+	if details == nil {
+		return nil
+	}
+	return details.policies[arn]
+}
+
+// groupUsers returns the users of a group, sorted by name
+func (details *iamAuthorizationDetails) groupUsers(groupName string) []*iam.User {
+	var users []*iam.User
+	for _, user := range details.users {
+		if helpers.StringSliceContains(aws.StringValueSlice(user.GroupList), groupName) {
+			users = append(users, &iam.User{
+				Arn:        user.Arn,
+				CreateDate: user.CreateDate,
+				Path:       user.Path,
+				UserId:     user.UserId,
+				UserName:   user.UserName,
+			})
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return *users[i].UserName < *users[j].UserName
+	})
+	return users
+}
+
+// userGroups returns the groups of a user, or false if one of them is not in the snapshot
+func (details *iamAuthorizationDetails) userGroups(user *iam.UserDetail) ([]*iam.Group, bool) {
+	var groups []*iam.Group
+	for _, groupName := range user.GroupList {
+		group := details.group(*groupName)
+		if group == nil {
+			return nil, false
+		}
+		groups = append(groups, &iam.Group{
+			Arn:        group.Arn,
+			CreateDate: group.CreateDate,
+			GroupId:    group.GroupId,
+			GroupName:  group.GroupName,
+			Path:       group.Path,
+		})
+	}
+	return groups, true
+}
+
+// attachedIamPolicyArns returns the ARNs of attached managed policies, as the
+// attached_policy_arns columns do
+func attachedIamPolicyArns(policies []*iam.AttachedPolicy) []string {
+	var attachedPolicyArns []string
+	for _, policy := range policies {
+		attachedPolicyArns = append(attachedPolicyArns, *policy.PolicyArn)
+	}
+	return attachedPolicyArns
+}
+
+// inlineIamPolicyNames returns the names of inline policies, as ListRolePolicies,
+// ListUserPolicies and ListGroupPolicies do
+func inlineIamPolicyNames(policies []*iam.PolicyDetail) []*string {
+	names := []*string{}
+	for _, policy := range policies {
+		names = append(names, policy.PolicyName)
+	}
+	return names
+}
+
+// inlineIamPolicies returns inline policies with their decoded documents, as the
+// inline_policies columns do
+func inlineIamPolicies(policies []*iam.PolicyDetail) ([]map[string]interface{}, error) {
+	var inlinePolicies []map[string]interface{}
+	for _, policy := range policies {
+		if policy.PolicyDocument == nil {
+			continue
+		}
+		decoded, err := url.QueryUnescape(*policy.PolicyDocument)
+		if err != nil {
+			return nil, err
+		}
+
+		var rawPolicy interface{}
+		if err := json.Unmarshal([]byte(decoded), &rawPolicy); err != nil {
+			return nil, err
+		}
+
+		inlinePolicies = append(inlinePolicies, map[string]interface{}{
+			"PolicyDocument": rawPolicy,
+			"PolicyName":     *policy.PolicyName,
+		})
+	}
+	return inlinePolicies, nil
+}
+
+// defaultIamPolicyVersion returns the default version of a managed policy, as GetPolicyVersion does
+func defaultIamPolicyVersion(policy *iam.ManagedPolicyDetail) *iam.PolicyVersion {
+	for _, version := range policy.PolicyVersionList {
+		if aws.BoolValue(version.IsDefaultVersion) {
+			return version
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+func TestListIamRolesFromAuthorizationDetails(t *testing.T) {
+	readOnlyPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+	authorizationDetails := &iam.GetAccountAuthorizationDetailsOutput{
+		RoleDetailList: []*iam.RoleDetail{
+			{
+				RoleName: aws.String("test-role-one"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/test-role-one"),
+				AttachedManagedPolicies: []*iam.AttachedPolicy{
+					{PolicyName: aws.String("ReadOnlyAccess"), PolicyArn: aws.String("arn:aws:iam::aws:policy/ReadOnlyAccess")},
+				},
+				// policy documents are URL encoded, as returned by IAM
+				RolePolicyList: []*iam.PolicyDetail{
+					{PolicyName: aws.String("read-objects"), PolicyDocument: aws.String(url.QueryEscape(readOnlyPolicy))},
+				},
+			},
+			{
+				RoleName: aws.String("test-role-two"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/test-role-two"),
+			},
+		},
+	}
+	expected := map[string]map[string]interface{}{
+		"test-role-one": {
+			"attached_policy_arns": []interface{}{"arn:aws:iam::aws:policy/ReadOnlyAccess"},
+			"inline_policies": []interface{}{
+				map[string]interface{}{
+					"PolicyName": "read-objects",
+					"PolicyDocument": map[string]interface{}{
+						"Version": "2012-10-17",
+						"Statement": []interface{}{
+							map[string]interface{}{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"},
+						},
+					},
+				},
+			},
+		},
+		"test-role-two": {
+			"attached_policy_arns": nil,
+			"inline_policies":      nil,
+		},
+	}
+
+	for _, testCase := range []struct {
+		name                      string
+		authorizationDetailsError error
+		// calls per item, made when the snapshot can't be loaded
+		expectedItemCalls int
+	}{
+		{
+			name: "snapshot",
+		},
+		{
+			name:                      "access denied",
+			authorizationDetailsError: awserr.New("AccessDenied", "not authorized to perform: iam:GetAccountAuthorizationDetails", nil),
+			expectedItemCalls:         2,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeIam := &fakeIAM{
+				AuthorizationDetails:      authorizationDetails,
+				AuthorizationDetailsError: testCase.authorizationDetailsError,
+			}
+			defer registerServiceClient("iam", fakeIam)()
+			defer registerServiceClient("ec2", &fakeEC2{Regions: []string{"us-east-1"}})()
+			defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "123456789012")})()
+
+			p := newFakeTableTestPlugin(t, "test_iam_authorization_details")
+			stream := executeTableTestQuery(t, p, "aws_iam_role", []string{"name", "attached_policy_arns", "inline_policies"}, nil, 0)
+
+			rows := map[string]map[string]interface{}{}
+			for _, row := range stream.rows {
+				rows[row["name"].(string)] = map[string]interface{}{
+					"attached_policy_arns": row["attached_policy_arns"],
+					"inline_policies":      row["inline_policies"],
+				}
+			}
+			if !reflect.DeepEqual(rows, expected) {
+				t.Errorf("expected rows %v, got %v", expected, rows)
+			}
+
+			// the snapshot, or the denial, is loaded once for all rows
+			if calls := fakeIam.Calls("GetAccountAuthorizationDetails"); calls != 1 {
+				t.Errorf("expected 1 GetAccountAuthorizationDetails call, got %d", calls)
+			}
+			for _, operation := range []string{"ListAttachedRolePolicies", "ListRolePolicies"} {
+				if calls := fakeIam.Calls(operation); calls != testCase.expectedItemCalls {
+					t.Errorf("expected %d %s calls, got %d", testCase.expectedItemCalls, operation, calls)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,519 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Types of the policies of a principal
+const (
+	iamPolicySourceInline              = "inline"
+	iamPolicySourceManaged             = "managed"
+	iamPolicySourceGroupInline         = "group_inline"
+	iamPolicySourceGroupManaged        = "group_managed"
+	iamPolicySourcePermissionsBoundary = "permissions_boundary"
+	iamPolicySourceServiceControl      = "service_control_policy"
+)
+
+// Decisions of an effective permission
+const (
+	iamEffectivePermissionAllowed          = "allowed"
+	iamEffectivePermissionPartiallyAllowed = "partiallyAllowed"
+	iamEffectivePermissionDenied           = "denied"
+)
+
+// iamPolicySource is a policy of a principal and where it comes from
+type iamPolicySource struct {
+	Type       string
+	PolicyName string
+	PolicyArn  string
+	// the group of group policies
+	GroupName string
+	// the root, organizational unit or account the service control policy is attached to
+	TargetId string
+	Policy   Policy
+}
+
+// iamPrincipalPolicySources are the policies which apply to the requests of a principal
+type iamPrincipalPolicySources struct {
+	// inline and attached managed policies of the principal and its groups
+	Identity            []iamPolicySource
+	PermissionsBoundary *iamPolicySource
+	// the service control policies of each level of the organization, from the root to the
+	// account. Each level has to allow a request.
+	ServiceControlPolicies []iamServiceControlPolicyLevel
+}
+
+// iamServiceControlPolicyLevel is the root, an organizational unit or the account, and the
+// service control policies attached to it
+type iamServiceControlPolicyLevel struct {
+	TargetId string
+	Policies []iamPolicySource
+}
+
+// iamPermissionTrim is a policy which denies some of the actions an identity policy allows
+type iamPermissionTrim struct {
+	Type       string
+	PolicyName string   `json:",omitempty"`
+	PolicyArn  string   `json:",omitempty"`
+	GroupName  string   `json:",omitempty"`
+	TargetId   string   `json:",omitempty"`
+	Sids       []string `json:",omitempty"`
+	// explicitDeny, or implicitDeny if the boundary or the service control policies of a level
+	// don't allow the actions
+	Decision string
+	// the policy only denies the actions for some of the resources of the pattern, or depending
+	// on condition keys which are not in the context, so they are kept
+	Conditional bool `json:",omitempty"`
+	Actions     []string
+}
+
+// iamEffectivePermission is an action and resource pattern allowed by a statement of an identity
+// policy, and what is left of it once denies, the permissions boundary and the service control
+// policies are applied
+type iamEffectivePermission struct {
+	Action            string
+	ExcludedActions   []string
+	Resource          string
+	ExcludedResources []string
+	Condition         map[string]interface{}
+	Sid               string
+	AllowedBy         iamPolicySource
+	Decision          string
+	EffectiveActions  []string
+	// the effective actions which a policy may still deny, see iamPermissionTrim.Conditional
+	ConditionalActions []string
+	TrimmedBy          []iamPermissionTrim
+}
+
+// evaluateIamEffectivePermissions returns the permissions granted by the Allow statements of the
+// identity policies of a principal. The patterns of each statement are expanded into the actions
+// of the catalogue, and each action is checked in the IAM evaluation order: an explicit deny in
+// any policy, then the service control policies of each level of the organization, then the
+// permissions boundary. The conditions of the Allow statements are returned but not evaluated,
+// those of the other policies are evaluated against requestContext. A policy which only applies to
+// part of a resource pattern, or whose conditions use keys missing from requestContext, can't
+// decide the actions, so they are kept as conditional actions.
+func evaluateIamEffectivePermissions(catalogue *iamActionCatalogue, principalArn string, sources iamPrincipalPolicySources, requestContext map[string][]string) ([]iamEffectivePermission, error) {
+	// the keys of the principal are known, whatever the request
+	context := map[string][]string{}
+	for key, values := range requestContext {
+		context[key] = values
+	}
+	if arnSegments := strings.Split(principalArn, ":"); len(arnSegments) >= 6 {
+		if _, ok := context["aws:principalarn"]; !ok {
+			context["aws:principalarn"] = []string{principalArn}
+		}
+		if _, ok := context["aws:principalaccount"]; !ok {
+			context["aws:principalaccount"] = []string{arnSegments[4]}
+		}
+	}
+	requestContext = context
+
+	var permissions []iamEffectivePermission
+	for _, source := range sources.Identity {
+		for _, statement := range source.Policy.Statements {
+			if statement.Effect != "Allow" {
+				continue
+			}
+
+			actionPatterns := []string(statement.Action)
+			if statement.NotAction != nil {
+				actionPatterns = []string{"*"}
+			}
+			resourcePatterns := []string(statement.Resource)
+			if statement.NotResource != nil || len(resourcePatterns) == 0 {
+				resourcePatterns = []string{"*"}
+			}
+
+			for _, actionPattern := range actionPatterns {
+				var actions []string
+				if statement.NotAction != nil {
+					actions = catalogue.expandNotActions(statement.NotAction)
+				} else {
+					actions = catalogue.expandActions([]string{actionPattern})
+				}
+				if len(actions) == 0 {
+					// e.g. a service which is not in the catalogue
+					actions = []string{strings.ToLower(actionPattern)}
+				}
+
+				for _, resourcePattern := range resourcePatterns {
+					permission := iamEffectivePermission{
+						Action:            actionPattern,
+						ExcludedActions:   statement.NotAction,
+						Resource:          resourcePattern,
+						ExcludedResources: statement.NotResource,
+						Sid:               statement.Sid,
+						AllowedBy:         source,
+					}
+					if len(statement.Condition) > 0 {
+						permission.Condition = statement.Condition
+					}
+					if err := trimIamEffectivePermission(&permission, actions, principalArn, sources, requestContext); err != nil {
+						return nil, err
+					}
+					permissions = append(permissions, permission)
+				}
+			}
+		}
+	}
+	return permissions, nil
+}
+
+// trimIamEffectivePermission sets the effective actions of a permission, and the policies which
+// deny the others
+func trimIamEffectivePermission(permission *iamEffectivePermission, actions []string, principalArn string, sources iamPrincipalPolicySources, requestContext map[string][]string) error {
+	// trims by policy, with the step of the evaluation order which trims the actions
+	var trims []*iamPermissionTrim
+	var steps []int
+	trimsByKey := map[string]*iamPermissionTrim{}
+	addTrim := func(step int, source iamPolicySource, decision string, conditional bool, sids []string, action string) {
+		key := strings.Join([]string{source.Type, source.PolicyArn, source.PolicyName, source.GroupName, source.TargetId, decision, strconv.FormatBool(conditional)}, "\x00")
+		trim, ok := trimsByKey[key]
+		if !ok {
+			trim = &iamPermissionTrim{
+				Type:        source.Type,
+				PolicyName:  source.PolicyName,
+				PolicyArn:   source.PolicyArn,
+				GroupName:   source.GroupName,
+				TargetId:    source.TargetId,
+				Decision:    decision,
+				Conditional: conditional,
+			}
+			trimsByKey[key] = trim
+			trims = append(trims, trim)
+			steps = append(steps, step)
+		}
+		if len(sids) > 0 {
+			trim.Sids = uniqueStrings(append(trim.Sids, sids...))
+		}
+		trim.Actions = append(trim.Actions, action)
+	}
+
+	// every policy which may deny a request explicitly
+	denySources := append([]iamPolicySource{}, sources.Identity...)
+	for _, level := range sources.ServiceControlPolicies {
+		denySources = append(denySources, level.Policies...)
+	}
+	if sources.PermissionsBoundary != nil {
+		denySources = append(denySources, *sources.PermissionsBoundary)
+	}
+
+	permission.EffectiveActions = []string{}
+	for _, action := range actions {
+		request := iamEvaluationRequest{
+			PrincipalArn: principalArn,
+			Action:       action,
+			ResourceArn:  permission.Resource,
+			Context:      requestContext,
+		}
+
+		allowed := true
+		// the trims which may deny the action, only kept if nothing else denies it
+		var conditionalTrims []func()
+		for _, source := range denySources {
+			source := source
+			match, err := matchIamPermissionStatements([]Policy{source.Policy}, "Deny", request)
+			if err != nil {
+				return err
+			}
+			switch {
+			case len(match.Full) > 0:
+				addTrim(0, source, iamDecisionExplicitDeny, false, matchedIamStatementSids(match.Full), action)
+				allowed = false
+			case len(match.Partial) > 0:
+				conditionalTrims = append(conditionalTrims, func() {
+					addTrim(0, source, iamDecisionExplicitDeny, true, matchedIamStatementSids(match.Partial), action)
+				})
+			}
+		}
+
+		for i, level := range sources.ServiceControlPolicies {
+			step := 1 + i
+			source := iamPolicySource{Type: iamPolicySourceServiceControl, TargetId: level.TargetId}
+			var policies []Policy
+			for _, source := range level.Policies {
+				policies = append(policies, source.Policy)
+			}
+			match, err := matchIamPermissionStatements(policies, "Allow", request)
+			if err != nil {
+				return err
+			}
+			switch {
+			case len(match.Full) > 0:
+			case len(match.Partial) > 0:
+				conditionalTrims = append(conditionalTrims, func() {
+					addTrim(step, source, iamDecisionImplicitDeny, true, nil, action)
+				})
+			default:
+				// no policy of the level allows the action, so the level trims it
+				addTrim(step, source, iamDecisionImplicitDeny, false, nil, action)
+				allowed = false
+			}
+		}
+
+		if sources.PermissionsBoundary != nil {
+			step := 1 + len(sources.ServiceControlPolicies)
+			match, err := matchIamPermissionStatements([]Policy{sources.PermissionsBoundary.Policy}, "Allow", request)
+			if err != nil {
+				return err
+			}
+			switch {
+			case len(match.Full) > 0:
+			case len(match.Partial) > 0:
+				conditionalTrims = append(conditionalTrims, func() {
+					addTrim(step, *sources.PermissionsBoundary, iamDecisionImplicitDeny, true, nil, action)
+				})
+			default:
+				addTrim(step, *sources.PermissionsBoundary, iamDecisionImplicitDeny, false, nil, action)
+				allowed = false
+			}
+		}
+
+		if allowed {
+			permission.EffectiveActions = append(permission.EffectiveActions, action)
+			if len(conditionalTrims) > 0 {
+				permission.ConditionalActions = append(permission.ConditionalActions, action)
+			}
+			for _, addConditionalTrim := range conditionalTrims {
+				addConditionalTrim()
+			}
+		}
+	}
+
+	for step := 0; len(permission.TrimmedBy) < len(trims); step++ {
+		for i, trim := range trims {
+			if steps[i] == step {
+				sort.Strings(trim.Actions)
+				permission.TrimmedBy = append(permission.TrimmedBy, *trim)
+			}
+		}
+	}
+
+	switch {
+	case len(permission.EffectiveActions) == len(actions):
+		permission.Decision = iamEffectivePermissionAllowed
+	case len(permission.EffectiveActions) == 0:
+		permission.Decision = iamEffectivePermissionDenied
+	default:
+		permission.Decision = iamEffectivePermissionPartiallyAllowed
+	}
+	return nil
+}
+
+// iamPermissionStatementMatch are the statements of an effect which apply to all the requests of
+// a permission, and those which only apply to some of them
+type iamPermissionStatementMatch struct {
+	Full    []iamMatchedStatement
+	Partial []iamMatchedStatement
+}
+
+// matchIamPermissionStatements returns the statements of an effect which apply to the action of a
+// permission. The resource of the request is the resource pattern of the permission.
+func matchIamPermissionStatements(policies []Policy, effect string, request iamEvaluationRequest) (*iamPermissionStatementMatch, error) {
+	match := &iamPermissionStatementMatch{}
+	for policyIndex, policy := range policies {
+		for _, statement := range policy.Statements {
+			if statement.Effect != effect {
+				continue
+			}
+			coverage, err := iamStatementCoverage(statement, request)
+			if err != nil {
+				return nil, fmt.Errorf("statement %q of policy %d: %s", statement.Sid, policyIndex, err.Error())
+			}
+			matched := iamMatchedStatement{PolicyIndex: policyIndex, Sid: statement.Sid, Effect: statement.Effect}
+			switch coverage {
+			case iamCoverageFull:
+				match.Full = append(match.Full, matched)
+			case iamCoveragePartial:
+				match.Partial = append(match.Partial, matched)
+			}
+		}
+	}
+	return match, nil
+}
+
+// How much of the requests of a permission a statement applies to
+const (
+	iamCoverageNone = iota
+	// some of the resources of the pattern, or depending on condition keys missing from the context
+	iamCoveragePartial
+	iamCoverageFull
+)
+
+// iamStatementCoverage returns how much of the requests of a permission a statement applies to
+func iamStatementCoverage(statement Statement, request iamEvaluationRequest) (int, error) {
+	if !iamStatementPrincipalMatches(statement, request.PrincipalArn) || !iamStatementActionMatches(statement, request.Action) {
+		return iamCoverageNone, nil
+	}
+	resourceCoverage := iamStatementResourceCoverage(statement, request)
+	if resourceCoverage == iamCoverageNone {
+		return iamCoverageNone, nil
+	}
+	conditionCoverage, err := iamConditionsCoverage(statement.Condition, request.Context)
+	if err != nil || conditionCoverage == iamCoverageNone {
+		return iamCoverageNone, err
+	}
+	if resourceCoverage == iamCoveragePartial || conditionCoverage == iamCoveragePartial {
+		return iamCoveragePartial, nil
+	}
+	return iamCoverageFull, nil
+}
+
+// iamStatementResourceCoverage returns how much of the resource pattern of a request the
+// Resource or NotResource of a statement covers
+func iamStatementResourceCoverage(statement Statement, request iamEvaluationRequest) int {
+	resource := request.ResourceArn
+	if resource == "" {
+		resource = "*"
+	}
+	if statement.Resource != nil {
+		return iamResourcePatternsCoverage(statement.Resource, resource, request.Context)
+	}
+	if statement.NotResource != nil {
+		switch iamResourcePatternsCoverage(statement.NotResource, resource, request.Context) {
+		case iamCoverageFull:
+			return iamCoverageNone
+		case iamCoveragePartial:
+			return iamCoveragePartial
+		}
+	}
+	return iamCoverageFull
+}
+
+// iamResourcePatternsCoverage returns how much of a resource pattern the patterns of a statement
+// cover. A pattern with a variable missing from the context may cover any of it.
+func iamResourcePatternsCoverage(patterns []string, resource string, context map[string][]string) int {
+	coverage := iamCoverageNone
+	for _, pattern := range patterns {
+		pattern, ok := substituteIamPolicyVariables(pattern, context)
+		switch {
+		case !ok:
+			coverage = iamCoveragePartial
+		case iamArnPatternCovers(pattern, resource):
+			return iamCoverageFull
+		case iamArnPatternsOverlap(pattern, resource):
+			coverage = iamCoveragePartial
+		}
+	}
+	return coverage
+}
+
+// iamArnPatternCovers returns true if every ARN of the resource pattern matches the pattern. The
+// wildcards of the resource pattern are matched as characters, so only a * of the pattern, which
+// matches any characters, can cover them.
+func iamArnPatternCovers(pattern string, resource string) bool {
+	if strings.ContainsAny(resource, "*?") && strings.Contains(pattern, "?") {
+		return false
+	}
+	return iamArnMatch(pattern, resource)
+}
+
+// iamArnPatternsOverlap returns true if an ARN may match both patterns. The wildcards of the
+// partition, service, region and account only match within their segment.
+func iamArnPatternsOverlap(a string, b string) bool {
+	aSegments := strings.SplitN(a, ":", 6)
+	bSegments := strings.SplitN(b, ":", 6)
+	if len(aSegments) != 6 || len(bSegments) != 6 {
+		return iamWildcardPatternsOverlap(a, b)
+	}
+	for i := range aSegments {
+		if !iamWildcardPatternsOverlap(aSegments[i], bSegments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// iamWildcardPatternsOverlap returns true if a value may match both patterns, where * matches any
+// characters and ? a single one
+func iamWildcardPatternsOverlap(a string, b string) bool {
+	type state struct{ i, j int }
+	visited := map[state]bool{}
+	var overlaps func(i, j int) bool
+	overlaps = func(i, j int) bool {
+		if visited[state{i, j}] {
+			return false
+		}
+		visited[state{i, j}] = true
+
+		if i == len(a) && j == len(b) {
+			return true
+		}
+		// a * matches no characters
+		if i < len(a) && a[i] == '*' && overlaps(i+1, j) {
+			return true
+		}
+		if j < len(b) && b[j] == '*' && overlaps(i, j+1) {
+			return true
+		}
+		if i == len(a) || j == len(b) {
+			return false
+		}
+		// both patterns match the next character, a * keeps matching after it
+		if a[i] != '*' && a[i] != '?' && b[j] != '*' && b[j] != '?' && a[i] != b[j] {
+			return false
+		}
+		nextI, nextJ := i+1, j+1
+		if a[i] == '*' {
+			nextI = i
+		}
+		if b[j] == '*' {
+			nextJ = j
+		}
+		return (nextI != i || nextJ != j) && overlaps(nextI, nextJ)
+	}
+	return overlaps(0, 0)
+}
+
+// iamConditionsCoverage evaluates the conditions of a statement. Conditions on keys missing from
+// the context, or with values using them, may match or not, so they cover part of the requests.
+func iamConditionsCoverage(conditions map[string]interface{}, context map[string][]string) (int, error) {
+	coverage := iamCoverageFull
+	for operator, condition := range conditions {
+		keys, ok := condition.(map[string]interface{})
+		if !ok {
+			return iamCoverageNone, fmt.Errorf("invalid condition %s", operator)
+		}
+		for key, values := range keys {
+			conditionValues, _ := values.([]string)
+			if !iamConditionKnown(key, conditionValues, context) {
+				coverage = iamCoveragePartial
+				continue
+			}
+			matches, err := iamConditionMatches(operator, key, conditionValues, context)
+			if err != nil || !matches {
+				return iamCoverageNone, err
+			}
+		}
+	}
+	return coverage, nil
+}
+
+// iamConditionKnown returns true if the key of a condition, and the variables of its values, are
+// in the context
+func iamConditionKnown(key string, conditionValues []string, context map[string][]string) bool {
+	if len(context[strings.ToLower(key)]) == 0 {
+		return false
+	}
+	for _, conditionValue := range conditionValues {
+		if _, ok := substituteIamPolicyVariables(conditionValue, context); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchedIamStatementSids returns the Sids of the statements which decided an evaluation
+func matchedIamStatementSids(statements []iamMatchedStatement) []string {
+	sids := []string{}
+	for _, statement := range statements {
+		if statement.Sid != "" {
+			sids = append(sids, statement.Sid)
+		}
+	}
+	return sids
+}
@@ -0,0 +1,355 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateIamEffectivePermissions(t *testing.T) {
+	catalogue := newIamActionCatalogue(ParliamentPermissions{
+		{Prefix: "s3", Privileges: []ParliamentPrivilege{
+			{AccessLevel: "Read", Privilege: "GetObject"},
+			{AccessLevel: "Write", Privilege: "PutObject"},
+			{AccessLevel: "Permissions management", Privilege: "PutBucketPolicy"},
+		}},
+		{Prefix: "ec2", Privileges: []ParliamentPrivilege{
+			{AccessLevel: "Write", Privilege: "RunInstances"},
+		}},
+	})
+	policy := func(document string) Policy {
+		policies, err := parseIamPolicyDocuments(document)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return policies[0]
+	}
+
+	sources := iamPrincipalPolicySources{
+		Identity: []iamPolicySource{
+			{Type: iamPolicySourceInline, PolicyName: "s3", Policy: policy(`{"Version": "2012-10-17", "Statement": {"Sid": "S3", "Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::data/*"}}`)},
+			{Type: iamPolicySourceGroupManaged, PolicyName: "NoEc2", PolicyArn: "arn:aws:iam::123456789012:policy/NoEc2", GroupName: "developers", Policy: policy(`{"Version": "2012-10-17", "Statement": [{"Sid": "All", "Effect": "Allow", "NotAction": "s3:*", "Resource": "*"}, {"Sid": "NoRun", "Effect": "Deny", "Action": "ec2:RunInstances", "Resource": "*"}]}`)},
+		},
+		PermissionsBoundary: &iamPolicySource{
+			Type:       iamPolicySourcePermissionsBoundary,
+			PolicyName: "boundary",
+			PolicyArn:  "arn:aws:iam::123456789012:policy/boundary",
+			Policy:     policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": ["s3:*", "ec2:*"], "Resource": "*"}}`),
+		},
+		ServiceControlPolicies: []iamServiceControlPolicyLevel{
+			{TargetId: "r-root", Policies: []iamPolicySource{
+				{Type: iamPolicySourceServiceControl, PolicyName: "FullAWSAccess", TargetId: "r-root", Policy: policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`)},
+			}},
+			{TargetId: "ou-1", Policies: []iamPolicySource{
+				{Type: iamPolicySourceServiceControl, PolicyName: "NoPolicies", TargetId: "ou-1", Policy: policy(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "*", "Resource": "*"}, {"Sid": "NoBucketPolicy", "Effect": "Deny", "Action": "s3:PutBucketPolicy", "Resource": "*"}]}`)},
+			}},
+			{TargetId: "123456789012", Policies: []iamPolicySource{
+				{Type: iamPolicySourceServiceControl, PolicyName: "S3Only", TargetId: "123456789012", Policy: policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": ["s3:*", "ec2:*"], "Resource": "*", "Condition": {"StringEquals": {"aws:RequestedRegion": "eu-west-1"}}}}`)},
+			}},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		context  map[string][]string
+		expected []iamEffectivePermission
+	}{
+		{
+			name:    "in an allowed region",
+			context: map[string][]string{"aws:requestedregion": {"eu-west-1"}},
+			expected: []iamEffectivePermission{
+				{
+					Action:           "s3:*",
+					Resource:         "arn:aws:s3:::data/*",
+					Sid:              "S3",
+					Decision:         iamEffectivePermissionPartiallyAllowed,
+					EffectiveActions: []string{"s3:getobject", "s3:putobject"},
+					TrimmedBy: []iamPermissionTrim{
+						{Type: iamPolicySourceServiceControl, PolicyName: "NoPolicies", TargetId: "ou-1", Sids: []string{"NoBucketPolicy"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:putbucketpolicy"}},
+					},
+				},
+				{
+					Action:           "*",
+					ExcludedActions:  []string{"s3:*"},
+					Resource:         "*",
+					Sid:              "All",
+					Decision:         iamEffectivePermissionDenied,
+					EffectiveActions: []string{},
+					TrimmedBy: []iamPermissionTrim{
+						{Type: iamPolicySourceGroupManaged, PolicyName: "NoEc2", PolicyArn: "arn:aws:iam::123456789012:policy/NoEc2", GroupName: "developers", Sids: []string{"NoRun"}, Decision: iamDecisionExplicitDeny, Actions: []string{"ec2:runinstances"}},
+					},
+				},
+			},
+		},
+		{
+			name:    "outside the allowed region",
+			context: map[string][]string{"aws:requestedregion": {"us-east-1"}},
+			expected: []iamEffectivePermission{
+				{
+					Action:           "s3:*",
+					Resource:         "arn:aws:s3:::data/*",
+					Sid:              "S3",
+					Decision:         iamEffectivePermissionDenied,
+					EffectiveActions: []string{},
+					TrimmedBy: []iamPermissionTrim{
+						{Type: iamPolicySourceServiceControl, PolicyName: "NoPolicies", TargetId: "ou-1", Sids: []string{"NoBucketPolicy"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:putbucketpolicy"}},
+						{Type: iamPolicySourceServiceControl, TargetId: "123456789012", Decision: iamDecisionImplicitDeny, Actions: []string{"s3:getobject", "s3:putbucketpolicy", "s3:putobject"}},
+					},
+				},
+				{
+					Action:           "*",
+					ExcludedActions:  []string{"s3:*"},
+					Resource:         "*",
+					Sid:              "All",
+					Decision:         iamEffectivePermissionDenied,
+					EffectiveActions: []string{},
+					TrimmedBy: []iamPermissionTrim{
+						{Type: iamPolicySourceGroupManaged, PolicyName: "NoEc2", PolicyArn: "arn:aws:iam::123456789012:policy/NoEc2", GroupName: "developers", Sids: []string{"NoRun"}, Decision: iamDecisionExplicitDeny, Actions: []string{"ec2:runinstances"}},
+						{Type: iamPolicySourceServiceControl, TargetId: "123456789012", Decision: iamDecisionImplicitDeny, Actions: []string{"ec2:runinstances"}},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			permissions, err := evaluateIamEffectivePermissions(catalogue, "arn:aws:iam::123456789012:user/alice", sources, testCase.context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(permissions) != len(testCase.expected) {
+				t.Fatalf("expected %d permissions, got %d: %+v", len(testCase.expected), len(permissions), permissions)
+			}
+			for i, permission := range permissions {
+				// the source is the policy of the statement
+				permission.AllowedBy = iamPolicySource{}
+				if !reflect.DeepEqual(permission, testCase.expected[i]) {
+					t.Errorf("expected %+v, got %+v", testCase.expected[i], permission)
+				}
+			}
+		})
+	}
+
+	t.Run("without a region in the context", func(t *testing.T) {
+		permissions, err := evaluateIamEffectivePermissions(catalogue, "arn:aws:iam::123456789012:user/alice", sources, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the SCP of the account may or may not allow the request
+		expected := []iamPermissionTrim{
+			{Type: iamPolicySourceServiceControl, PolicyName: "NoPolicies", TargetId: "ou-1", Sids: []string{"NoBucketPolicy"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:putbucketpolicy"}},
+			{Type: iamPolicySourceServiceControl, TargetId: "123456789012", Decision: iamDecisionImplicitDeny, Conditional: true, Actions: []string{"s3:getobject", "s3:putobject"}},
+		}
+		if !reflect.DeepEqual(permissions[0].TrimmedBy, expected) {
+			t.Errorf("expected %+v, got %+v", expected, permissions[0].TrimmedBy)
+		}
+		if expected := []string{"s3:getobject", "s3:putobject"}; !reflect.DeepEqual(permissions[0].ConditionalActions, expected) || !reflect.DeepEqual(permissions[0].EffectiveActions, expected) {
+			t.Errorf("expected the conditional actions %v, got %v of %v", expected, permissions[0].ConditionalActions, permissions[0].EffectiveActions)
+		}
+	})
+
+	t.Run("permissions boundary", func(t *testing.T) {
+		boundary := *sources.PermissionsBoundary
+		boundary.Policy = policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:Get*", "Resource": "*"}}`)
+		permissions, err := evaluateIamEffectivePermissions(catalogue, "arn:aws:iam::123456789012:user/alice", iamPrincipalPolicySources{
+			Identity:            sources.Identity[:1],
+			PermissionsBoundary: &boundary,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []iamPermissionTrim{
+			{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", PolicyArn: "arn:aws:iam::123456789012:policy/boundary", Decision: iamDecisionImplicitDeny, Actions: []string{"s3:putbucketpolicy", "s3:putobject"}},
+		}
+		if !reflect.DeepEqual(permissions[0].TrimmedBy, expected) {
+			t.Errorf("expected %+v, got %+v", expected, permissions[0].TrimmedBy)
+		}
+		if permissions[0].AllowedBy.PolicyName != "s3" {
+			t.Errorf("expected the permission to be allowed by s3, got %s", permissions[0].AllowedBy.PolicyName)
+		}
+	})
+}
+
+func TestEvaluateIamEffectivePermissionsCoverage(t *testing.T) {
+	catalogue := newIamActionCatalogue(ParliamentPermissions{
+		{Prefix: "s3", Privileges: []ParliamentPrivilege{
+			{AccessLevel: "Read", Privilege: "GetObject"},
+		}},
+	})
+	policy := func(document string) Policy {
+		policies, err := parseIamPolicyDocuments(document)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return policies[0]
+	}
+	identity := []iamPolicySource{
+		{Type: iamPolicySourceInline, PolicyName: "s3", Policy: policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::data/*"}}`)},
+	}
+	scp := func(statement string) []iamServiceControlPolicyLevel {
+		return []iamServiceControlPolicyLevel{
+			{TargetId: "r-root", Policies: []iamPolicySource{
+				{Type: iamPolicySourceServiceControl, PolicyName: "FullAWSAccess", TargetId: "r-root", Policy: policy(`{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "*", "Resource": "*"}}`)},
+				{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Policy: policy(`{"Version": "2012-10-17", "Statement": ` + statement + `}`)},
+			}},
+		}
+	}
+	boundary := func(statement string) *iamPolicySource {
+		return &iamPolicySource{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Policy: policy(`{"Version": "2012-10-17", "Statement": ` + statement + `}`)}
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		sources  iamPrincipalPolicySources
+		context  map[string][]string
+		decision string
+		// the trim of the guard or boundary, nil if it doesn't trim the action
+		trim *iamPermissionTrim
+	}{
+		{
+			name:     "region SCP without a region",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Region", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"StringNotEquals": {"aws:RequestedRegion": "eu-west-1"}}}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"Region"}, Decision: iamDecisionExplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "region SCP in another region",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Region", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"StringNotEquals": {"aws:RequestedRegion": "eu-west-1"}}}`)},
+			context:  map[string][]string{"aws:requestedregion": {"us-east-1"}},
+			decision: iamEffectivePermissionDenied,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"Region"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "region SCP in the allowed region",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Region", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"StringNotEquals": {"aws:RequestedRegion": "eu-west-1"}}}`)},
+			context:  map[string][]string{"aws:requestedregion": {"eu-west-1"}},
+			decision: iamEffectivePermissionAllowed,
+		},
+		{
+			name:     "MFA guard without MFA in the context",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Mfa", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"BoolIfExists": {"aws:MultiFactorAuthPresent": "false"}}}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"Mfa"}, Decision: iamDecisionExplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "MFA guard with MFA",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Mfa", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"BoolIfExists": {"aws:MultiFactorAuthPresent": "false"}}}`)},
+			context:  map[string][]string{"aws:multifactorauthpresent": {"true"}},
+			decision: iamEffectivePermissionAllowed,
+		},
+		{
+			name:     "SCP exempting other principals",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "AdminOnly", "Effect": "Deny", "Action": "s3:*", "Resource": "*", "Condition": {"ArnNotLike": {"aws:PrincipalArn": "arn:aws:iam::*:role/Admin"}}}`)},
+			decision: iamEffectivePermissionDenied,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"AdminOnly"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "SCP denying part of the resources",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Secret", "Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::data/secret/*"}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"Secret"}, Decision: iamDecisionExplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "SCP denying all of the resources",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Buckets", "Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::*"}`)},
+			decision: iamEffectivePermissionDenied,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"Buckets"}, Decision: iamDecisionExplicitDeny, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "SCP denying other resources",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "Logs", "Effect": "Deny", "Action": "s3:*", "Resource": "arn:aws:s3:::logs/*"}`)},
+			decision: iamEffectivePermissionAllowed,
+		},
+		{
+			name:     "SCP denying all but part of the resources",
+			sources:  iamPrincipalPolicySources{ServiceControlPolicies: scp(`{"Sid": "PublicOnly", "Effect": "Deny", "Action": "s3:*", "NotResource": "arn:aws:s3:::data/public/*"}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourceServiceControl, PolicyName: "Guard", TargetId: "r-root", Sids: []string{"PublicOnly"}, Decision: iamDecisionExplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "boundary allowing part of the resources",
+			sources:  iamPrincipalPolicySources{PermissionsBoundary: boundary(`{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::data/public/*"}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Decision: iamDecisionImplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "boundary allowing other resources",
+			sources:  iamPrincipalPolicySources{PermissionsBoundary: boundary(`{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::logs/*"}`)},
+			decision: iamEffectivePermissionDenied,
+			trim:     &iamPermissionTrim{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Decision: iamDecisionImplicitDeny, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "boundary allowing the resources of the user",
+			sources:  iamPrincipalPolicySources{PermissionsBoundary: boundary(`{"Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::data/${aws:username}/*"}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Decision: iamDecisionImplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "boundary with a condition",
+			sources:  iamPrincipalPolicySources{PermissionsBoundary: boundary(`{"Effect": "Allow", "Action": "s3:*", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}`)},
+			decision: iamEffectivePermissionAllowed,
+			trim:     &iamPermissionTrim{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Decision: iamDecisionImplicitDeny, Conditional: true, Actions: []string{"s3:getobject"}},
+		},
+		{
+			name:     "boundary with a condition outside the context",
+			sources:  iamPrincipalPolicySources{PermissionsBoundary: boundary(`{"Effect": "Allow", "Action": "s3:*", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}`)},
+			context:  map[string][]string{"aws:sourceip": {"192.0.2.1"}},
+			decision: iamEffectivePermissionDenied,
+			trim:     &iamPermissionTrim{Type: iamPolicySourcePermissionsBoundary, PolicyName: "boundary", Decision: iamDecisionImplicitDeny, Actions: []string{"s3:getobject"}},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			sources := testCase.sources
+			sources.Identity = identity
+			permissions, err := evaluateIamEffectivePermissions(catalogue, "arn:aws:iam::123456789012:role/deployer", sources, testCase.context)
+			if err != nil {
+				t.Fatal(err)
+			}
+			permission := permissions[0]
+			if permission.Decision != testCase.decision {
+				t.Errorf("expected %s, got %s", testCase.decision, permission.Decision)
+			}
+
+			var expectedTrims []iamPermissionTrim
+			var expectedConditionalActions []string
+			if testCase.trim != nil {
+				expectedTrims = []iamPermissionTrim{*testCase.trim}
+				if testCase.trim.Conditional {
+					expectedConditionalActions = testCase.trim.Actions
+				}
+			}
+			if !reflect.DeepEqual(permission.TrimmedBy, expectedTrims) {
+				t.Errorf("expected the trims %+v, got %+v", expectedTrims, permission.TrimmedBy)
+			}
+			if !reflect.DeepEqual(permission.ConditionalActions, expectedConditionalActions) {
+				t.Errorf("expected the conditional actions %v, got %v", expectedConditionalActions, permission.ConditionalActions)
+			}
+		})
+	}
+}
+
+func TestIamArnPatternsOverlap(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     string
+		covers   bool
+		overlaps bool
+	}{
+		{"*", "arn:aws:s3:::data/*", true, true},
+		{"arn:aws:s3:::*", "arn:aws:s3:::data/*", true, true},
+		{"arn:aws:s3:::data/secret/*", "arn:aws:s3:::data/*", false, true},
+		{"arn:aws:s3:::logs/*", "arn:aws:s3:::data/*", false, false},
+		{"arn:aws:s3:::data/*", "*", false, true},
+		{"arn:aws:s3:::data/*.csv", "arn:aws:s3:::data/2021/*", false, true},
+		{"arn:aws:s3:::data/??", "arn:aws:s3:::data/*", false, true},
+		{"arn:aws:s3:::data/?", "arn:aws:s3:::data/ab", false, false},
+		{"arn:aws:iam::123456789012:role/*", "arn:aws:iam::*:role/deployer", false, true},
+		{"arn:aws:iam::123456789012:role/*", "arn:aws:iam::210987654321:role/*", false, false},
+		// the wildcards of the account only match within the segment
+		{"arn:aws:iam::*:role/x", "arn:aws:iam::1:2:role/x", false, false},
+	} {
+		if covers := iamArnPatternCovers(testCase.a, testCase.b); covers != testCase.covers {
+			t.Errorf("expected %s to cover %s: %t, got %t", testCase.a, testCase.b, testCase.covers, covers)
+		}
+		if overlaps := iamArnPatternsOverlap(testCase.a, testCase.b); overlaps != testCase.overlaps {
+			t.Errorf("expected %s and %s to overlap: %t, got %t", testCase.a, testCase.b, testCase.overlaps, overlaps)
+		}
+	}
+}
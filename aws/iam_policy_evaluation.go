@@ -0,0 +1,534 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_policies_evaluation-logic.html
+//
+
+// Decisions of an evaluation, the same as the EvalDecision of SimulatePrincipalPolicy
+const (
+	iamDecisionAllowed      = "allowed"
+	iamDecisionExplicitDeny = "explicitDeny"
+	iamDecisionImplicitDeny = "implicitDeny"
+)
+
+// iamEvaluationRequest is the request a set of policies is evaluated for
+type iamEvaluationRequest struct {
+	// ARN of the principal making the request, or a service principal, e.g. ec2.amazonaws.com.
+	// Only matched against the Principal and NotPrincipal of resource-based policies.
+	PrincipalArn string
+	// e.g. s3:GetObject
+	Action string
+	// ARN of the resource, or * for actions which don't support resource-level permissions
+	ResourceArn string
+	// values of the condition keys, by lower case key, e.g. aws:sourceip
+	Context map[string][]string
+}
+
+// iamMatchedStatement is a statement which applies to the request
+type iamMatchedStatement struct {
+	// index of the policy in the evaluated policies
+	PolicyIndex int
+	Sid         string
+	Effect      string
+}
+
+// iamEvaluationResult is the result of evaluating a set of policies
+type iamEvaluationResult struct {
+	Decision string
+	// statements which decided the request, i.e. the denying statements of an explicit deny,
+	// or the allowing statements of an allow
+	MatchedStatements []iamMatchedStatement
+}
+
+// evaluateIamPolicies evaluates the canonical policies, e.g. all the policies of a principal, for
+// a request. An explicit deny in any policy overrides all allows, and a request which no statement
+// allows is implicitly denied. Boundaries, SCPs and session policies are not intersected, evaluate
+// them separately.
+func evaluateIamPolicies(policies []Policy, request iamEvaluationRequest) (*iamEvaluationResult, error) {
+	var allowed, denied []iamMatchedStatement
+	for policyIndex, policy := range policies {
+		for _, statement := range policy.Statements {
+			matches, err := iamStatementMatches(statement, request)
+			if err != nil {
+				return nil, fmt.Errorf("statement %q of policy %d: %s", statement.Sid, policyIndex, err.Error())
+			}
+			if !matches {
+				continue
+			}
+			matched := iamMatchedStatement{PolicyIndex: policyIndex, Sid: statement.Sid, Effect: statement.Effect}
+			switch statement.Effect {
+			case "Deny":
+				denied = append(denied, matched)
+			case "Allow":
+				allowed = append(allowed, matched)
+			}
+		}
+	}
+
+	if len(denied) > 0 {
+		return &iamEvaluationResult{Decision: iamDecisionExplicitDeny, MatchedStatements: denied}, nil
+	}
+	if len(allowed) > 0 {
+		return &iamEvaluationResult{Decision: iamDecisionAllowed, MatchedStatements: allowed}, nil
+	}
+	return &iamEvaluationResult{Decision: iamDecisionImplicitDeny, MatchedStatements: []iamMatchedStatement{}}, nil
+}
+
+// iamStatementMatches returns true if a statement applies to a request, i.e. its principal,
+// action, resource and conditions all match
+func iamStatementMatches(statement Statement, request iamEvaluationRequest) (bool, error) {
+	if !iamStatementPrincipalMatches(statement, request.PrincipalArn) {
+		return false, nil
+	}
+	if !iamStatementActionMatches(statement, request.Action) {
+		return false, nil
+	}
+	if !iamStatementResourceMatches(statement, request) {
+		return false, nil
+	}
+	return iamConditionsMatch(statement.Condition, request.Context)
+}
+
+func iamStatementActionMatches(statement Statement, action string) bool {
+	if statement.Action != nil {
+		return iamActionMatchesAny(statement.Action, action)
+	}
+	if statement.NotAction != nil {
+		return !iamActionMatchesAny(statement.NotAction, action)
+	}
+	return false
+}
+
+// iamActionMatchesAny returns true if an action matches one of the patterns, ignoring case
+func iamActionMatchesAny(patterns []string, action string) bool {
+	for _, pattern := range patterns {
+		if iamWildcardMatch(pattern, action, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// iamStatementResourceMatches returns true if the resource of a request matches a statement. A
+// statement without Resource and NotResource, e.g. of a trust policy, matches any resource.
+func iamStatementResourceMatches(statement Statement, request iamEvaluationRequest) bool {
+	resource := request.ResourceArn
+	if resource == "" {
+		resource = "*"
+	}
+	if statement.Resource != nil {
+		return iamResourceMatchesAny(statement.Resource, resource, request.Context)
+	}
+	if statement.NotResource != nil {
+		return !iamResourceMatchesAny(statement.NotResource, resource, request.Context)
+	}
+	return true
+}
+
+func iamResourceMatchesAny(patterns []string, resource string, context map[string][]string) bool {
+	for _, pattern := range patterns {
+		// a pattern with a variable missing from the context matches nothing
+		pattern, ok := substituteIamPolicyVariables(pattern, context)
+		if ok && iamArnMatch(pattern, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// iamArnMatch matches an ARN against an ARN pattern. The wildcards of the partition, service,
+// region and account of the pattern only match within their segment, those of the resource
+// segment match any characters, including : and /.
+func iamArnMatch(pattern string, arn string) bool {
+	if pattern == "*" {
+		return true
+	}
+	patternSegments := strings.SplitN(pattern, ":", 6)
+	arnSegments := strings.SplitN(arn, ":", 6)
+	if len(patternSegments) != 6 || len(arnSegments) != 6 {
+		return iamWildcardMatch(pattern, arn, false)
+	}
+	for i := range patternSegments {
+		if !iamWildcardMatch(patternSegments[i], arnSegments[i], false) {
+			return false
+		}
+	}
+	return true
+}
+
+// iamStatementPrincipalMatches returns true if the principal of a request matches a statement. A
+// statement without Principal and NotPrincipal, e.g. of an identity-based policy, matches any principal.
+func iamStatementPrincipalMatches(statement Statement, principal string) bool {
+	if statement.Principal != nil {
+		return iamPrincipalMatchesAny(statement.Principal, principal)
+	}
+	if statement.NotPrincipal != nil {
+		return !iamPrincipalMatchesAny(statement.NotPrincipal, principal)
+	}
+	return true
+}
+
+// iamPrincipalMatchesAny returns true if a principal is one of the principals of a Principal
+// element. An account ID or root ARN matches all the principals of the account, and a role
+// matches its sessions.
+func iamPrincipalMatchesAny(principals Principal, principal string) bool {
+	candidates := []string{principal}
+	if roleArn := iamAssumedRoleArn(principal); roleArn != "" {
+		candidates = append(candidates, roleArn)
+	}
+	account := ""
+	if segments := strings.Split(principal, ":"); len(segments) >= 6 && strings.HasPrefix(principal, "arn:") {
+		account = segments[4]
+	}
+
+	for _, values := range principals {
+		valueSlice, _ := values.([]string)
+		for _, value := range valueSlice {
+			if value == "*" {
+				return true
+			}
+			if account != "" && (value == account || value == fmt.Sprintf("arn:%s:iam::%s:root", strings.Split(principal, ":")[1], account)) {
+				return true
+			}
+			for _, candidate := range candidates {
+				if candidate != "" && value == candidate {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// iamAssumedRoleArn returns the role ARN of an assumed role session ARN,
+// e.g. arn:aws:iam::123456789012:role/admin for arn:aws:sts::123456789012:assumed-role/admin/session
+func iamAssumedRoleArn(principal string) string {
+	segments := strings.SplitN(principal, ":", 6)
+	if len(segments) != 6 || segments[2] != "sts" || !strings.HasPrefix(segments[5], "assumed-role/") {
+		return ""
+	}
+	parts := strings.Split(segments[5], "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	// the path of the role is not part of the session ARN
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", segments[1], segments[4], parts[1])
+}
+
+// iamConditionOperators maps the negated condition operators to the operator they negate.
+// The other supported operators map to themselves.
+var iamConditionOperators = map[string]struct {
+	base    string
+	negated bool
+}{
+	"stringequals":              {"stringequals", false},
+	"stringnotequals":           {"stringequals", true},
+	"stringequalsignorecase":    {"stringequalsignorecase", false},
+	"stringnotequalsignorecase": {"stringequalsignorecase", true},
+	"stringlike":                {"stringlike", false},
+	"stringnotlike":             {"stringlike", true},
+	"numericequals":             {"numericequals", false},
+	"numericnotequals":          {"numericequals", true},
+	"numericlessthan":           {"numericlessthan", false},
+	"numericlessthanequals":     {"numericlessthanequals", false},
+	"numericgreaterthan":        {"numericgreaterthan", false},
+	"numericgreaterthanequals":  {"numericgreaterthanequals", false},
+	"dateequals":                {"dateequals", false},
+	"datenotequals":             {"dateequals", true},
+	"datelessthan":              {"datelessthan", false},
+	"datelessthanequals":        {"datelessthanequals", false},
+	"dategreaterthan":           {"dategreaterthan", false},
+	"dategreaterthanequals":     {"dategreaterthanequals", false},
+	"bool":                      {"bool", false},
+	"binaryequals":              {"binaryequals", false},
+	"ipaddress":                 {"ipaddress", false},
+	"notipaddress":              {"ipaddress", true},
+	"arnequals":                 {"arnlike", false},
+	"arnlike":                   {"arnlike", false},
+	"arnnotequals":              {"arnlike", true},
+	"arnnotlike":                {"arnlike", true},
+}
+
+// iamConditionsMatch returns true if all the conditions of a statement match the request context.
+// The conditions are in canonical form, see canonicalCondition.
+func iamConditionsMatch(conditions map[string]interface{}, context map[string][]string) (bool, error) {
+	for operator, condition := range conditions {
+		keys, ok := condition.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("invalid condition %s", operator)
+		}
+		for key, values := range keys {
+			conditionValues, _ := values.([]string)
+			matches, err := iamConditionMatches(operator, key, conditionValues, context)
+			if err != nil || !matches {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// iamConditionMatches evaluates the condition of one operator and key, e.g. StringEquals
+// aws:username. The condition values are alternatives, any of them may match.
+func iamConditionMatches(operator string, key string, conditionValues []string, context map[string][]string) (bool, error) {
+	contextValues, present := context[strings.ToLower(key)]
+	if present && len(contextValues) == 0 {
+		present = false
+	}
+
+	name := strings.ToLower(operator)
+	if name == "null" {
+		if len(conditionValues) == 0 {
+			return false, fmt.Errorf("Null condition of %s has no value", key)
+		}
+		// Null true matches if the key is missing, false if it is present
+		return strings.EqualFold(conditionValues[0], "true") != present, nil
+	}
+
+	setOperator := ""
+	for _, prefix := range []string{"forallvalues:", "foranyvalue:"} {
+		if strings.HasPrefix(name, prefix) {
+			setOperator = prefix
+			name = strings.TrimPrefix(name, prefix)
+		}
+	}
+	ifExists := strings.HasSuffix(name, "ifexists")
+	name = strings.TrimSuffix(name, "ifexists")
+
+	op, ok := iamConditionOperators[name]
+	if !ok {
+		return false, fmt.Errorf("unsupported condition operator %s", operator)
+	}
+
+	// substitute the policy variables of the condition values, values with missing variables match nothing
+	var values []string
+	for _, conditionValue := range conditionValues {
+		if value, ok := substituteIamPolicyVariables(conditionValue, context); ok {
+			values = append(values, value)
+		}
+	}
+
+	// contextValueMatches returns true if a context value matches the condition, including negation
+	contextValueMatches := func(contextValue string) (bool, error) {
+		for _, value := range values {
+			matches, err := iamConditionValueMatches(op.base, contextValue, value)
+			if err != nil {
+				return false, err
+			}
+			if matches {
+				return !op.negated, nil
+			}
+		}
+		return op.negated, nil
+	}
+
+	switch setOperator {
+	case "forallvalues:":
+		// true for an empty set, e.g. a missing key
+		for _, contextValue := range contextValues {
+			matches, err := contextValueMatches(contextValue)
+			if err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	case "foranyvalue:":
+		for _, contextValue := range contextValues {
+			matches, err := contextValueMatches(contextValue)
+			if err != nil || matches {
+				return matches, err
+			}
+		}
+		return false, nil
+	}
+
+	if !present {
+		// a key missing from the request matches the negated operators, e.g. StringNotEquals
+		return ifExists || op.negated, nil
+	}
+	if op.negated {
+		// none of the values of a multivalued key may match
+		for _, contextValue := range contextValues {
+			matches, err := contextValueMatches(contextValue)
+			if err != nil || !matches {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	for _, contextValue := range contextValues {
+		matches, err := contextValueMatches(contextValue)
+		if err != nil || matches {
+			return matches, err
+		}
+	}
+	return false, nil
+}
+
+// iamConditionValueMatches compares a value of the request context to a value of a condition
+// with a (not negated) condition operator
+func iamConditionValueMatches(operator string, contextValue string, conditionValue string) (bool, error) {
+	switch operator {
+	case "stringequals", "binaryequals":
+		return contextValue == conditionValue, nil
+	case "stringequalsignorecase":
+		return strings.EqualFold(contextValue, conditionValue), nil
+	case "stringlike":
+		return iamWildcardMatch(conditionValue, contextValue, false), nil
+	case "bool":
+		return strings.EqualFold(contextValue, conditionValue), nil
+	case "arnlike":
+		return iamArnMatch(conditionValue, contextValue), nil
+	case "ipaddress":
+		return iamIpAddressMatches(contextValue, conditionValue)
+	}
+
+	if strings.HasPrefix(operator, "numeric") {
+		a, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			// a value of the wrong type doesn't match, like in AWS
+			return false, nil
+		}
+		b, err := strconv.ParseFloat(conditionValue, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid number %q in %s condition", conditionValue, operator)
+		}
+		return compareIamConditionValues(strings.TrimPrefix(operator, "numeric"), a-b), nil
+	}
+	if strings.HasPrefix(operator, "date") {
+		a, err := parseIamConditionDate(contextValue)
+		if err != nil {
+			return false, nil
+		}
+		b, err := parseIamConditionDate(conditionValue)
+		if err != nil {
+			return false, fmt.Errorf("invalid date %q in %s condition", conditionValue, operator)
+		}
+		return compareIamConditionValues(strings.TrimPrefix(operator, "date"), float64(a.Sub(b))), nil
+	}
+	return false, fmt.Errorf("unsupported condition operator %s", operator)
+}
+
+// compareIamConditionValues returns the result of a comparison, given the difference of the
+// context value and the condition value
+func compareIamConditionValues(comparison string, difference float64) bool {
+	switch comparison {
+	case "equals":
+		return difference == 0
+	case "lessthan":
+		return difference < 0
+	case "lessthanequals":
+		return difference <= 0
+	case "greaterthan":
+		return difference > 0
+	case "greaterthanequals":
+		return difference >= 0
+	}
+	return false
+}
+
+// parseIamConditionDate parses the date of a condition, in ISO 8601 format or as epoch seconds
+func parseIamConditionDate(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04Z07:00", "2006-01-02"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// iamIpAddressMatches returns true if an IP address is in a CIDR block, or is the IP address of the condition
+func iamIpAddressMatches(contextValue string, conditionValue string) (bool, error) {
+	ip := net.ParseIP(contextValue)
+	if ip == nil {
+		return false, nil
+	}
+	if !strings.Contains(conditionValue, "/") {
+		conditionIp := net.ParseIP(conditionValue)
+		if conditionIp == nil {
+			return false, fmt.Errorf("invalid IP address %q in IpAddress condition", conditionValue)
+		}
+		return conditionIp.Equal(ip), nil
+	}
+	_, cidr, err := net.ParseCIDR(conditionValue)
+	if err != nil {
+		return false, fmt.Errorf("invalid CIDR block %q in IpAddress condition", conditionValue)
+	}
+	return cidr.Contains(ip), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// matches policy variables, e.g. ${aws:username} or ${aws:username, 'default'}
+var iamPolicyVariableRegex = regexp.MustCompile(`\$\{([^},]+)(?:,\s*'([^']*)')?\}`)
+
+// substituteIamPolicyVariables replaces the policy variables of a value with their values from
+// the request context. It returns false if a variable without default is missing from the context.
+func substituteIamPolicyVariables(value string, context map[string][]string) (string, bool) {
+	if !strings.Contains(value, "${") {
+		return value, true
+	}
+	ok := true
+	substituted := iamPolicyVariableRegex.ReplaceAllStringFunc(value, func(variable string) string {
+		match := iamPolicyVariableRegex.FindStringSubmatch(variable)
+		key := strings.TrimSpace(match[1])
+		switch key {
+		case "*", "?", "$":
+			return key
+		}
+		if values := context[strings.ToLower(key)]; len(values) > 0 {
+			return values[0]
+		}
+		if strings.Contains(variable, ",") {
+			return match[2]
+		}
+		ok = false
+		return variable
+	})
+	return substituted, ok
+}
+
+// iamWildcardMatch returns true if a value matches a pattern, in which * matches any characters
+// and ? matches any single character
+func iamWildcardMatch(pattern string, value string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern = strings.ToLower(pattern)
+		value = strings.ToLower(value)
+	}
+	p, v := 0, 0
+	// position of the last * in the pattern, and of the value when it was reached
+	star, starValue := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, starValue = p, v
+			p++
+		case star != -1:
+			// let the last * match one more character
+			p = star + 1
+			starValue++
+			v = starValue
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateIamPolicies(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		policies     string
+		request      iamEvaluationRequest
+		expected     string
+		expectedSids []string
+	}{
+		{
+			name:         "allow",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Read", "Effect": "Allow", "Action": "s3:Get*", "Resource": "arn:aws:s3:::bucket/*"}}`,
+			request:      iamEvaluationRequest{Action: "s3:GetObject", ResourceArn: "arn:aws:s3:::bucket/key"},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Read"},
+		},
+		{
+			name:         "action is case insensitive",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Read", "Effect": "Allow", "Action": "S3:GETOBJECT", "Resource": "*"}}`,
+			request:      iamEvaluationRequest{Action: "s3:GetObject", ResourceArn: "arn:aws:s3:::bucket/key"},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Read"},
+		},
+		{
+			name:     "resource is case sensitive",
+			policies: `{"Version": "2012-10-17", "Statement": {"Sid": "Read", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::Bucket/*"}}`,
+			request:  iamEvaluationRequest{Action: "s3:GetObject", ResourceArn: "arn:aws:s3:::bucket/key"},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "resource wildcard spans : and /",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Logs", "Effect": "Allow", "Action": "logs:*", "Resource": "arn:aws:logs:*:123456789012:log-group:app*"}}`,
+			request:      iamEvaluationRequest{Action: "logs:PutLogEvents", ResourceArn: "arn:aws:logs:us-east-1:123456789012:log-group:app/web:log-stream:i-1"},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Logs"},
+		},
+		{
+			name:     "region wildcard does not span account",
+			policies: `{"Version": "2012-10-17", "Statement": {"Effect": "Allow", "Action": "logs:*", "Resource": "arn:aws:logs:us-*:log-group:app"}}`,
+			request:  iamEvaluationRequest{Action: "logs:PutLogEvents", ResourceArn: "arn:aws:logs:us-east-1:123456789012:log-group:app"},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "explicit deny overrides allow",
+			policies:     `[{"Version": "2012-10-17", "Statement": {"Sid": "All", "Effect": "Allow", "Action": "*", "Resource": "*"}}, {"Version": "2012-10-17", "Statement": {"Sid": "NoDelete", "Effect": "Deny", "Action": "s3:Delete*", "Resource": "*"}}]`,
+			request:      iamEvaluationRequest{Action: "s3:DeleteBucket", ResourceArn: "arn:aws:s3:::bucket"},
+			expected:     iamDecisionExplicitDeny,
+			expectedSids: []string{"NoDelete"},
+		},
+		{
+			name:         "not action",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "NotIam", "Effect": "Allow", "NotAction": "iam:*", "Resource": "*"}}`,
+			request:      iamEvaluationRequest{Action: "iam:CreateUser"},
+			expected:     iamDecisionImplicitDeny,
+			expectedSids: nil,
+		},
+		{
+			name:         "not resource",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "OtherBuckets", "Effect": "Deny", "Action": "s3:*", "NotResource": ["arn:aws:s3:::logs", "arn:aws:s3:::logs/*"]}}`,
+			request:      iamEvaluationRequest{Action: "s3:GetObject", ResourceArn: "arn:aws:s3:::data/key"},
+			expected:     iamDecisionExplicitDeny,
+			expectedSids: []string{"OtherBuckets"},
+		},
+		{
+			name:         "policy variable",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Home", "Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::home/${aws:username}/*"}}`,
+			request:      iamEvaluationRequest{Action: "s3:PutObject", ResourceArn: "arn:aws:s3:::home/alice/notes", Context: map[string][]string{"aws:username": {"alice"}}},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Home"},
+		},
+		{
+			name:     "missing policy variable",
+			policies: `{"Version": "2012-10-17", "Statement": {"Sid": "Home", "Effect": "Allow", "Action": "s3:*", "Resource": "arn:aws:s3:::home/${aws:username}/*"}}`,
+			request:  iamEvaluationRequest{Action: "s3:PutObject", ResourceArn: "arn:aws:s3:::home/alice/notes"},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "ip address condition",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Office", "Effect": "Allow", "Action": "*", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": ["203.0.113.0/24"]}}}}`,
+			request:      iamEvaluationRequest{Action: "ec2:DescribeInstances", Context: map[string][]string{"aws:sourceip": {"203.0.113.7"}}},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Office"},
+		},
+		{
+			name:     "condition key missing",
+			policies: `{"Version": "2012-10-17", "Statement": {"Sid": "Office", "Effect": "Allow", "Action": "*", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": ["203.0.113.0/24"]}}}}`,
+			request:  iamEvaluationRequest{Action: "ec2:DescribeInstances"},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "negated condition with missing key",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "OutsideOrg", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"StringNotEquals": {"aws:PrincipalOrgID": "o-123"}}}}`,
+			request:      iamEvaluationRequest{Action: "s3:GetObject"},
+			expected:     iamDecisionExplicitDeny,
+			expectedSids: []string{"OutsideOrg"},
+		},
+		{
+			name:     "bool if exists",
+			policies: `{"Version": "2012-10-17", "Statement": {"Sid": "NoMfa", "Effect": "Deny", "Action": "*", "Resource": "*", "Condition": {"BoolIfExists": {"aws:MultiFactorAuthPresent": "false"}}}}`,
+			request:  iamEvaluationRequest{Action: "s3:GetObject", Context: map[string][]string{"aws:multifactorauthpresent": {"true"}}},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "numeric and date conditions",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Recent", "Effect": "Allow", "Action": "*", "Resource": "*", "Condition": {"NumericLessThan": {"aws:MultiFactorAuthAge": "3600"}, "DateGreaterThan": {"aws:CurrentTime": "2020-01-01T00:00:00Z"}}}}`,
+			request:      iamEvaluationRequest{Action: "iam:ListUsers", Context: map[string][]string{"aws:multifactorauthage": {"60"}, "aws:currenttime": {"2021-06-01T12:00:00Z"}}},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Recent"},
+		},
+		{
+			name:     "for all values",
+			policies: `{"Version": "2012-10-17", "Statement": {"Sid": "Tags", "Effect": "Allow", "Action": "ec2:CreateTags", "Resource": "*", "Condition": {"ForAllValues:StringEquals": {"aws:TagKeys": ["env", "team"]}}}}`,
+			request:  iamEvaluationRequest{Action: "ec2:CreateTags", Context: map[string][]string{"aws:tagkeys": {"env", "owner"}}},
+			expected: iamDecisionImplicitDeny,
+		},
+		{
+			name:         "principal of a resource-based policy",
+			policies:     `{"Version": "2012-10-17", "Statement": {"Sid": "Account", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*"}}`,
+			request:      iamEvaluationRequest{PrincipalArn: "arn:aws:sts::123456789012:assumed-role/reader/session", Action: "s3:GetObject", ResourceArn: "arn:aws:s3:::bucket/key"},
+			expected:     iamDecisionAllowed,
+			expectedSids: []string{"Account"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			policies, err := parseIamPolicyDocuments(testCase.policies)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := evaluateIamPolicies(policies, testCase.request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Decision != testCase.expected {
+				t.Errorf("expected decision %s, got %s", testCase.expected, result.Decision)
+			}
+			var sids []string
+			for _, statement := range result.MatchedStatements {
+				sids = append(sids, statement.Sid)
+			}
+			if !reflect.DeepEqual(sids, testCase.expectedSids) {
+				t.Errorf("expected matched statements %v, got %v", testCase.expectedSids, sids)
+			}
+		})
+	}
+}
+
+func TestIamWildcardMatch(t *testing.T) {
+	for _, testCase := range []struct {
+		pattern  string
+		value    string
+		expected bool
+	}{
+		{"*", "", true},
+		{"s3:*", "s3:GetObject", true},
+		{"s3:Get*Acl", "s3:GetObjectAcl", true},
+		{"s3:Get*Acl", "s3:GetObject", false},
+		{"s3:Get?bject", "s3:GetObject", true},
+		{"s3:Get?bject", "s3:Getbject", false},
+		{"a*b*c", "aXbYbZc", true},
+	} {
+		if actual := iamWildcardMatch(testCase.pattern, testCase.value, false); actual != testCase.expected {
+			t.Errorf("expected %q matching %q to be %t", testCase.pattern, testCase.value, testCase.expected)
+		}
+	}
+}
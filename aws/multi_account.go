@@ -0,0 +1,238 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+const matrixKeyAccount = "account_id"
+
+// struct to store the organization accounts a connection fans out to
+type organizationAccountData struct {
+	// account and partition of the identity the connection is configured with
+	CallerAccountId string
+	CallerPartition string
+	// active accounts matching the organization filters in the connection config
+	AccountIds []string
+}
+
+// BuildAccountList :: return a list of matrix items, one per organization account, for GLOBAL resources.
+// Returns nil unless the connection config sets organization_role_name.
+func BuildAccountList(ctx context.Context, connection *plugin.Connection) []map[string]interface{} {
+	if GetConfig(connection).OrganizationRoleName == nil {
+		return nil
+	}
+	return expandMatrixForAccounts(ctx, connection, []map[string]interface{}{{}})
+}
+
+// expandMatrixForAccounts returns a copy of each matrix item per organization account,
+// with the account_id added. The matrix is returned unchanged if the connection is not
+// organization-wide, or if the accounts can't be listed.
+func expandMatrixForAccounts(ctx context.Context, connection *plugin.Connection, matrix []map[string]interface{}) []map[string]interface{} {
+	if GetConfig(connection).OrganizationRoleName == nil {
+		return matrix
+	}
+	accountData, err := listOrganizationAccounts(ctx, getConnectionQueryData(connection))
+	if err != nil {
+		// e.g. missing organizations permissions or throttling. The failure is not cached, so the
+		// next query lists the accounts again, and this one runs in the connection's own account.
+		plugin.Logger(ctx).Error("expandMatrixForAccounts", "connection", connection.Name, "list_accounts_error", err)
+		return matrix
+	}
+
+	expanded := make([]map[string]interface{}, 0, len(matrix)*len(accountData.AccountIds))
+	for _, accountId := range accountData.AccountIds {
+		for _, item := range matrix {
+			accountItem := map[string]interface{}{matrixKeyAccount: accountId}
+			for k, v := range item {
+				accountItem[k] = v
+			}
+			expanded = append(expanded, accountItem)
+		}
+	}
+	return expanded
+}
+
+// listOrganizationAccounts returns the active organization accounts which match the
+// organization_unit_ids and organization_account_ids filters of the connection config
+func listOrganizationAccounts(ctx context.Context, d *plugin.QueryData) (*organizationAccountData, error) {
+	cacheKey := connectionCacheKey(d, "listOrganizationAccounts")
+
+	// if found in cache, return the result
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*organizationAccountData), nil
+	}
+
+	awsConfig := GetConfig(d.Connection)
+
+	// identify the account we are running as, which is queried with its own credentials
+	stsSvc, err := StsService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	callerIdentity, err := stsSvc.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := OrganizationService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []*organizations.Account
+	if len(awsConfig.OrganizationUnitIds) > 0 {
+		for _, ouId := range awsConfig.OrganizationUnitIds {
+			ouAccounts, err := listOrganizationUnitAccounts(svc, ouId)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, ouAccounts...)
+		}
+	} else {
+		err = svc.ListAccountsPages(
+			&organizations.ListAccountsInput{},
+			func(page *organizations.ListAccountsOutput, isLast bool) bool {
+				accounts = append(accounts, page.Accounts...)
+				return !isLast
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var accountIds []string
+	for _, account := range accounts {
+		if aws.StringValue(account.Status) != organizations.AccountStatusActive {
+			continue
+		}
+		if matchesAccountIdPatterns(*account.Id, awsConfig.OrganizationAccountIds) {
+			accountIds = append(accountIds, *account.Id)
+		}
+	}
+
+	data := &organizationAccountData{
+		CallerAccountId: *callerIdentity.Account,
+		CallerPartition: strings.Split(*callerIdentity.Arn, ":")[1],
+		AccountIds:      unique(accountIds),
+	}
+
+	// save to extension cache
+	d.ConnectionManager.Cache.Set(cacheKey, data)
+	return data, nil
+}
+
+// listOrganizationUnitAccounts returns the accounts in an organizational unit and all of its child units
+func listOrganizationUnitAccounts(svc organizationsiface.OrganizationsAPI, parentId string) ([]*organizations.Account, error) {
+	var accounts []*organizations.Account
+	err := svc.ListAccountsForParentPages(
+		&organizations.ListAccountsForParentInput{ParentId: aws.String(parentId)},
+		func(page *organizations.ListAccountsForParentOutput, isLast bool) bool {
+			accounts = append(accounts, page.Accounts...)
+			return !isLast
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var childIds []string
+	err = svc.ListOrganizationalUnitsForParentPages(
+		&organizations.ListOrganizationalUnitsForParentInput{ParentId: aws.String(parentId)},
+		func(page *organizations.ListOrganizationalUnitsForParentOutput, isLast bool) bool {
+			for _, ou := range page.OrganizationalUnits {
+				childIds = append(childIds, *ou.Id)
+			}
+			return !isLast
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, childId := range childIds {
+		childAccounts, err := listOrganizationUnitAccounts(svc, childId)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, childAccounts...)
+	}
+	return accounts, nil
+}
+
+func matchesAccountIdPatterns(accountId string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, accountId); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getMatrixAccountId returns the organization account being queried, or an empty
+// string if the connection is not organization-wide
+func getMatrixAccountId(d *plugin.QueryData) string {
+	if GetConfig(d.Connection).OrganizationRoleName == nil {
+		return ""
+	}
+	return d.KeyColumnQualString(matrixKeyAccount)
+}
+
+// scopedCacheKey prefixes a cache key with the connection and the organization account being
+// queried, so sessions, services and cached results are not shared between connections or accounts
+func scopedCacheKey(d *plugin.QueryData, key string) string {
+	if accountId := getMatrixAccountId(d); accountId != "" {
+		key = fmt.Sprintf("%s-%s", accountId, key)
+	}
+	return connectionCacheKey(d, key)
+}
+
+// getOrganizationAccountCredentials returns credentials for organization_role_name in the
+// given account, assumed from the connection's own session. It returns nil for the
+// account the connection runs as, which is queried with its own credentials.
+func getOrganizationAccountCredentials(ctx context.Context, d *plugin.QueryData, baseSession *session.Session, accountId string) (*credentials.Credentials, error) {
+	cacheKey := connectionCacheKey(d, fmt.Sprintf("OrganizationAccountCredentials-%s", accountId))
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*credentials.Credentials), nil
+	}
+
+	// listed without the account being queried, which would need these credentials
+	accountData, err := listOrganizationAccounts(ctx, getConnectionQueryData(d.Connection))
+	if err != nil {
+		return nil, err
+	}
+	if accountId == accountData.CallerAccountId {
+		return nil, nil
+	}
+
+	awsConfig := GetConfig(d.Connection)
+	roleArn := fmt.Sprintf("arn:%s:iam::%s:role/%s", accountData.CallerPartition, accountId, *awsConfig.OrganizationRoleName)
+
+	creds := stscreds.NewCredentials(baseSession, roleArn, func(p *stscreds.AssumeRoleProvider) {
+		if awsConfig.RoleSessionName != nil {
+			p.RoleSessionName = *awsConfig.RoleSessionName
+		}
+	})
+	if _, err := creds.GetWithContext(ctx); err != nil {
+		plugin.Logger(ctx).Error("getOrganizationAccountCredentials", "account_id", accountId, "assume_role_error", err)
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, creds)
+	return creds, nil
+}
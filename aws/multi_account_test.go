@@ -0,0 +1,55 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+func TestExpandMatrixForAccounts(t *testing.T) {
+	ctx := newTestContext()
+	defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "111111111111")})()
+
+	matrix := []map[string]interface{}{{matrixKeyRegion: "us-east-1"}}
+
+	for _, testCase := range []struct {
+		name          string
+		organizations *fakeOrganizations
+		expected      []map[string]interface{}
+	}{
+		{
+			name: "accounts",
+			organizations: &fakeOrganizations{Accounts: []*organizations.Account{
+				{Id: aws.String("111111111111"), Status: aws.String(organizations.AccountStatusActive)},
+				{Id: aws.String("222222222222"), Status: aws.String(organizations.AccountStatusActive)},
+				{Id: aws.String("333333333333"), Status: aws.String(organizations.AccountStatusSuspended)},
+			}},
+			expected: []map[string]interface{}{
+				{matrixKeyRegion: "us-east-1", matrixKeyAccount: "111111111111"},
+				{matrixKeyRegion: "us-east-1", matrixKeyAccount: "222222222222"},
+			},
+		},
+		{
+			name:          "accounts can't be listed",
+			organizations: &fakeOrganizations{AccountsError: awserr.New("AccessDeniedException", "You don't have permissions to access this resource.", nil)},
+			expected:      matrix,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			defer registerServiceClient("organizations", testCase.organizations)()
+
+			connection := newTestConnection("test_expand_matrix_"+testCase.name, "AKIDCONNECTIONONE", "http://localhost", []string{"us-east-1"})
+			config := connection.Config.(awsConfig)
+			config.OrganizationRoleName = aws.String("OrganizationAccountAccessRole")
+			connection.Config = config
+
+			actual := expandMatrixForAccounts(ctx, connection, matrix)
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected matrix %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -2,11 +2,10 @@ package aws
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"path"
-	"strings"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/connection"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -14,26 +13,78 @@ import (
 
 const matrixKeyRegion = "region"
 
-var pluginQueryData *plugin.QueryData
+// errNoServiceRegion is returned by the service constructors of the services which are not offered in
+// every region when they are called without a region, i.e. when BuildRegionListForService returned an
+// empty matrix and the SDK runs the list or get call once without a matrix item. Those calls are
+// skipped, see shouldIgnoreNoServiceRegion.
+var errNoServiceRegion = errors.New("no region of the connection offers the service")
+
+// matrixConnectionManager caches the data of the matrix builders, which are called without
+// query data. It is shared by all connections, see connectionCacheKey.
+var matrixConnectionManager = connection.NewManager()
+
+// getConnectionQueryData returns query data for a connection, for the functions
+// which are called without one, e.g. the matrix builders
+func getConnectionQueryData(connection *plugin.Connection) *plugin.QueryData {
+	return &plugin.QueryData{
+		Connection:        connection,
+		ConnectionManager: matrixConnectionManager,
+	}
+}
 
-func init() {
-	pluginQueryData = &plugin.QueryData{
-		ConnectionManager: connection.NewManager(),
+// connectionCacheKey prefixes a cache key with the connection name, as a plugin
+// instance and its caches can be shared by several connections
+func connectionCacheKey(d *plugin.QueryData, key string) string {
+	if d.Connection == nil {
+		return key
 	}
+	return fmt.Sprintf("%s-%s", d.Connection.Name, key)
 }
 
 // BuildRegionList :: return a list of matrix items, one per region specified in the connection config
+// (and one per region and account for organization-wide connections)
 func BuildRegionList(ctx context.Context, connection *plugin.Connection) []map[string]interface{} {
-	pluginQueryData.Connection = connection
+	return expandMatrixForAccounts(ctx, connection, buildRegionMatrix(ctx, connection))
+}
+
+// BuildRegionListForService :: return a matrix item builder for the tables of a service which is not offered
+// in every region. It only includes the regions specified in the connection config which offer the service,
+// according to the SDK endpoints metadata, so no calls are made to regions without an endpoint. If none of
+// them offer the service, the matrix is empty and the table returns no rows.
+// The service is identified by its SDK endpoint ID, e.g. "auditmanager" or "wellarchitected".
+func BuildRegionListForService(serviceID string) plugin.MatrixItemFunc {
+	return func(ctx context.Context, connection *plugin.Connection) []map[string]interface{} {
+		regionMatrix := buildRegionMatrix(ctx, connection)
+
+		matrix := make([]map[string]interface{}, 0, len(regionMatrix))
+		for _, item := range regionMatrix {
+			if isServiceAvailableInRegion(serviceID, item[matrixKeyRegion].(string)) {
+				matrix = append(matrix, item)
+			}
+		}
+
+		// the table is skipped, see errNoServiceRegion
+		if len(matrix) == 0 {
+			plugin.Logger(ctx).Warn("BuildRegionListForService", "service", serviceID, "no_configured_region_offers_service", regionMatrix)
+			return matrix
+		}
+
+		return expandMatrixForAccounts(ctx, connection, matrix)
+	}
+}
+
+// buildRegionMatrix returns a list of matrix items, one per region specified in the connection config
+func buildRegionMatrix(ctx context.Context, connection *plugin.Connection) []map[string]interface{} {
+	d := getConnectionQueryData(connection)
 
 	// cache matrix
-	cacheKey := "RegionListMatrix"
-	if cachedData, ok := pluginQueryData.ConnectionManager.Cache.Get(cacheKey); ok {
+	cacheKey := connectionCacheKey(d, "RegionListMatrix")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
 		return cachedData.([]map[string]interface{})
 	}
 
-	defaultAwsRegion := GetDefaultAwsRegion(pluginQueryData)
-	regionData, _ := listRegions(ctx, pluginQueryData)
+	defaultAwsRegion := GetDefaultAwsRegion(d)
+	catalogue := getRegionCatalogue(ctx, d)
 	var allRegions []string
 
 	// retrieve regions from connection config
@@ -41,23 +92,24 @@ func BuildRegionList(ctx context.Context, connection *plugin.Connection) []map[s
 	// Get only the regions as required by config file
 	if awsConfig.Regions != nil {
 		for _, pattern := range awsConfig.Regions {
-			for _, validRegion := range regionData["AllRegions"] {
+			for _, validRegion := range catalogue.Regions {
 				if ok, _ := path.Match(pattern, validRegion); ok {
 					allRegions = append(allRegions, validRegion)
 				}
 			}
+			// regions which are newer than both the SDK and the DescribeRegions fallback
+			// are still queried, as long as they belong to the connection's partition
+			if !isRegionPattern(pattern) && !helpers.StringSliceContains(catalogue.Regions, pattern) {
+				if partition, ok := getPartitionForRegion(pattern); ok && partition.ID() == catalogue.Partition {
+					allRegions = append(allRegions, pattern)
+				}
+			}
 		}
 	}
 
 	if len(allRegions) > 0 {
-		uniqueRegions := unique(allRegions)
-
-		if len(getInvalidRegions(uniqueRegions)) > 0 {
-			panic("\n\nConnection config have invalid regions: " + strings.Join(getInvalidRegions(uniqueRegions), ", "))
-		}
-
 		// Remove inactive regions from the list
-		finalRegions := helpers.StringSliceDiff(uniqueRegions, regionData["NotOptedRegions"])
+		finalRegions := helpers.StringSliceDiff(unique(allRegions), catalogue.NotOptedRegions())
 
 		matrix := make([]map[string]interface{}, len(finalRegions))
 		for i, region := range finalRegions {
@@ -65,7 +117,7 @@ func BuildRegionList(ctx context.Context, connection *plugin.Connection) []map[s
 		}
 
 		// set cache
-		pluginQueryData.ConnectionManager.Cache.Set(cacheKey, matrix)
+		d.ConnectionManager.Cache.Set(cacheKey, matrix)
 		return matrix
 	}
 
@@ -74,105 +126,19 @@ func BuildRegionList(ctx context.Context, connection *plugin.Connection) []map[s
 	}
 
 	// set cache
-	pluginQueryData.ConnectionManager.Cache.Set(cacheKey, matrix)
+	d.ConnectionManager.Cache.Set(cacheKey, matrix)
 	return matrix
 }
 
-func getInvalidRegions(regions []string) []string {
-	awsRegions := []string{
-		"af-south-1", "ap-east-1", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-south-1", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-north-1", "eu-south-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1", "sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2", "us-gov-east-1", "us-gov-west-1", "cn-north-1", "cn-northwest-1"}
-
-	invalidRegions := []string{}
-	for _, region := range regions {
-		if !helpers.StringSliceContains(awsRegions, region) {
-			invalidRegions = append(invalidRegions, region)
-		}
-	}
-	return invalidRegions
-}
-
 // BuildWafRegionList :: return a list of matrix items for AWS WAF resources, one per region specified in the connection config
 func BuildWafRegionList(ctx context.Context, connection *plugin.Connection) []map[string]interface{} {
-	var regionMatrix []map[string]interface{}
-	if cachedData, ok := pluginQueryData.ConnectionManager.Cache.Get("RegionListMatrix"); ok {
-		regionMatrix = cachedData.([]map[string]interface{})
-	} else {
-		regionMatrix = BuildRegionList(ctx, connection)
-	}
+	regionMatrix := buildRegionMatrix(ctx, connection)
 
 	matrix := make([]map[string]interface{}, 1, len(regionMatrix)+1)
 	matrix[0] = map[string]interface{}{matrixKeyRegion: "global"}
 	matrix = append(matrix, regionMatrix...)
 
-	return matrix
-}
-
-func listRegions(ctx context.Context, d *plugin.QueryData) (map[string][]string, error) {
-	cacheKey := "listRegions"
-
-	// if found in cache, return the result
-	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
-		return cachedData.(map[string][]string), nil
-	}
-
-	awsCommercialRegions := []string{
-		"af-south-1", "ap-east-1", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-south-1", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-north-1", "eu-south-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1", "sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2"}
-	awsUsGovRegions := []string{"us-gov-east-1", "us-gov-west-1"}
-	awsChinaGovRegions := []string{"cn-north-1", "cn-northwest-1"}
-	defaultRegions := awsCommercialRegions
-
-	defaultRegion := GetDefaultAwsRegion(pluginQueryData)
-	if strings.HasPrefix(defaultRegion, "us-gov") {
-		defaultRegions = awsUsGovRegions
-	} else if strings.HasPrefix(defaultRegion, "cn") {
-		defaultRegions = awsChinaGovRegions
-	}
-
-	data := map[string][]string{
-		"AllRegions":    defaultRegions,
-		"ActiveRegions": defaultRegions,
-	}
-
-	// Create Session
-	svc, err := Ec2Service(ctx, d, defaultRegion)
-	if err != nil {
-		// handle in case user doesn't have access to ec2 service
-		// save to extension cache
-		d.ConnectionManager.Cache.Set(cacheKey, data)
-		return data, nil
-	}
-
-	params := &ec2.DescribeRegionsInput{
-		AllRegions: aws.Bool(true),
-	}
-
-	// execute list call
-	resp, err := svc.DescribeRegions(params)
-	if err != nil {
-		// handle in case user doesn't have access to ec2 service
-		d.ConnectionManager.Cache.Set(cacheKey, data)
-		return data, nil
-	}
-
-	var activeRegions []string
-	var notOptedRegions []string
-	var allRegions []string
-	for _, region := range resp.Regions {
-		allRegions = append(allRegions, *region.RegionName)
-		if *region.OptInStatus != "not-opted-in" {
-			activeRegions = append(activeRegions, *region.RegionName)
-		} else {
-			notOptedRegions = append(notOptedRegions, *region.RegionName)
-		}
-	}
-
-	data["AllRegions"] = allRegions
-	data["ActiveRegions"] = activeRegions
-	data["NotOptedRegions"] = notOptedRegions
-
-	// save to extension cache
-	d.ConnectionManager.Cache.Set(cacheKey, data)
-	return data, err
+	return expandMatrixForAccounts(ctx, connection, matrix)
 }
 
 func unique(stringSlice []string) []string {
@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/context_key"
+)
+
+// regions returned by DescribeRegions for each access key, with their opt-in status
+var testAccountRegions = map[string]map[string]string{
+	"AKIDCONNECTIONONE": {
+		"ap-east-1": "not-opted-in",
+		"eu-west-1": "opt-in-not-required",
+		"us-east-1": "opt-in-not-required",
+		"us-west-2": "opt-in-not-required",
+	},
+	"AKIDCONNECTIONTWO": {
+		"eu-central-1": "opt-in-not-required",
+		"eu-west-1":    "opt-in-not-required",
+		"us-east-1":    "opt-in-not-required",
+	},
+}
+
+// newDescribeRegionsServer returns a fake EC2 endpoint, which answers DescribeRegions
+// with the regions of the account the request was signed for
+func newDescribeRegionsServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("Action") != "DescribeRegions" {
+			t.Errorf("unexpected request: %s %v", r.Method, r.Form)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var regions map[string]string
+		for accessKey, accountRegions := range testAccountRegions {
+			if strings.Contains(r.Header.Get("Authorization"), "Credential="+accessKey+"/") {
+				regions = accountRegions
+			}
+		}
+		if regions == nil {
+			t.Errorf("request signed with an unknown access key: %s", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		var items strings.Builder
+		for name, optInStatus := range regions {
+			fmt.Fprintf(&items, "<item><regionName>%s</regionName><regionEndpoint>ec2.%s.amazonaws.com</regionEndpoint><optInStatus>%s</optInStatus></item>", name, name, optInStatus)
+		}
+		fmt.Fprintf(w, `<DescribeRegionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><requestId>test</requestId><regionInfo>%s</regionInfo></DescribeRegionsResponse>`, items.String())
+	}))
+}
+
+func newTestConnection(name string, accessKey string, endpointUrl string, regions []string) *plugin.Connection {
+	secretKey := "secret"
+	return &plugin.Connection{
+		Name: name,
+		Config: awsConfig{
+			Regions:     regions,
+			AccessKey:   &accessKey,
+			SecretKey:   &secretKey,
+			EndpointUrl: &endpointUrl,
+		},
+	}
+}
+
+func newTestContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+func TestBuildRegionListPerConnection(t *testing.T) {
+	server := newDescribeRegionsServer(t)
+	defer server.Close()
+	ctx := newTestContext()
+
+	connectionOne := newTestConnection("test_region_list_one", "AKIDCONNECTIONONE", server.URL, []string{"us-*", "ap-east-1"})
+	connectionTwo := newTestConnection("test_region_list_two", "AKIDCONNECTIONTWO", server.URL, []string{"eu-west-1", "eu-*"})
+
+	// build each matrix twice, so the second build of each connection comes from the cache
+	for i := 0; i < 2; i++ {
+		for _, testCase := range []struct {
+			connection *plugin.Connection
+			expected   []map[string]interface{}
+		}{
+			{connectionOne, []map[string]interface{}{{matrixKeyRegion: "us-east-1"}, {matrixKeyRegion: "us-west-2"}}},
+			{connectionTwo, []map[string]interface{}{{matrixKeyRegion: "eu-west-1"}, {matrixKeyRegion: "eu-central-1"}}},
+		} {
+			matrix := BuildRegionList(ctx, testCase.connection)
+			if !reflect.DeepEqual(matrix, testCase.expected) {
+				t.Errorf("connection %s: expected matrix %v, got %v", testCase.connection.Name, testCase.expected, matrix)
+			}
+		}
+	}
+}
+
+func TestBuildRegionListForService(t *testing.T) {
+	server := newDescribeRegionsServer(t)
+	defer server.Close()
+	ctx := newTestContext()
+
+	// Device Farm is only offered in us-west-2
+	buildMatrix := BuildRegionListForService("devicefarm")
+
+	for _, testCase := range []struct {
+		connection *plugin.Connection
+		expected   []map[string]interface{}
+	}{
+		{newTestConnection("test_region_list_service_one", "AKIDCONNECTIONONE", server.URL, []string{"us-*"}), []map[string]interface{}{{matrixKeyRegion: "us-west-2"}}},
+		{newTestConnection("test_region_list_service_two", "AKIDCONNECTIONTWO", server.URL, []string{"eu-*"}), []map[string]interface{}{}},
+	} {
+		matrix := buildMatrix(ctx, testCase.connection)
+		if !reflect.DeepEqual(matrix, testCase.expected) {
+			t.Errorf("connection %s: expected matrix %v, got %v", testCase.connection.Name, testCase.expected, matrix)
+		}
+	}
+
+	// the list and get calls made without a region are skipped
+	shouldIgnoreError := shouldIgnoreNoServiceRegion(nil)
+	if !shouldIgnoreError(fmt.Errorf("region must be passed DeviceFarmService: %w", errNoServiceRegion)) {
+		t.Error("expected the error of a call without a region to be ignored")
+	}
+	if shouldIgnoreError(fmt.Errorf("region must be passed EC2Service")) {
+		t.Error("expected other errors not to be ignored")
+	}
+}
+
+func TestGetDefaultAwsRegionPerConnection(t *testing.T) {
+	connectionOne := newTestConnection("test_default_region_one", "AKIDCONNECTIONONE", "http://localhost", []string{"us-west-2", "us-east-1"})
+	connectionTwo := newTestConnection("test_default_region_two", "AKIDCONNECTIONTWO", "http://localhost", []string{"cn-*"})
+
+	for i := 0; i < 2; i++ {
+		if region := GetDefaultAwsRegion(getConnectionQueryData(connectionOne)); region != "us-west-2" {
+			t.Errorf("connection %s: expected default region us-west-2, got %s", connectionOne.Name, region)
+		}
+		if region := GetDefaultAwsRegion(getConnectionQueryData(connectionTwo)); region != "cn-northwest-1" {
+			t.Errorf("connection %s: expected default region cn-northwest-1, got %s", connectionTwo.Name, region)
+		}
+	}
+}
+
+func TestGetSessionPerConnection(t *testing.T) {
+	ctx := newTestContext()
+	connectionOne := newTestConnection("test_session_one", "AKIDCONNECTIONONE", "http://localhost", []string{"us-east-1"})
+	connectionTwo := newTestConnection("test_session_two", "AKIDCONNECTIONTWO", "http://localhost", []string{"us-east-1"})
+
+	for i := 0; i < 2; i++ {
+		for _, testCase := range []struct {
+			connection *plugin.Connection
+			expected   string
+		}{
+			{connectionOne, "AKIDCONNECTIONONE"},
+			{connectionTwo, "AKIDCONNECTIONTWO"},
+		} {
+			sess, err := getSession(ctx, getConnectionQueryData(testCase.connection), "us-east-1")
+			if err != nil {
+				t.Fatalf("connection %s: %s", testCase.connection.Name, err.Error())
+			}
+			creds, err := sess.Config.Credentials.Get()
+			if err != nil {
+				t.Fatalf("connection %s: %s", testCase.connection.Name, err.Error())
+			}
+			if creds.AccessKeyID != testCase.expected {
+				t.Errorf("connection %s: expected session for access key %s, got %s", testCase.connection.Name, testCase.expected, creds.AccessKeyID)
+			}
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package aws
 
 import (
+	"context"
+	"errors"
+	"path"
+
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -15,3 +19,35 @@ func isNotFoundError(notFoundErrors []string) plugin.ErrorPredicate {
 		return false
 	}
 }
+
+// function which returns an ErrorPredicate ignoring the AWS error codes matching any of
+// the given patterns (e.g. "AccessDenied*"), in addition to the errors ignored by next.
+// Ignored errors are logged, so a denied region or service is skipped but not silently.
+func shouldIgnoreErrorCodes(ctx context.Context, tableName string, patterns []string, next plugin.ErrorPredicate) plugin.ErrorPredicate {
+	return func(err error) bool {
+		if next != nil && next(err) {
+			return true
+		}
+		if awsErr, ok := err.(awserr.Error); ok {
+			for _, pattern := range patterns {
+				if ok, _ := path.Match(pattern, awsErr.Code()); ok {
+					plugin.Logger(ctx).Warn("shouldIgnoreErrorCodes", "table", tableName, "ignored_error", err)
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// function which returns an ErrorPredicate ignoring errNoServiceRegion, in addition to the
+// errors ignored by next, so the tables of a service none of the connection's regions offer
+// return no rows rather than an error
+func shouldIgnoreNoServiceRegion(next plugin.ErrorPredicate) plugin.ErrorPredicate {
+	return func(err error) bool {
+		if next != nil && next(err) {
+			return true
+		}
+		return errors.Is(err, errNoServiceRegion)
+	}
+}
@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// listPageSize returns the page size of a paginated list call. If the query has a limit below
+// the maximum page size, only that many items are requested per page, but at least the minimum
+// page size of the call, e.g. DescribeInstances rejects a MaxResults below 5.
+func listPageSize(d *plugin.QueryData, minPageSize int64, maxPageSize int64) *int64 {
+	pageSize := maxPageSize
+	if limit := d.QueryContext.Limit; limit != nil && *limit < pageSize {
+		pageSize = *limit
+	}
+	if pageSize < minPageSize {
+		pageSize = minPageSize
+	}
+	return aws.Int64(pageSize)
+}
+
+// streamListItem streams an item of a list call and returns false once the query has all the
+// rows it needs or has been cancelled, so the page callback can stop paging, e.g.
+//
+//	for _, item := range page.Items {
+//		if !streamListItem(ctx, d, item) {
+//			return false
+//		}
+//	}
+//	return !lastPage
+func streamListItem(ctx context.Context, d *plugin.QueryData, item interface{}) bool {
+	d.StreamListItem(ctx, item)
+	// if there is a limit, this is the number of rows still required to reach it
+	return d.QueryStatus.RowsRemaining(ctx) > 0
+}
+
+// streamLeafListItem is streamListItem for the list function of a child table
+func streamLeafListItem(ctx context.Context, d *plugin.QueryData, item interface{}) bool {
+	d.StreamLeafListItem(ctx, item)
+	return d.QueryStatus.RowsRemaining(ctx) > 0
+}
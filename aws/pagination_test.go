@@ -0,0 +1,43 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestListPageSize(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		limit    *int64
+		expected int64
+	}{
+		{
+			name:     "no limit",
+			expected: 1000,
+		},
+		{
+			name:     "limit below the minimum page size",
+			limit:    aws.Int64(2),
+			expected: 5,
+		},
+		{
+			name:     "limit",
+			limit:    aws.Int64(20),
+			expected: 20,
+		},
+		{
+			name:     "limit above the maximum page size",
+			limit:    aws.Int64(5000),
+			expected: 1000,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := &plugin.QueryData{QueryContext: &plugin.QueryContext{Limit: testCase.limit}}
+			if actual := *listPageSize(d, 5, 1000); actual != testCase.expected {
+				t.Errorf("expected page size %d, got %d", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// rateLimitAllServices is the rate_limits key which applies to the services without their own entry
+const rateLimitAllServices = "*"
+
+// Default requests per second for services with tight account-wide API limits, which
+// are shared with everything else running in the account, e.g. deploy tooling.
+// Other services are not limited unless rate_limits sets a limit for them or for "*".
+var defaultRateLimits = map[string]float64{
+	"ce":            5,
+	"cloudtrail":    5,
+	"iam":           10,
+	"organizations": 5,
+}
+
+// parseRateLimits returns the requests per second for each service, from the defaults and
+// the "<endpoint ID>=<requests per second>" entries of the rate_limits connection config.
+// A limit of 0 turns off the limit of a service.
+func parseRateLimits(entries []string) (map[string]float64, error) {
+	limits := map[string]float64{}
+	for service, limit := range defaultRateLimits {
+		limits[service] = limit
+	}
+
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("Invalid rate_limits entry %q in connection config, expected: <service>=<requests per second>", entry)
+		}
+		limit, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || limit < 0 || math.IsInf(limit, 0) {
+			return nil, fmt.Errorf("Invalid rate_limits entry %q in connection config, the requests per second must be a number >= 0", entry)
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits, nil
+}
+
+// rateLimiters holds a token bucket per service and region, shared by the sessions of all
+// regions of a connection (or of an organization account)
+type rateLimiters struct {
+	limits map[string]float64
+
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// getRateLimiters returns the rate limiters of the connection, or of the organization account being queried
+func getRateLimiters(d *plugin.QueryData) (*rateLimiters, error) {
+	cacheKey := scopedCacheKey(d, "RateLimiters")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*rateLimiters), nil
+	}
+
+	limits, err := parseRateLimits(GetConfig(d.Connection).RateLimits)
+	if err != nil {
+		return nil, err
+	}
+	limiters := &rateLimiters{
+		limits:  limits,
+		buckets: map[string]*tokenBucket{},
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, limiters)
+	return limiters, nil
+}
+
+// bucket returns the token bucket of a service in a region, or nil if the service is not limited
+func (l *rateLimiters) bucket(service string, region string) *tokenBucket {
+	limit, ok := l.limits[service]
+	if !ok {
+		limit = l.limits[rateLimitAllServices]
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := fmt.Sprintf("%s-%s", service, region)
+	if _, ok := l.buckets[key]; !ok {
+		l.buckets[key] = newTokenBucket(limit)
+	}
+	return l.buckets[key]
+}
+
+// rateLimitHandler returns a request handler which waits for the rate limit of the request's
+// service before the request is signed, so retries are rate limited too. Global services are
+// limited once for the account, as their signing region is the same for every session.
+func rateLimitHandler(limiters *rateLimiters) request.NamedHandler {
+	return request.NamedHandler{
+		Name: "steampipe.RateLimitHandler",
+		Fn: func(r *request.Request) {
+			bucket := limiters.bucket(r.ClientInfo.ServiceName, r.ClientInfo.SigningRegion)
+			if bucket == nil {
+				return
+			}
+			if err := bucket.Wait(r.Context()); err != nil {
+				r.Error = awserr.New(request.CanceledErrorCode, "request context canceled while waiting for the rate limit", err)
+			}
+		},
+	}
+}
+
+// tokenBucket allows a steady rate of requests per second, with bursts of up to one second of requests
+type tokenBucket struct {
+	mutex    sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	updated  time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	capacity := math.Max(rate, 1)
+	return &tokenBucket{
+		rate:     rate,
+		capacity: capacity,
+		tokens:   capacity,
+		updated:  time.Now(),
+	}
+}
+
+// Wait takes a token from the bucket, waiting until one is available or the context is done
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	b.mutex.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+	b.updated = now
+	// take the token now, callers queue up behind each other by the tokens they owe
+	b.tokens--
+	wait := time.Duration(0)
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mutex.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// give the token back for the callers queued up behind
+		b.mutex.Lock()
+		b.tokens++
+		b.mutex.Unlock()
+		return ctx.Err()
+	}
+}
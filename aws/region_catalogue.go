@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// Partition details which are not exposed by the SDK endpoints metadata
+var awsPartitionDetails = map[string]struct {
+	Description string
+	// region targeted by the global services (IAM, STS, Route 53, etc.) of the partition
+	GlobalRegion string
+}{
+	"aws":        {"AWS Standard", "us-east-1"},
+	"aws-cn":     {"AWS China", "cn-northwest-1"},
+	"aws-us-gov": {"AWS GovCloud (US)", "us-gov-west-1"},
+	"aws-iso":    {"AWS ISO (US)", "us-iso-east-1"},
+	"aws-iso-b":  {"AWS ISOB (US)", "us-isob-east-1"},
+}
+
+// getPartitionForRegion returns the partition of a region. Regions which are not yet known
+// to the SDK are matched against the region name pattern of each partition.
+func getPartitionForRegion(region string) (endpoints.Partition, bool) {
+	// check the known regions of all partitions first, as the pattern of the aws
+	// partition also matches the region names of other partitions, e.g. us-iso-east-1
+	for _, partition := range endpoints.DefaultPartitions() {
+		if _, ok := partition.Regions()[region]; ok {
+			return partition, true
+		}
+	}
+	return endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+}
+
+// getPartitionGlobalRegion returns the region targeted by the global services of a partition
+func getPartitionGlobalRegion(partition endpoints.Partition) string {
+	if details, ok := awsPartitionDetails[partition.ID()]; ok {
+		return details.GlobalRegion
+	}
+	return "us-east-1"
+}
+
+// getPartitionRegions returns the sorted names of the regions of a partition known to the SDK
+func getPartitionRegions(partition endpoints.Partition) []string {
+	var regions []string
+	for name := range partition.Regions() {
+		regions = append(regions, name)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// getKnownRegions returns the names of the regions of all partitions known to the SDK
+func getKnownRegions() []string {
+	var regions []string
+	for _, partition := range endpoints.DefaultPartitions() {
+		regions = append(regions, getPartitionRegions(partition)...)
+	}
+	return regions
+}
+
+// isRegionPattern returns true if a regions entry of the connection config is a wildcard
+func isRegionPattern(region string) bool {
+	return strings.ContainsAny(region, "*?[")
+}
+
+// isServiceAvailableInRegion returns false if the SDK endpoints metadata lists the regions a service
+// is offered in, and the region is not one of them. The SDK endpoint ID of the service is used,
+// e.g. "ec2", "auditmanager" or "wellarchitected". Regions unknown to the SDK are assumed to offer
+// the service, as the metadata can't tell either way.
+func isServiceAvailableInRegion(serviceID string, region string) bool {
+	partition, ok := getPartitionForRegion(region)
+	if !ok {
+		return true
+	}
+	if _, ok := partition.Regions()[region]; !ok {
+		return true
+	}
+	service, ok := partition.Services()[serviceID]
+	if !ok {
+		return true
+	}
+	serviceRegions := service.Regions()
+	// global services, e.g. IAM, are only modelled with a partition endpoint
+	if len(serviceRegions) == 0 {
+		return true
+	}
+	_, ok = serviceRegions[region]
+	return ok
+}
+
+// struct to store the regions of the partition a connection is in
+type awsRegionCatalogue struct {
+	Partition string
+	// all regions of the partition, as returned by DescribeRegions or known to the SDK
+	Regions []string
+	// opt-in status of each region, only known if DescribeRegions could be called
+	OptInStatus map[string]string
+}
+
+// NotOptedRegions returns the opt-in regions which are not enabled for the account
+func (c *awsRegionCatalogue) NotOptedRegions() []string {
+	var regions []string
+	for _, region := range c.Regions {
+		if c.OptInStatus[region] == "not-opted-in" {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// getRegionCatalogue returns the regions of the partition of the connection's default region.
+// The regions known to the SDK are used as a fallback if DescribeRegions can't be called.
+func getRegionCatalogue(ctx context.Context, d *plugin.QueryData) *awsRegionCatalogue {
+	cacheKey := connectionCacheKey(d, "RegionCatalogue")
+
+	// if found in cache, return the result
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*awsRegionCatalogue)
+	}
+	cachedCatalogue := &awsRegionCatalogue{}
+	if diskCacheGet(ctx, d, cacheKey, cachedCatalogue) {
+		d.ConnectionManager.Cache.Set(cacheKey, cachedCatalogue)
+		return cachedCatalogue
+	}
+
+	defaultRegion := GetDefaultAwsRegion(d)
+	partition, _ := getPartitionForRegion(defaultRegion)
+
+	catalogue := &awsRegionCatalogue{
+		Partition:   partition.ID(),
+		Regions:     getPartitionRegions(partition),
+		OptInStatus: map[string]string{},
+	}
+
+	// Create Session
+	svc, err := Ec2Service(ctx, d, defaultRegion)
+	if err != nil {
+		// handle in case user doesn't have access to ec2 service
+		d.ConnectionManager.Cache.Set(cacheKey, catalogue)
+		return catalogue
+	}
+
+	params := &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(true),
+	}
+
+	// execute list call
+	resp, err := svc.DescribeRegions(params)
+	if err != nil {
+		// handle in case user doesn't have access to ec2 service
+		plugin.Logger(ctx).Warn("getRegionCatalogue", "DescribeRegions_error", err)
+		d.ConnectionManager.Cache.Set(cacheKey, catalogue)
+		return catalogue
+	}
+
+	// DescribeRegions also knows the regions launched after this SDK version
+	var regions []string
+	for _, region := range resp.Regions {
+		regions = append(regions, *region.RegionName)
+		catalogue.OptInStatus[*region.RegionName] = aws.StringValue(region.OptInStatus)
+	}
+	sort.Strings(regions)
+	catalogue.Regions = regions
+
+	// save to extension cache, and to the disk cache, as the fallbacks above are only kept in memory
+	d.ConnectionManager.Cache.Set(cacheKey, catalogue)
+	diskCacheSet(ctx, d, cacheKey, catalogue, regionCatalogueDiskCacheTTL)
+	return catalogue
+}
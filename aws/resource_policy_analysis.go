@@ -0,0 +1,249 @@
+package aws
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// resourcePolicyAccess is who a resource-based policy grants access to, outside of the account
+// of the resource
+type resourcePolicyAccess struct {
+	// an Allow statement grants access to anyone, and no condition restricts it
+	IsPublic bool
+	// the accounts, other than the account of the resource, which a statement grants access to
+	ExternalAccountIds []string
+	// the organizations whose principals are granted access with aws:PrincipalOrgID
+	AllowedOrganizationIds []string
+	// all the statements granting access to anyone are restricted by conditions
+	ConditionsRestrictAccess bool
+}
+
+// resourcePolicyRestrictingKeys are the condition keys which restrict who can use a statement
+// granting access to anyone, to an account, an organization, a network or a source resource.
+// aws:username and aws:userid are not account-scoped, a user of that name, or a role matching the
+// wildcard of a userid, may be in any account.
+var resourcePolicyRestrictingKeys = map[string]bool{
+	"aws:principalaccount":  true,
+	"aws:principalarn":      true,
+	"aws:principalorgid":    true,
+	"aws:principalorgpaths": true,
+	"aws:sourceaccount":     true,
+	"aws:sourcearn":         true,
+	"aws:sourceip":          true,
+	"aws:sourceorgid":       true,
+	"aws:sourceorgpaths":    true,
+	"aws:sourceowner":       true,
+	"aws:sourcevpc":         true,
+	"aws:sourcevpce":        true,
+	"kms:calleraccount":     true,
+}
+
+// resourcePolicyAccountKeys are the condition keys whose values are account ids
+var resourcePolicyAccountKeys = map[string]bool{
+	"aws:principalaccount": true,
+	"aws:sourceaccount":    true,
+	"aws:sourceowner":      true,
+	"kms:calleraccount":    true,
+}
+
+// resourcePolicyArnKeys are the condition keys whose values are ARNs
+var resourcePolicyArnKeys = map[string]bool{
+	"aws:principalarn": true,
+	"aws:sourcearn":    true,
+}
+
+var awsAccountIdRegex = regexp.MustCompile(`^[0-9]{12}$`)
+
+// resourcePolicyRestriction is what the conditions of a statement restrict access to
+type resourcePolicyRestriction struct {
+	restricts       bool
+	accountIds      []string
+	organizationIds []string
+}
+
+// analyzeResourcePolicy returns who a resource-based policy in canonical form grants access to.
+// An Allow statement for any principal, with a wildcard AWS principal or a NotPrincipal, makes
+// the policy public unless it has a condition restricting it, e.g. StringEquals aws:SourceVpce.
+// A Deny statement for any principal and action with a negated restricting condition, e.g.
+// StringNotEquals aws:PrincipalOrgID, restricts all the Allow statements. Service principals
+// are not public, they act on behalf of the account of the resource or of a source resource.
+func analyzeResourcePolicy(policy Policy, accountId string) resourcePolicyAccess {
+	var accountIds, organizationIds []string
+	addAccount := func(id string) {
+		if id != "" && id != accountId && awsAccountIdRegex.MatchString(id) {
+			accountIds = append(accountIds, id)
+		}
+	}
+
+	// denies restricting all the Allow statements
+	denyRestriction := resourcePolicyRestriction{}
+	for _, statement := range policy.Statements {
+		if statement.Effect == "Deny" && resourcePolicyStatementIsForAnyone(statement) && resourcePolicyStatementIsForAnyAction(statement) {
+			restriction := resourcePolicyConditionRestriction(statement.Condition, true)
+			if restriction.restricts {
+				denyRestriction.restricts = true
+				denyRestriction.accountIds = append(denyRestriction.accountIds, restriction.accountIds...)
+				denyRestriction.organizationIds = append(denyRestriction.organizationIds, restriction.organizationIds...)
+			}
+		}
+	}
+
+	access := resourcePolicyAccess{}
+	anyoneStatements, restrictedStatements := 0, 0
+	for _, statement := range policy.Statements {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		for _, principal := range resourcePolicyAwsPrincipals(statement.Principal) {
+			addAccount(awsPrincipalAccountId(principal))
+		}
+		if !resourcePolicyStatementIsForAnyone(statement) {
+			continue
+		}
+
+		anyoneStatements++
+		restriction := resourcePolicyConditionRestriction(statement.Condition, false)
+		if !restriction.restricts && !denyRestriction.restricts {
+			access.IsPublic = true
+			continue
+		}
+		restrictedStatements++
+		for _, id := range append(restriction.accountIds, denyRestriction.accountIds...) {
+			addAccount(id)
+		}
+		organizationIds = append(organizationIds, restriction.organizationIds...)
+		organizationIds = append(organizationIds, denyRestriction.organizationIds...)
+	}
+
+	access.ConditionsRestrictAccess = anyoneStatements > 0 && restrictedStatements == anyoneStatements
+	access.ExternalAccountIds = sortedUniqueStrings(accountIds)
+	access.AllowedOrganizationIds = sortedUniqueStrings(organizationIds)
+	return access
+}
+
+// resourcePolicyStatementIsForAnyone returns true if a statement applies to any AWS principal
+func resourcePolicyStatementIsForAnyone(statement Statement) bool {
+	if statement.NotPrincipal != nil {
+		return true
+	}
+	for _, principal := range resourcePolicyAwsPrincipals(statement.Principal) {
+		if principal == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// resourcePolicyStatementIsForAnyAction returns true if a statement applies to all the actions of
+// a service, e.g. * or s3:*
+func resourcePolicyStatementIsForAnyAction(statement Statement) bool {
+	if statement.NotAction != nil {
+		return false
+	}
+	for _, action := range statement.Action {
+		if action == "*" || strings.HasSuffix(action, ":*") {
+			return true
+		}
+	}
+	return false
+}
+
+// resourcePolicyAwsPrincipals returns the AWS principals of the Principal of a statement
+func resourcePolicyAwsPrincipals(principal Principal) []string {
+	principals, _ := principal["AWS"].([]string)
+	return principals
+}
+
+// resourcePolicyConditionRestriction returns what the conditions of a statement restrict access to.
+// For an Allow statement a condition restricts access if it requires a restricting key to have
+// given values, for a Deny statement if it denies the requests where the key has other values.
+// Conditions with IfExists or ForAllValues don't restrict an Allow statement, they match if the
+// key is missing, nor do wildcard values or 0.0.0.0/0.
+func resourcePolicyConditionRestriction(conditions map[string]interface{}, deny bool) resourcePolicyRestriction {
+	restriction := resourcePolicyRestriction{}
+	for operator, condition := range conditions {
+		name := strings.ToLower(operator)
+		ifExists := strings.HasSuffix(name, "ifexists")
+		name = strings.TrimSuffix(name, "ifexists")
+		if strings.HasPrefix(name, "forallvalues:") {
+			if !deny {
+				continue
+			}
+			name = strings.TrimPrefix(name, "forallvalues:")
+		}
+		name = strings.TrimPrefix(name, "foranyvalue:")
+
+		definition, ok := iamConditionOperators[name]
+		if !ok || definition.negated != deny || (ifExists && !deny) {
+			continue
+		}
+
+		keys, _ := condition.(map[string]interface{})
+		for key, values := range keys {
+			conditionValues, _ := values.([]string)
+			if !resourcePolicyRestrictingKeys[key] || !resourcePolicyValuesRestrict(key, conditionValues) {
+				continue
+			}
+			restriction.restricts = true
+			for _, value := range conditionValues {
+				switch {
+				case key == "aws:principalorgid":
+					restriction.organizationIds = append(restriction.organizationIds, value)
+				case resourcePolicyAccountKeys[key]:
+					restriction.accountIds = append(restriction.accountIds, value)
+				case resourcePolicyArnKeys[key]:
+					restriction.accountIds = append(restriction.accountIds, awsPrincipalAccountId(value))
+				}
+			}
+		}
+	}
+	return restriction
+}
+
+// resourcePolicyValuesRestrict returns false if any of the values of a condition matches anything,
+// or any account, e.g. arn:aws:iam::*:role/admin
+func resourcePolicyValuesRestrict(key string, values []string) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		if strings.Trim(value, "*") == "" || value == "0.0.0.0/0" || value == "::/0" {
+			return false
+		}
+		if resourcePolicyAccountKeys[key] && strings.ContainsAny(value, "*?") {
+			return false
+		}
+		if resourcePolicyArnKeys[key] {
+			if segments := strings.SplitN(value, ":", 6); len(segments) == 6 && strings.ContainsAny(segments[4], "*?") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// awsPrincipalAccountId returns the account of an account id or ARN, or "" if it has none, e.g.
+// for arn:aws:s3:::my-bucket
+func awsPrincipalAccountId(principal string) string {
+	if awsAccountIdRegex.MatchString(principal) {
+		return principal
+	}
+	segments := strings.SplitN(principal, ":", 6)
+	if len(segments) == 6 && segments[0] == "arn" && awsAccountIdRegex.MatchString(segments[4]) {
+		return segments[4]
+	}
+	return ""
+}
+
+// sortedUniqueStrings returns the values without duplicates, sorted, and an empty slice rather
+// than nil
+func sortedUniqueStrings(values []string) []string {
+	unique := []string{}
+	if len(values) > 0 {
+		unique = uniqueStrings(values)
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,203 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeResourcePolicy(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		policy   string
+		expected resourcePolicyAccess
+	}{
+		{
+			name:   "own account",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111111111111:root"}, "Action": "sqs:*", "Resource": "*"}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "external accounts",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": ["222222222222", "arn:aws:iam::333333333333:role/reader"]}, "Action": "s3:GetObject", "Resource": "*"}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:     []string{"222222222222", "333333333333"},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "wildcard principal",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*"}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "not principal",
+			policy: `{"Statement": {"Effect": "Allow", "NotPrincipal": {"AWS": "arn:aws:iam::111111111111:root"}, "Action": "s3:GetObject", "Resource": "*"}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "service principal",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"Service": "sns.amazonaws.com"}, "Action": "sqs:SendMessage", "Resource": "*"}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "organization",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"StringEquals": {"aws:PrincipalOrgID": "o-abc123"}}}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:       []string{},
+				AllowedOrganizationIds:   []string{"o-abc123"},
+				ConditionsRestrictAccess: true,
+			},
+		},
+		{
+			name:   "source account and arn",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "sns:Publish", "Resource": "*", "Condition": {"StringEquals": {"aws:SourceAccount": ["111111111111", "444444444444"]}, "ArnLike": {"aws:SourceArn": "arn:aws:s3:*:555555555555:*"}}}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:       []string{"444444444444", "555555555555"},
+				AllowedOrganizationIds:   []string{},
+				ConditionsRestrictAccess: true,
+			},
+		},
+		{
+			name:   "vpc endpoint",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:*", "Resource": "*", "Condition": {"StringEquals": {"aws:SourceVpce": "vpce-1a2b3c4d"}}}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:       []string{},
+				AllowedOrganizationIds:   []string{},
+				ConditionsRestrictAccess: true,
+			},
+		},
+		{
+			name:   "if exists does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:*", "Resource": "*", "Condition": {"StringEqualsIfExists": {"aws:SourceVpce": "vpce-1a2b3c4d"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "wildcard value does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:*", "Resource": "*", "Condition": {"StringLike": {"aws:PrincipalOrgID": "*"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "user name does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"StringEquals": {"aws:username": "alice"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "user id does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"StringLike": {"aws:userid": "AROAEXAMPLEID:*"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "arn of any account does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"ArnLike": {"aws:PrincipalArn": "arn:aws:iam::*:role/reader"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "wildcard account does not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"StringLike": {"aws:SourceAccount": "22222222222?"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name:   "arn of an account with a wildcard resource",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"ArnLike": {"aws:PrincipalArn": "arn:aws:iam::222222222222:role/*"}}}}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:       []string{"222222222222"},
+				AllowedOrganizationIds:   []string{},
+				ConditionsRestrictAccess: true,
+			},
+		},
+		{
+			name:   "other condition keys do not restrict",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"Bool": {"aws:SecureTransport": "true"}}}}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name: "deny outside the organization",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*"},
+				{"Effect": "Deny", "Principal": "*", "Action": "s3:*", "Resource": "*", "Condition": {"StringNotEquals": {"aws:PrincipalOrgID": "o-abc123"}}}
+			]}`,
+			expected: resourcePolicyAccess{
+				ExternalAccountIds:       []string{},
+				AllowedOrganizationIds:   []string{"o-abc123"},
+				ConditionsRestrictAccess: true,
+			},
+		},
+		{
+			name: "deny of some actions does not restrict",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*"},
+				{"Effect": "Deny", "Principal": "*", "Action": "s3:PutObject", "Resource": "*", "Condition": {"StringNotEquals": {"aws:PrincipalOrgID": "o-abc123"}}}
+			]}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+		{
+			name: "one unrestricted statement",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": "203.0.113.0/24"}}},
+				{"Effect": "Allow", "Principal": "*", "Action": "s3:ListBucket", "Resource": "*", "Condition": {"IpAddress": {"aws:SourceIp": "0.0.0.0/0"}}}
+			]}`,
+			expected: resourcePolicyAccess{
+				IsPublic:               true,
+				ExternalAccountIds:     []string{},
+				AllowedOrganizationIds: []string{},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := canonicalPolicy(testCase.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := analyzeResourcePolicy(policy.(Policy), "111111111111")
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// number of buckets whose region is resolved in parallel, when filtering buckets by region
+const s3BucketRegionConcurrency = 10
+
+// locks of the bucket lists being loaded, by cache key
+var s3BucketListLocks sync.Map
+
+// getS3Buckets returns the buckets of the account, listed once per connection, e.g. for the
+// tables which list the buckets of each region of the matrix
+func getS3Buckets(ctx context.Context, d *plugin.QueryData) ([]*s3.Bucket, error) {
+	cacheKey := scopedCacheKey(d, "ListBuckets")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]*s3.Bucket), nil
+	}
+
+	lock, _ := s3BucketListLocks.LoadOrStore(cacheKey, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// listed while waiting for the lock
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.([]*s3.Bucket), nil
+	}
+
+	// Create Session
+	svc, err := S3Service(ctx, d, GetDefaultAwsRegion(d))
+	if err != nil {
+		return nil, err
+	}
+	bucketsResult, err := svc.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, bucketsResult.Buckets)
+	return bucketsResult.Buckets, nil
+}
+
+// getS3BucketRegion returns the region of a bucket. S3 returns it in the x-amz-bucket-region
+// header of HeadBucket, also when the bucket is in another region than the client, or the call is
+// denied, so this works for buckets in opt-in regions too. GetBucketLocation is called instead if
+// the header is missing, e.g. for S3 compatible endpoints. The region is cached per bucket.
+func getS3BucketRegion(ctx context.Context, d *plugin.QueryData, bucketName string) (string, error) {
+	cacheKey := connectionCacheKey(d, fmt.Sprintf("S3BucketRegion-%s", bucketName))
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(string), nil
+	}
+
+	// Create Session
+	svc, err := S3Service(ctx, d, GetDefaultAwsRegion(d))
+	if err != nil {
+		return "", err
+	}
+
+	region, err := headS3BucketRegion(ctx, svc, bucketName)
+	if err != nil {
+		return "", err
+	}
+	if region == "" {
+		plugin.Logger(ctx).Debug("getS3BucketRegion", "bucket", bucketName, "no x-amz-bucket-region header, calling GetBucketLocation")
+		region, err = getS3BucketLocationRegion(svc, bucketName)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	d.ConnectionManager.Cache.Set(cacheKey, region)
+	return region, nil
+}
+
+// headS3BucketRegion returns the x-amz-bucket-region header of HeadBucket, or "" if it is missing
+func headS3BucketRegion(ctx context.Context, svc s3iface.S3API, bucketName string) (string, error) {
+	req, _ := svc.HeadBucketRequest(&s3.HeadBucketInput{
+		Bucket: aws.String(bucketName),
+	})
+	req.SetContext(ctx)
+	err := req.Send()
+	if req.HTTPResponse != nil {
+		if region := req.HTTPResponse.Header.Get("X-Amz-Bucket-Region"); region != "" {
+			return region, nil
+		}
+	}
+	// the header is missing for buckets which don't exist, and HEAD responses have no error code
+	if err != nil {
+		if a, ok := err.(awserr.RequestFailure); ok && a.StatusCode() == 404 {
+			return "", awserr.NewRequestFailure(awserr.New(s3.ErrCodeNoSuchBucket, fmt.Sprintf("The specified bucket %s does not exist", bucketName), err), 404, a.RequestID())
+		}
+	}
+	return "", nil
+}
+
+// getS3BucketLocationRegion returns the region of the location constraint of a bucket
+func getS3BucketLocationRegion(svc s3iface.S3API, bucketName string) (string, error) {
+	location, err := svc.GetBucketLocation(&s3.GetBucketLocationInput{
+		Bucket: aws.String(bucketName),
+	})
+	if err != nil {
+		return "", err
+	}
+	// Buckets in Region us-east-1 have a LocationConstraint of null.
+	// Buckets created with the legacy EU constraint are in eu-west-1.
+	region := s3.NormalizeBucketLocation(aws.StringValue(location.LocationConstraint))
+	return region, nil
+}
+
+// S3BucketService returns the service connection for AWS S3 service in the region of a bucket,
+// so calls on the bucket or its objects are not redirected
+func S3BucketService(ctx context.Context, d *plugin.QueryData, bucketName string) (s3iface.S3API, error) {
+	region, err := getS3BucketRegion(ctx, d, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	return S3Service(ctx, d, region)
+}
+
+// filterS3BucketsByRegion returns the buckets in one of the given regions. The regions of the
+// buckets are resolved in parallel. Buckets deleted since they were listed are skipped.
+func filterS3BucketsByRegion(ctx context.Context, d *plugin.QueryData, buckets []*s3.Bucket, regions []string) ([]*s3.Bucket, error) {
+	inRegion := make([]bool, len(buckets))
+	errs := make([]error, len(buckets))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, s3BucketRegionConcurrency)
+	for i, bucket := range buckets {
+		wg.Add(1)
+		go func(i int, bucketName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			region, err := getS3BucketRegion(ctx, d, bucketName)
+			if err != nil {
+				if a, ok := err.(awserr.Error); ok && a.Code() == s3.ErrCodeNoSuchBucket {
+					return
+				}
+				errs[i] = err
+				return
+			}
+			inRegion[i] = helpers.StringSliceContains(regions, region)
+		}(i, *bucket.Name)
+	}
+	wg.Wait()
+
+	var filtered []*s3.Bucket
+	for i, bucket := range buckets {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if inRegion[i] {
+			filtered = append(filtered, bucket)
+		}
+	}
+	return filtered, nil
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
-	"path"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -14,96 +14,171 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/accessanalyzer"
+	"github.com/aws/aws-sdk-go/service/accessanalyzer/accessanalyzeriface"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
 	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/apigatewayv2"
+	"github.com/aws/aws-sdk-go/service/apigatewayv2/apigatewayv2iface"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
+	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/auditmanager"
+	"github.com/aws/aws-sdk-go/service/auditmanager/auditmanageriface"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/aws/aws-sdk-go/service/backup/backupiface"
 	"github.com/aws/aws-sdk-go/service/cloudcontrolapi"
+	"github.com/aws/aws-sdk-go/service/cloudcontrolapi/cloudcontrolapiiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
 	"github.com/aws/aws-sdk-go/service/codecommit"
+	"github.com/aws/aws-sdk-go/service/codecommit/codecommitiface"
 	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/aws/aws-sdk-go/service/codepipeline/codepipelineiface"
 	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/configservice/configserviceiface"
 	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
 	"github.com/aws/aws-sdk-go/service/databasemigrationservice"
+	"github.com/aws/aws-sdk-go/service/databasemigrationservice/databasemigrationserviceiface"
 	"github.com/aws/aws-sdk-go/service/dax"
+	"github.com/aws/aws-sdk-go/service/dax/daxiface"
 	"github.com/aws/aws-sdk-go/service/directoryservice"
+	"github.com/aws/aws-sdk-go/service/directoryservice/directoryserviceiface"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecrpublic"
+	"github.com/aws/aws-sdk-go/service/ecrpublic/ecrpubliciface"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
 	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
 	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go/service/elasticbeanstalk/elasticbeanstalkiface"
 	"github.com/aws/aws-sdk-go/service/elasticsearchservice"
+	"github.com/aws/aws-sdk-go/service/elasticsearchservice/elasticsearchserviceiface"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/emr"
+	"github.com/aws/aws-sdk-go/service/emr/emriface"
 	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/eventbridge/eventbridgeiface"
 	"github.com/aws/aws-sdk-go/service/firehose"
+	"github.com/aws/aws-sdk-go/service/firehose/firehoseiface"
 	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/fsx/fsxiface"
 	"github.com/aws/aws-sdk-go/service/glacier"
+	"github.com/aws/aws-sdk-go/service/glacier/glacieriface"
 	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
 	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/aws/aws-sdk-go/service/guardduty/guarddutyiface"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/identitystore"
+	"github.com/aws/aws-sdk-go/service/identitystore/identitystoreiface"
 	"github.com/aws/aws-sdk-go/service/inspector"
+	"github.com/aws/aws-sdk-go/service/inspector/inspectoriface"
 	"github.com/aws/aws-sdk-go/service/kinesis"
+	"github.com/aws/aws-sdk-go/service/kinesis/kinesisiface"
 	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2"
+	"github.com/aws/aws-sdk-go/service/kinesisanalyticsv2/kinesisanalyticsv2iface"
 	"github.com/aws/aws-sdk-go/service/kinesisvideo"
+	"github.com/aws/aws-sdk-go/service/kinesisvideo/kinesisvideoiface"
 	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/macie2"
+	"github.com/aws/aws-sdk-go/service/macie2/macie2iface"
 	"github.com/aws/aws-sdk-go/service/mediastore"
+	"github.com/aws/aws-sdk-go/service/mediastore/mediastoreiface"
 	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/redshift/redshiftiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/route53domains"
+	"github.com/aws/aws-sdk-go/service/route53domains/route53domainsiface"
 	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3control"
+	"github.com/aws/aws-sdk-go/service/s3control/s3controliface"
 	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/securityhub"
+	"github.com/aws/aws-sdk-go/service/securityhub/securityhubiface"
 	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/ssoadmin"
+	"github.com/aws/aws-sdk-go/service/ssoadmin/ssoadminiface"
 	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/aws/aws-sdk-go/service/waf"
+	"github.com/aws/aws-sdk-go/service/waf/wafiface"
 	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 	"github.com/aws/aws-sdk-go/service/wellarchitected"
+	"github.com/aws/aws-sdk-go/service/wellarchitected/wellarchitectediface"
 	"github.com/aws/aws-sdk-go/service/workspaces"
+	"github.com/aws/aws-sdk-go/service/workspaces/workspacesiface"
 
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 )
 
 // AccessAnalyzerService returns the service connection for AWS IAM Access Analyzer service
-func AccessAnalyzerService(ctx context.Context, d *plugin.QueryData) (*accessanalyzer.AccessAnalyzer, error) {
+func AccessAnalyzerService(ctx context.Context, d *plugin.QueryData) (accessanalyzeriface.AccessAnalyzerAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed AccessAnalyzerService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("accessanalyzer-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*accessanalyzer.AccessAnalyzer), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("accessanalyzer-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "accessanalyzer", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(accessanalyzeriface.AccessAnalyzerAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -113,19 +188,19 @@ func AccessAnalyzerService(ctx context.Context, d *plugin.QueryData) (*accessana
 	svc := accessanalyzer.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(accessanalyzeriface.AccessAnalyzerAPI), nil
 }
 
 // ACMService returns the service connection for AWS ACM service
-func ACMService(ctx context.Context, d *plugin.QueryData) (*acm.ACM, error) {
+func ACMService(ctx context.Context, d *plugin.QueryData) (acmiface.ACMAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ACMService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("acm-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*acm.ACM), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("acm-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "acm", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(acmiface.ACMAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -135,19 +210,19 @@ func ACMService(ctx context.Context, d *plugin.QueryData) (*acm.ACM, error) {
 	svc := acm.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(acmiface.ACMAPI), nil
 }
 
 // APIGatewayService returns the service connection for AWS API Gateway service
-func APIGatewayService(ctx context.Context, d *plugin.QueryData) (*apigateway.APIGateway, error) {
+func APIGatewayService(ctx context.Context, d *plugin.QueryData) (apigatewayiface.APIGatewayAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed APIGateway")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("apigateway-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*apigateway.APIGateway), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("apigateway-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "apigateway", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(apigatewayiface.APIGatewayAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -157,19 +232,19 @@ func APIGatewayService(ctx context.Context, d *plugin.QueryData) (*apigateway.AP
 	svc := apigateway.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(apigatewayiface.APIGatewayAPI), nil
 }
 
 // APIGatewayV2Service returns the service connection for AWS API Gateway V2 service
-func APIGatewayV2Service(ctx context.Context, d *plugin.QueryData) (*apigatewayv2.ApiGatewayV2, error) {
+func APIGatewayV2Service(ctx context.Context, d *plugin.QueryData) (apigatewayv2iface.ApiGatewayV2API, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed APIGatewayV2Service")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("apigatewayv2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*apigatewayv2.ApiGatewayV2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("apigatewayv2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "apigatewayv2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(apigatewayv2iface.ApiGatewayV2API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -179,19 +254,19 @@ func APIGatewayV2Service(ctx context.Context, d *plugin.QueryData) (*apigatewayv
 	svc := apigatewayv2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(apigatewayv2iface.ApiGatewayV2API), nil
 }
 
 // ApplicationAutoScalingService returns the service connection for AWS Application Auto Scaling service
-func ApplicationAutoScalingService(ctx context.Context, d *plugin.QueryData) (*applicationautoscaling.ApplicationAutoScaling, error) {
+func ApplicationAutoScalingService(ctx context.Context, d *plugin.QueryData) (applicationautoscalingiface.ApplicationAutoScalingAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ApplicationAutoScalingService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("applicationautoscaling-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*applicationautoscaling.ApplicationAutoScaling), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("applicationautoscaling-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "applicationautoscaling", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(applicationautoscalingiface.ApplicationAutoScalingAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -201,18 +276,18 @@ func ApplicationAutoScalingService(ctx context.Context, d *plugin.QueryData) (*a
 	svc := applicationautoscaling.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(applicationautoscalingiface.ApplicationAutoScalingAPI), nil
 }
 
 // AuditManagerService returns the service connection for AWS Audit Manager service
-func AuditManagerService(ctx context.Context, d *plugin.QueryData, region string) (*auditmanager.AuditManager, error) {
+func AuditManagerService(ctx context.Context, d *plugin.QueryData, region string) (auditmanageriface.AuditManagerAPI, error) {
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed AuditManagerService")
+		return nil, fmt.Errorf("region must be passed AuditManagerService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("auditmanager-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*auditmanager.AuditManager), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("auditmanager-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "auditmanager", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(auditmanageriface.AuditManagerAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -221,19 +296,19 @@ func AuditManagerService(ctx context.Context, d *plugin.QueryData, region string
 	}
 	svc := auditmanager.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(auditmanageriface.AuditManagerAPI), nil
 }
 
 // AutoScalingService returns the service connection for AWS AutoScaling service
-func AutoScalingService(ctx context.Context, d *plugin.QueryData) (*autoscaling.AutoScaling, error) {
+func AutoScalingService(ctx context.Context, d *plugin.QueryData) (autoscalingiface.AutoScalingAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed AutoScalingService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("autoscaling-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*autoscaling.AutoScaling), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("autoscaling-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "autoscaling", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(autoscalingiface.AutoScalingAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -243,19 +318,19 @@ func AutoScalingService(ctx context.Context, d *plugin.QueryData) (*autoscaling.
 	svc := autoscaling.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(autoscalingiface.AutoScalingAPI), nil
 }
 
 // BackupService returns the service connection for AWS Backup service
-func BackupService(ctx context.Context, d *plugin.QueryData) (*backup.Backup, error) {
+func BackupService(ctx context.Context, d *plugin.QueryData) (backupiface.BackupAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed BackupService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("backup-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*backup.Backup), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("backup-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "backup", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(backupiface.BackupAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -264,11 +339,11 @@ func BackupService(ctx context.Context, d *plugin.QueryData) (*backup.Backup, er
 	}
 	svc := backup.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(backupiface.BackupAPI), nil
 }
 
 // CloudControlService returns the service connection for AWS Cloud Control API service
-func CloudControlService(ctx context.Context, d *plugin.QueryData) (*cloudcontrolapi.CloudControlApi, error) {
+func CloudControlService(ctx context.Context, d *plugin.QueryData) (cloudcontrolapiiface.CloudControlApiAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	if region == "" {
@@ -276,9 +351,9 @@ func CloudControlService(ctx context.Context, d *plugin.QueryData) (*cloudcontro
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("cloudcontrolapi-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudcontrolapi.CloudControlApi), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("cloudcontrolapi-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "cloudcontrolapi", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudcontrolapiiface.CloudControlApiAPI), nil
 	}
 
 	// CloudControl returns GeneralServiceException, which appears to be retryable
@@ -291,19 +366,19 @@ func CloudControlService(ctx context.Context, d *plugin.QueryData) (*cloudcontro
 	svc := cloudcontrolapi.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudcontrolapiiface.CloudControlApiAPI), nil
 }
 
 // CodeBuildService returns the service connection for AWS CodeBuild service
-func CodeBuildService(ctx context.Context, d *plugin.QueryData) (*codebuild.CodeBuild, error) {
+func CodeBuildService(ctx context.Context, d *plugin.QueryData) (codebuildiface.CodeBuildAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CodeBuildService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("codebuild-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*codebuild.CodeBuild), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("codebuild-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "codebuild", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(codebuildiface.CodeBuildAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -312,19 +387,19 @@ func CodeBuildService(ctx context.Context, d *plugin.QueryData) (*codebuild.Code
 	}
 	svc := codebuild.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(codebuildiface.CodeBuildAPI), nil
 }
 
 // CodeCommitService returns the service connection for AWS CodeCommit service
-func CodeCommitService(ctx context.Context, d *plugin.QueryData) (*codecommit.CodeCommit, error) {
+func CodeCommitService(ctx context.Context, d *plugin.QueryData) (codecommitiface.CodeCommitAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CodeCommitService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("codecommit-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*codecommit.CodeCommit), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("codecommit-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "codecommit", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(codecommitiface.CodeCommitAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -333,19 +408,19 @@ func CodeCommitService(ctx context.Context, d *plugin.QueryData) (*codecommit.Co
 	}
 	svc := codecommit.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(codecommitiface.CodeCommitAPI), nil
 }
 
 // CodePipelineService returns the service connection for AWS Codepipeline service
-func CodePipelineService(ctx context.Context, d *plugin.QueryData) (*codepipeline.CodePipeline, error) {
+func CodePipelineService(ctx context.Context, d *plugin.QueryData) (codepipelineiface.CodePipelineAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CodePipelineService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("codepipeline-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*codepipeline.CodePipeline), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("codepipeline-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "codepipeline", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(codepipelineiface.CodePipelineAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -354,15 +429,15 @@ func CodePipelineService(ctx context.Context, d *plugin.QueryData) (*codepipelin
 	}
 	svc := codepipeline.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(codepipelineiface.CodePipelineAPI), nil
 }
 
 // CloudFrontService returns the service connection for AWS CloudFront service
-func CloudFrontService(ctx context.Context, d *plugin.QueryData) (*cloudfront.CloudFront, error) {
+func CloudFrontService(ctx context.Context, d *plugin.QueryData) (cloudfrontiface.CloudFrontAPI, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "cloudfront"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudfront.CloudFront), nil
+	serviceCacheKey := scopedCacheKey(d, "cloudfront")
+	if cachedData, ok := getCachedServiceClient(d, "cloudfront", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudfrontiface.CloudFrontAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -371,19 +446,19 @@ func CloudFrontService(ctx context.Context, d *plugin.QueryData) (*cloudfront.Cl
 	}
 	svc := cloudfront.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudfrontiface.CloudFrontAPI), nil
 }
 
 // CloudFormationService returns the service connection for AWS CloudFormation service
-func CloudFormationService(ctx context.Context, d *plugin.QueryData) (*cloudformation.CloudFormation, error) {
+func CloudFormationService(ctx context.Context, d *plugin.QueryData) (cloudformationiface.CloudFormationAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CloudFormationService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("cloudformation-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudformation.CloudFormation), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("cloudformation-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "cloudformation", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudformationiface.CloudFormationAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -393,19 +468,19 @@ func CloudFormationService(ctx context.Context, d *plugin.QueryData) (*cloudform
 	svc := cloudformation.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudformationiface.CloudFormationAPI), nil
 }
 
 // CloudWatchService returns the service connection for AWS Cloud Watch service
-func CloudWatchService(ctx context.Context, d *plugin.QueryData) (*cloudwatch.CloudWatch, error) {
+func CloudWatchService(ctx context.Context, d *plugin.QueryData) (cloudwatchiface.CloudWatchAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CloudWatchService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("cloudwatch-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudwatch.CloudWatch), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("cloudwatch-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "cloudwatch", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudwatchiface.CloudWatchAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -415,19 +490,19 @@ func CloudWatchService(ctx context.Context, d *plugin.QueryData) (*cloudwatch.Cl
 	svc := cloudwatch.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudwatchiface.CloudWatchAPI), nil
 }
 
 // CloudWatchLogsService returns the service connection for AWS Cloud Watch Logs service
-func CloudWatchLogsService(ctx context.Context, d *plugin.QueryData) (*cloudwatchlogs.CloudWatchLogs, error) {
+func CloudWatchLogsService(ctx context.Context, d *plugin.QueryData) (cloudwatchlogsiface.CloudWatchLogsAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CloudWatchLogsService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("cloudwatchlogs-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudwatchlogs.CloudWatchLogs), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("cloudwatchlogs-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "cloudwatchlogs", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudwatchlogsiface.CloudWatchLogsAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -437,19 +512,19 @@ func CloudWatchLogsService(ctx context.Context, d *plugin.QueryData) (*cloudwatc
 	svc := cloudwatchlogs.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudwatchlogsiface.CloudWatchLogsAPI), nil
 }
 
 // CloudTrailService returns the service connection for AWS CloudTrail service
-func CloudTrailService(ctx context.Context, d *plugin.QueryData) (*cloudtrail.CloudTrail, error) {
+func CloudTrailService(ctx context.Context, d *plugin.QueryData) (cloudtrailiface.CloudTrailAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed CloudTrailService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("cloudtrail-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*cloudtrail.CloudTrail), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("cloudtrail-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "cloudtrail", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(cloudtrailiface.CloudTrailAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -459,15 +534,15 @@ func CloudTrailService(ctx context.Context, d *plugin.QueryData) (*cloudtrail.Cl
 	svc := cloudtrail.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(cloudtrailiface.CloudTrailAPI), nil
 }
 
 // CostExplorerService returns the service connection for AWS Cost Explorer service
-func CostExplorerService(ctx context.Context, d *plugin.QueryData) (*costexplorer.CostExplorer, error) {
+func CostExplorerService(ctx context.Context, d *plugin.QueryData) (costexploreriface.CostExplorerAPI, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "costexplorer"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*costexplorer.CostExplorer), nil
+	serviceCacheKey := scopedCacheKey(d, "costexplorer")
+	if cachedData, ok := getCachedServiceClient(d, "costexplorer", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(costexploreriface.CostExplorerAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -477,19 +552,19 @@ func CostExplorerService(ctx context.Context, d *plugin.QueryData) (*costexplore
 	svc := costexplorer.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(costexploreriface.CostExplorerAPI), nil
 }
 
 // DaxService returns the service connection for AWS DAX service
-func DaxService(ctx context.Context, d *plugin.QueryData) (*dax.DAX, error) {
+func DaxService(ctx context.Context, d *plugin.QueryData) (daxiface.DAXAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed DaxService")
+		return nil, fmt.Errorf("region must be passed DaxService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("dax-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*dax.DAX), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("dax-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "dax", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(daxiface.DAXAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -499,19 +574,19 @@ func DaxService(ctx context.Context, d *plugin.QueryData) (*dax.DAX, error) {
 	svc := dax.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(daxiface.DAXAPI), nil
 }
 
 // DatabaseMigrationService returns the service connection for AWS Database Migration service
-func DatabaseMigrationService(ctx context.Context, d *plugin.QueryData) (*databasemigrationservice.DatabaseMigrationService, error) {
+func DatabaseMigrationService(ctx context.Context, d *plugin.QueryData) (databasemigrationserviceiface.DatabaseMigrationServiceAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed DatabaseMigrationService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("databasemigrationservice-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*databasemigrationservice.DatabaseMigrationService), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("databasemigrationservice-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "databasemigrationservice", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(databasemigrationserviceiface.DatabaseMigrationServiceAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -521,19 +596,19 @@ func DatabaseMigrationService(ctx context.Context, d *plugin.QueryData) (*databa
 	svc := databasemigrationservice.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(databasemigrationserviceiface.DatabaseMigrationServiceAPI), nil
 }
 
 // DirectoryService returns the service connection for AWS Directory service
-func DirectoryService(ctx context.Context, d *plugin.QueryData) (*directoryservice.DirectoryService, error) {
+func DirectoryService(ctx context.Context, d *plugin.QueryData) (directoryserviceiface.DirectoryServiceAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed DirectoryService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("directoryservice-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*directoryservice.DirectoryService), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("directoryservice-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "directoryservice", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(directoryserviceiface.DirectoryServiceAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -543,19 +618,19 @@ func DirectoryService(ctx context.Context, d *plugin.QueryData) (*directoryservi
 	svc := directoryservice.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(directoryserviceiface.DirectoryServiceAPI), nil
 }
 
 // DynamoDbService returns the service connection for AWS DynamoDb service
-func DynamoDbService(ctx context.Context, d *plugin.QueryData) (*dynamodb.DynamoDB, error) {
+func DynamoDbService(ctx context.Context, d *plugin.QueryData) (dynamodbiface.DynamoDBAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed DynamoDbService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("dynamodb-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*dynamodb.DynamoDB), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("dynamodb-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "dynamodb", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(dynamodbiface.DynamoDBAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -565,18 +640,18 @@ func DynamoDbService(ctx context.Context, d *plugin.QueryData) (*dynamodb.Dynamo
 	svc := dynamodb.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(dynamodbiface.DynamoDBAPI), nil
 }
 
 // Ec2Service returns the service connection for AWS EC2 service
-func Ec2Service(ctx context.Context, d *plugin.QueryData, region string) (*ec2.EC2, error) {
+func Ec2Service(ctx context.Context, d *plugin.QueryData, region string) (ec2iface.EC2API, error) {
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed Ec2Service")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ec2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ec2.EC2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ec2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ec2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ec2iface.EC2API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -586,19 +661,19 @@ func Ec2Service(ctx context.Context, d *plugin.QueryData, region string) (*ec2.E
 	svc := ec2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ec2iface.EC2API), nil
 }
 
 // EcrService returns the service connection for AWS ECR service
-func EcrService(ctx context.Context, d *plugin.QueryData) (*ecr.ECR, error) {
+func EcrService(ctx context.Context, d *plugin.QueryData) (ecriface.ECRAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EcrService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ecr-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ecr.ECR), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ecr-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ecr", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ecriface.ECRAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -609,19 +684,19 @@ func EcrService(ctx context.Context, d *plugin.QueryData) (*ecr.ECR, error) {
 	svc := ecr.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ecriface.ECRAPI), nil
 }
 
 // EcrPublicService returns the service connection for AWS ECRPublic service
-func EcrPublicService(ctx context.Context, d *plugin.QueryData) (*ecrpublic.ECRPublic, error) {
+func EcrPublicService(ctx context.Context, d *plugin.QueryData) (ecrpubliciface.ECRPublicAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed EcrPublicService")
+		return nil, fmt.Errorf("region must be passed EcrPublicService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ecrpublic-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ecrpublic.ECRPublic), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ecrpublic-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ecrpublic", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ecrpubliciface.ECRPublicAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -632,19 +707,19 @@ func EcrPublicService(ctx context.Context, d *plugin.QueryData) (*ecrpublic.ECRP
 	svc := ecrpublic.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ecrpubliciface.ECRPublicAPI), nil
 }
 
 // EcsService returns the service connection for AWS ECS service
-func EcsService(ctx context.Context, d *plugin.QueryData) (*ecs.ECS, error) {
+func EcsService(ctx context.Context, d *plugin.QueryData) (ecsiface.ECSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EcsService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ecs-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ecs.ECS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ecs-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ecs", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ecsiface.ECSAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -655,20 +730,20 @@ func EcsService(ctx context.Context, d *plugin.QueryData) (*ecs.ECS, error) {
 	svc := ecs.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ecsiface.ECSAPI), nil
 }
 
 // EfsService returns the service connection for AWS Elastic File System service
-func EfsService(ctx context.Context, d *plugin.QueryData) (*efs.EFS, error) {
+func EfsService(ctx context.Context, d *plugin.QueryData) (efsiface.EFSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EfsService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("efs-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*efs.EFS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("efs-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "efs", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(efsiface.EFSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -678,20 +753,20 @@ func EfsService(ctx context.Context, d *plugin.QueryData) (*efs.EFS, error) {
 	svc := efs.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(efsiface.EFSAPI), nil
 }
 
 // FsxService returns the service connection for AWS FSx File System service
-func FsxService(ctx context.Context, d *plugin.QueryData) (*fsx.FSx, error) {
+func FsxService(ctx context.Context, d *plugin.QueryData) (fsxiface.FSxAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed FsxService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("fsx-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*fsx.FSx), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("fsx-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "fsx", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(fsxiface.FSxAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -701,19 +776,19 @@ func FsxService(ctx context.Context, d *plugin.QueryData) (*fsx.FSx, error) {
 	svc := fsx.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(fsxiface.FSxAPI), nil
 }
 
 // EksService returns the service connection for AWS EKS service
-func EksService(ctx context.Context, d *plugin.QueryData) (*eks.EKS, error) {
+func EksService(ctx context.Context, d *plugin.QueryData) (eksiface.EKSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EksService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("eks-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*eks.EKS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("eks-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "eks", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(eksiface.EKSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -723,19 +798,19 @@ func EksService(ctx context.Context, d *plugin.QueryData) (*eks.EKS, error) {
 	svc := eks.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(eksiface.EKSAPI), nil
 }
 
 // ElasticBeanstalkService returns the service connection for AWS ElasticBeanstalk service
-func ElasticBeanstalkService(ctx context.Context, d *plugin.QueryData) (*elasticbeanstalk.ElasticBeanstalk, error) {
+func ElasticBeanstalkService(ctx context.Context, d *plugin.QueryData) (elasticbeanstalkiface.ElasticBeanstalkAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ElasticBeanstalkService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("elasticbeanstalk-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*elasticbeanstalk.ElasticBeanstalk), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("elasticbeanstalk-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "elasticbeanstalk", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(elasticbeanstalkiface.ElasticBeanstalkAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -745,19 +820,19 @@ func ElasticBeanstalkService(ctx context.Context, d *plugin.QueryData) (*elastic
 	svc := elasticbeanstalk.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(elasticbeanstalkiface.ElasticBeanstalkAPI), nil
 }
 
 // ElastiCacheService returns the service connection for AWS ElastiCache service
-func ElastiCacheService(ctx context.Context, d *plugin.QueryData) (*elasticache.ElastiCache, error) {
+func ElastiCacheService(ctx context.Context, d *plugin.QueryData) (elasticacheiface.ElastiCacheAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ElastiCache")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("elasticache-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*elasticache.ElastiCache), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("elasticache-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "elasticache", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(elasticacheiface.ElastiCacheAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -766,19 +841,19 @@ func ElastiCacheService(ctx context.Context, d *plugin.QueryData) (*elasticache.
 	}
 	svc := elasticache.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(elasticacheiface.ElastiCacheAPI), nil
 }
 
 // ElasticsearchService returns the service connection for AWS Elasticsearch service
-func ElasticsearchService(ctx context.Context, d *plugin.QueryData) (*elasticsearchservice.ElasticsearchService, error) {
+func ElasticsearchService(ctx context.Context, d *plugin.QueryData) (elasticsearchserviceiface.ElasticsearchServiceAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ElasticsearchService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("elasticsearch-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*elasticsearchservice.ElasticsearchService), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("elasticsearch-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "elasticsearchservice", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(elasticsearchserviceiface.ElasticsearchServiceAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -787,20 +862,20 @@ func ElasticsearchService(ctx context.Context, d *plugin.QueryData) (*elasticsea
 	}
 	svc := elasticsearchservice.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(elasticsearchserviceiface.ElasticsearchServiceAPI), nil
 }
 
 // ELBv2Service returns the service connection for AWS EC2 service
-func ELBv2Service(ctx context.Context, d *plugin.QueryData) (*elbv2.ELBV2, error) {
+func ELBv2Service(ctx context.Context, d *plugin.QueryData) (elbv2iface.ELBV2API, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ELBv2Service")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("elbv2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*elbv2.ELBV2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("elbv2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "elbv2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(elbv2iface.ELBV2API), nil
 	}
 
 	// so it was not in cache - create service
@@ -811,20 +886,20 @@ func ELBv2Service(ctx context.Context, d *plugin.QueryData) (*elbv2.ELBV2, error
 	svc := elbv2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(elbv2iface.ELBV2API), nil
 }
 
 // ELBService returns the service connection for AWS ELB Classic service
-func ELBService(ctx context.Context, d *plugin.QueryData) (*elb.ELB, error) {
+func ELBService(ctx context.Context, d *plugin.QueryData) (elbiface.ELBAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ELBv2Service")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("elb-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*elb.ELB), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("elb-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "elb", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(elbiface.ELBAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -835,20 +910,20 @@ func ELBService(ctx context.Context, d *plugin.QueryData) (*elb.ELB, error) {
 	svc := elb.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(elbiface.ELBAPI), nil
 }
 
 // EventBridgeService returns the service connection for AWS EventBridge service
-func EventBridgeService(ctx context.Context, d *plugin.QueryData) (*eventbridge.EventBridge, error) {
+func EventBridgeService(ctx context.Context, d *plugin.QueryData) (eventbridgeiface.EventBridgeAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EventBridgeService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("eventbridge-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*eventbridge.EventBridge), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("eventbridge-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "eventbridge", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(eventbridgeiface.EventBridgeAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -859,20 +934,20 @@ func EventBridgeService(ctx context.Context, d *plugin.QueryData) (*eventbridge.
 	svc := eventbridge.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(eventbridgeiface.EventBridgeAPI), nil
 }
 
 // EmrService returns the service connection for AWS EMR service
-func EmrService(ctx context.Context, d *plugin.QueryData) (*emr.EMR, error) {
+func EmrService(ctx context.Context, d *plugin.QueryData) (emriface.EMRAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed EmrService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("emr-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*emr.EMR), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("emr-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "emr", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(emriface.EMRAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -883,19 +958,19 @@ func EmrService(ctx context.Context, d *plugin.QueryData) (*emr.EMR, error) {
 	svc := emr.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(emriface.EMRAPI), nil
 }
 
 // FirehoseService returns the service connection for AWS Kinesis Firehose service
-func FirehoseService(ctx context.Context, d *plugin.QueryData) (*firehose.Firehose, error) {
+func FirehoseService(ctx context.Context, d *plugin.QueryData) (firehoseiface.FirehoseAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed FirehoseService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("firehose-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*firehose.Firehose), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("firehose-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "firehose", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(firehoseiface.FirehoseAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -905,20 +980,20 @@ func FirehoseService(ctx context.Context, d *plugin.QueryData) (*firehose.Fireho
 	svc := firehose.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(firehoseiface.FirehoseAPI), nil
 }
 
 // GlacierService returns the service connection for AWS Glacier service
-func GlacierService(ctx context.Context, d *plugin.QueryData) (*glacier.Glacier, error) {
+func GlacierService(ctx context.Context, d *plugin.QueryData) (glacieriface.GlacierAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed GlacierService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("glacier-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*glacier.Glacier), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("glacier-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "glacier", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(glacieriface.GlacierAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -929,20 +1004,20 @@ func GlacierService(ctx context.Context, d *plugin.QueryData) (*glacier.Glacier,
 	svc := glacier.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(glacieriface.GlacierAPI), nil
 }
 
 // GlueService returns the service connection for AWS Glue service
-func GlueService(ctx context.Context, d *plugin.QueryData) (*glue.Glue, error) {
+func GlueService(ctx context.Context, d *plugin.QueryData) (glueiface.GlueAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed GlueService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("glue-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*glue.Glue), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("glue-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "glue", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(glueiface.GlueAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -953,20 +1028,20 @@ func GlueService(ctx context.Context, d *plugin.QueryData) (*glue.Glue, error) {
 	svc := glue.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(glueiface.GlueAPI), nil
 }
 
 // GuardDutyService returns the service connection for AWS GuardDuty service
-func GuardDutyService(ctx context.Context, d *plugin.QueryData) (*guardduty.GuardDuty, error) {
+func GuardDutyService(ctx context.Context, d *plugin.QueryData) (guarddutyiface.GuardDutyAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed GuardDutyService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("guardduty-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*guardduty.GuardDuty), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("guardduty-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "guardduty", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(guarddutyiface.GuardDutyAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -977,15 +1052,15 @@ func GuardDutyService(ctx context.Context, d *plugin.QueryData) (*guardduty.Guar
 	svc := guardduty.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(guarddutyiface.GuardDutyAPI), nil
 }
 
 // IAMService returns the service connection for AWS IAM service
-func IAMService(ctx context.Context, d *plugin.QueryData) (*iam.IAM, error) {
+func IAMService(ctx context.Context, d *plugin.QueryData) (iamiface.IAMAPI, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "iam"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*iam.IAM), nil
+	serviceCacheKey := scopedCacheKey(d, "iam")
+	if cachedData, ok := getCachedServiceClient(d, "iam", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(iamiface.IAMAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -996,19 +1071,19 @@ func IAMService(ctx context.Context, d *plugin.QueryData) (*iam.IAM, error) {
 	svc := iam.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(iamiface.IAMAPI), nil
 }
 
 // IdentityStoreService returns the service connection for AWS IdentityStore service
-func IdentityStoreService(ctx context.Context, d *plugin.QueryData) (*identitystore.IdentityStore, error) {
+func IdentityStoreService(ctx context.Context, d *plugin.QueryData) (identitystoreiface.IdentityStoreAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed IdentityStoreService")
+		return nil, fmt.Errorf("region must be passed IdentityStoreService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("identitystore-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*identitystore.IdentityStore), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("identitystore-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "identitystore", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(identitystoreiface.IdentityStoreAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1018,19 +1093,19 @@ func IdentityStoreService(ctx context.Context, d *plugin.QueryData) (*identityst
 	svc := identitystore.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(identitystoreiface.IdentityStoreAPI), nil
 }
 
 // InspectorService returns the service connection for AWS Inspector service
-func InspectorService(ctx context.Context, d *plugin.QueryData) (*inspector.Inspector, error) {
+func InspectorService(ctx context.Context, d *plugin.QueryData) (inspectoriface.InspectorAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed InspectorService")
+		return nil, fmt.Errorf("region must be passed InspectorService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("inspector-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*inspector.Inspector), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("inspector-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "inspector", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(inspectoriface.InspectorAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1040,19 +1115,19 @@ func InspectorService(ctx context.Context, d *plugin.QueryData) (*inspector.Insp
 	svc := inspector.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(inspectoriface.InspectorAPI), nil
 }
 
 // KinesisService returns the service connection for AWS Kinesis service
-func KinesisService(ctx context.Context, d *plugin.QueryData) (*kinesis.Kinesis, error) {
+func KinesisService(ctx context.Context, d *plugin.QueryData) (kinesisiface.KinesisAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed KinesisService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("kinesis-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*kinesis.Kinesis), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("kinesis-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "kinesis", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(kinesisiface.KinesisAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1062,19 +1137,19 @@ func KinesisService(ctx context.Context, d *plugin.QueryData) (*kinesis.Kinesis,
 	svc := kinesis.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(kinesisiface.KinesisAPI), nil
 }
 
 // KinesisAnalyticsV2Service returns the service connection for AWS Kinesis AnalyticsV2 service
-func KinesisAnalyticsV2Service(ctx context.Context, d *plugin.QueryData) (*kinesisanalyticsv2.KinesisAnalyticsV2, error) {
+func KinesisAnalyticsV2Service(ctx context.Context, d *plugin.QueryData) (kinesisanalyticsv2iface.KinesisAnalyticsV2API, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed KinesisAnalyticsV2Service")
+		return nil, fmt.Errorf("region must be passed KinesisAnalyticsV2Service: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("kinesisanalyticsv2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*kinesisanalyticsv2.KinesisAnalyticsV2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("kinesisanalyticsv2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "kinesisanalyticsv2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(kinesisanalyticsv2iface.KinesisAnalyticsV2API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1084,19 +1159,19 @@ func KinesisAnalyticsV2Service(ctx context.Context, d *plugin.QueryData) (*kines
 	svc := kinesisanalyticsv2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(kinesisanalyticsv2iface.KinesisAnalyticsV2API), nil
 }
 
 // KinesisVideoService returns the service connection for AWS Kinesis Video service
-func KinesisVideoService(ctx context.Context, d *plugin.QueryData) (*kinesisvideo.KinesisVideo, error) {
+func KinesisVideoService(ctx context.Context, d *plugin.QueryData) (kinesisvideoiface.KinesisVideoAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed Kinesis Video")
+		return nil, fmt.Errorf("region must be passed Kinesis Video: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("kinesisvideo-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*kinesisvideo.KinesisVideo), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("kinesisvideo-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "kinesisvideo", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(kinesisvideoiface.KinesisVideoAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1106,19 +1181,19 @@ func KinesisVideoService(ctx context.Context, d *plugin.QueryData) (*kinesisvide
 	svc := kinesisvideo.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(kinesisvideoiface.KinesisVideoAPI), nil
 }
 
 // KMSService returns the service connection for AWS KMS service
-func KMSService(ctx context.Context, d *plugin.QueryData) (*kms.KMS, error) {
+func KMSService(ctx context.Context, d *plugin.QueryData) (kmsiface.KMSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed KMSService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("kms-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*kms.KMS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("kms-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "kms", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(kmsiface.KMSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1128,19 +1203,19 @@ func KMSService(ctx context.Context, d *plugin.QueryData) (*kms.KMS, error) {
 	svc := kms.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(kmsiface.KMSAPI), nil
 }
 
 // LambdaService returns the service connection for AWS Lambda service
-func LambdaService(ctx context.Context, d *plugin.QueryData) (*lambda.Lambda, error) {
+func LambdaService(ctx context.Context, d *plugin.QueryData) (lambdaiface.LambdaAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed LambdaService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("lambda-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*lambda.Lambda), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("lambda-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "lambda", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(lambdaiface.LambdaAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1150,19 +1225,19 @@ func LambdaService(ctx context.Context, d *plugin.QueryData) (*lambda.Lambda, er
 	svc := lambda.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(lambdaiface.LambdaAPI), nil
 }
 
 // Macie2Service returns the service connection for AWS Macie2 service
-func Macie2Service(ctx context.Context, d *plugin.QueryData) (*macie2.Macie2, error) {
+func Macie2Service(ctx context.Context, d *plugin.QueryData) (macie2iface.Macie2API, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed Macie2Service")
+		return nil, fmt.Errorf("region must be passed Macie2Service: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("macie2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*macie2.Macie2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("macie2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "macie2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(macie2iface.Macie2API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1172,19 +1247,19 @@ func Macie2Service(ctx context.Context, d *plugin.QueryData) (*macie2.Macie2, er
 	svc := macie2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(macie2iface.Macie2API), nil
 }
 
 // MediaStoreService returns the service connection for AWS Media Store Service
-func MediaStoreService(ctx context.Context, d *plugin.QueryData) (*mediastore.MediaStore, error) {
+func MediaStoreService(ctx context.Context, d *plugin.QueryData) (mediastoreiface.MediaStoreAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed MediaStoreService")
+		return nil, fmt.Errorf("region must be passed MediaStoreService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("mediastore-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*mediastore.MediaStore), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("mediastore-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "mediastore", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(mediastoreiface.MediaStoreAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1194,15 +1269,15 @@ func MediaStoreService(ctx context.Context, d *plugin.QueryData) (*mediastore.Me
 	svc := mediastore.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(mediastoreiface.MediaStoreAPI), nil
 }
 
 // OrganizationService returns the service connection for AWS Organization service
-func OrganizationService(ctx context.Context, d *plugin.QueryData) (*organizations.Organizations, error) {
+func OrganizationService(ctx context.Context, d *plugin.QueryData) (organizationsiface.OrganizationsAPI, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "Organization"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*organizations.Organizations), nil
+	serviceCacheKey := scopedCacheKey(d, "Organization")
+	if cachedData, ok := getCachedServiceClient(d, "organizations", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(organizationsiface.OrganizationsAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -1212,19 +1287,19 @@ func OrganizationService(ctx context.Context, d *plugin.QueryData) (*organizatio
 	svc := organizations.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(organizationsiface.OrganizationsAPI), nil
 }
 
 // ConfigService returns the service connection for AWS Config  service
-func ConfigService(ctx context.Context, d *plugin.QueryData) (*configservice.ConfigService, error) {
+func ConfigService(ctx context.Context, d *plugin.QueryData) (configserviceiface.ConfigServiceAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed ConfigService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("config-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*configservice.ConfigService), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("config-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "configservice", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(configserviceiface.ConfigServiceAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1234,19 +1309,19 @@ func ConfigService(ctx context.Context, d *plugin.QueryData) (*configservice.Con
 	svc := configservice.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(configserviceiface.ConfigServiceAPI), nil
 }
 
 // RDSService returns the service connection for AWS RDS service
-func RDSService(ctx context.Context, d *plugin.QueryData) (*rds.RDS, error) {
+func RDSService(ctx context.Context, d *plugin.QueryData) (rdsiface.RDSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed RDSService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("rds-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*rds.RDS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("rds-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "rds", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(rdsiface.RDSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1256,19 +1331,19 @@ func RDSService(ctx context.Context, d *plugin.QueryData) (*rds.RDS, error) {
 	svc := rds.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(rdsiface.RDSAPI), nil
 }
 
 // RedshiftService returns the service connection for AWS Redshift service
-func RedshiftService(ctx context.Context, d *plugin.QueryData) (*redshift.Redshift, error) {
+func RedshiftService(ctx context.Context, d *plugin.QueryData) (redshiftiface.RedshiftAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed Redshift")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("redshift-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*redshift.Redshift), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("redshift-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "redshift", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(redshiftiface.RedshiftAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1278,19 +1353,19 @@ func RedshiftService(ctx context.Context, d *plugin.QueryData) (*redshift.Redshi
 	svc := redshift.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(redshiftiface.RedshiftAPI), nil
 }
 
 // Route53DomainsService returns the service connection for AWS route53 domains service
-func Route53DomainsService(ctx context.Context, d *plugin.QueryData) (*route53domains.Route53Domains, error) {
+func Route53DomainsService(ctx context.Context, d *plugin.QueryData) (route53domainsiface.Route53DomainsAPI, error) {
 	region := "us-east-1"
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed Route53Domains")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("route53domain-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*route53domains.Route53Domains), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("route53domain-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "route53domains", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(route53domainsiface.Route53DomainsAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1300,19 +1375,19 @@ func Route53DomainsService(ctx context.Context, d *plugin.QueryData) (*route53do
 	}
 	svc := route53domains.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(route53domainsiface.Route53DomainsAPI), nil
 }
 
 // Route53ResolverService returns the service connection for AWS route53resolver service
-func Route53ResolverService(ctx context.Context, d *plugin.QueryData) (*route53resolver.Route53Resolver, error) {
+func Route53ResolverService(ctx context.Context, d *plugin.QueryData) (route53resolveriface.Route53ResolverAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed Route53Resolver")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("route53resolver-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*route53resolver.Route53Resolver), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("route53resolver-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "route53resolver", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(route53resolveriface.Route53ResolverAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1321,15 +1396,15 @@ func Route53ResolverService(ctx context.Context, d *plugin.QueryData) (*route53r
 	}
 	svc := route53resolver.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(route53resolveriface.Route53ResolverAPI), nil
 }
 
 // Route53Service returns the service connection for AWS route53 service
-func Route53Service(ctx context.Context, d *plugin.QueryData) (*route53.Route53, error) {
+func Route53Service(ctx context.Context, d *plugin.QueryData) (route53iface.Route53API, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "route53"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*route53.Route53), nil
+	serviceCacheKey := scopedCacheKey(d, "route53")
+	if cachedData, ok := getCachedServiceClient(d, "route53", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(route53iface.Route53API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -1338,20 +1413,19 @@ func Route53Service(ctx context.Context, d *plugin.QueryData) (*route53.Route53,
 	}
 	svc := route53.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(route53iface.Route53API), nil
 }
 
 // SecretsManagerService returns the service connection for AWS secretsManager service
-func SecretsManagerService(ctx context.Context, d *plugin.QueryData) (*secretsmanager.SecretsManager,
-	error) {
+func SecretsManagerService(ctx context.Context, d *plugin.QueryData) (secretsmanageriface.SecretsManagerAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SecretsManagerService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("secretsmanager-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*secretsmanager.SecretsManager), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("secretsmanager-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "secretsmanager", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(secretsmanageriface.SecretsManagerAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1360,19 +1434,19 @@ func SecretsManagerService(ctx context.Context, d *plugin.QueryData) (*secretsma
 	}
 	svc := secretsmanager.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(secretsmanageriface.SecretsManagerAPI), nil
 }
 
 // SecurityHubService returns the service connection for AWS securityHub service
-func SecurityHubService(ctx context.Context, d *plugin.QueryData) (*securityhub.SecurityHub, error) {
+func SecurityHubService(ctx context.Context, d *plugin.QueryData) (securityhubiface.SecurityHubAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SecurityHubService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("securityhub-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*securityhub.SecurityHub), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("securityhub-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "securityhub", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(securityhubiface.SecurityHubAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1381,19 +1455,19 @@ func SecurityHubService(ctx context.Context, d *plugin.QueryData) (*securityhub.
 	}
 	svc := securityhub.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(securityhubiface.SecurityHubAPI), nil
 }
 
 // S3ControlService returns the service connection for AWS s3control service
-func S3ControlService(ctx context.Context, d *plugin.QueryData, region string) (*s3control.S3Control, error) {
+func S3ControlService(ctx context.Context, d *plugin.QueryData, region string) (s3controliface.S3ControlAPI, error) {
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed S3ControlService")
 	}
 
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("s3control-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*s3control.S3Control), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("s3control-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "s3control", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(s3controliface.S3ControlAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1403,18 +1477,18 @@ func S3ControlService(ctx context.Context, d *plugin.QueryData, region string) (
 	svc := s3control.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(s3controliface.S3ControlAPI), nil
 }
 
 // S3Service returns the service connection for AWS S3 service
-func S3Service(ctx context.Context, d *plugin.QueryData, region string) (*s3.S3, error) {
+func S3Service(ctx context.Context, d *plugin.QueryData, region string) (s3iface.S3API, error) {
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed S3Service")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("s3-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*s3.S3), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("s3-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "s3", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(s3iface.S3API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1424,19 +1498,19 @@ func S3Service(ctx context.Context, d *plugin.QueryData, region string) (*s3.S3,
 	svc := s3.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(s3iface.S3API), nil
 }
 
 // SageMakerService returns the service connection for AWS SageMaker service
-func SageMakerService(ctx context.Context, d *plugin.QueryData) (*sagemaker.SageMaker, error) {
+func SageMakerService(ctx context.Context, d *plugin.QueryData) (sagemakeriface.SageMakerAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SageMakerService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("sagemaker-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*sagemaker.SageMaker), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("sagemaker-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "sagemaker", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(sagemakeriface.SageMakerAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1445,19 +1519,19 @@ func SageMakerService(ctx context.Context, d *plugin.QueryData) (*sagemaker.Sage
 	}
 	svc := sagemaker.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(sagemakeriface.SageMakerAPI), nil
 }
 
 // SNSService returns the service connection for AWS SNS service
-func SNSService(ctx context.Context, d *plugin.QueryData) (*sns.SNS, error) {
+func SNSService(ctx context.Context, d *plugin.QueryData) (snsiface.SNSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SNSService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("sns-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*sns.SNS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("sns-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "sns", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(snsiface.SNSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1467,19 +1541,19 @@ func SNSService(ctx context.Context, d *plugin.QueryData) (*sns.SNS, error) {
 	svc := sns.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(snsiface.SNSAPI), nil
 }
 
 // SQSService returns the service connection for AWS SQS service
-func SQSService(ctx context.Context, d *plugin.QueryData) (*sqs.SQS, error) {
+func SQSService(ctx context.Context, d *plugin.QueryData) (sqsiface.SQSAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SQSService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("sqs-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*sqs.SQS), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("sqs-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "sqs", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(sqsiface.SQSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1489,19 +1563,19 @@ func SQSService(ctx context.Context, d *plugin.QueryData) (*sqs.SQS, error) {
 	svc := sqs.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(sqsiface.SQSAPI), nil
 }
 
 // SsmService returns the service connection for AWS SSM service
-func SsmService(ctx context.Context, d *plugin.QueryData) (*ssm.SSM, error) {
+func SsmService(ctx context.Context, d *plugin.QueryData) (ssmiface.SSMAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed SsmService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ssm-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ssm.SSM), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ssm-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ssm", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ssmiface.SSMAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1511,19 +1585,19 @@ func SsmService(ctx context.Context, d *plugin.QueryData) (*ssm.SSM, error) {
 	svc := ssm.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ssmiface.SSMAPI), nil
 }
 
 // SSOAdminService returns the service connection for AWS SSM service
-func SSOAdminService(ctx context.Context, d *plugin.QueryData) (*ssoadmin.SSOAdmin, error) {
+func SSOAdminService(ctx context.Context, d *plugin.QueryData) (ssoadminiface.SSOAdminAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed SSOAdminService")
+		return nil, fmt.Errorf("region must be passed SSOAdminService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("ssoadmin-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*ssoadmin.SSOAdmin), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("ssoadmin-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "ssoadmin", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(ssoadminiface.SSOAdminAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1533,19 +1607,19 @@ func SSOAdminService(ctx context.Context, d *plugin.QueryData) (*ssoadmin.SSOAdm
 	svc := ssoadmin.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(ssoadminiface.SSOAdminAPI), nil
 }
 
 // StepFunctionsService returns the service connection for AWS Step Functions service
-func StepFunctionsService(ctx context.Context, d *plugin.QueryData) (*sfn.SFN, error) {
+func StepFunctionsService(ctx context.Context, d *plugin.QueryData) (sfniface.SFNAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed StepFunctionsService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("stepfunctions-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*sfn.SFN), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("stepfunctions-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "sfn", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(sfniface.SFNAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -1556,15 +1630,15 @@ func StepFunctionsService(ctx context.Context, d *plugin.QueryData) (*sfn.SFN, e
 	svc := sfn.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(sfniface.SFNAPI), nil
 }
 
 // StsService returns the service connection for AWS STS service
-func StsService(ctx context.Context, d *plugin.QueryData) (*sts.STS, error) {
+func StsService(ctx context.Context, d *plugin.QueryData) (stsiface.STSAPI, error) {
 	// have we already created and cached the service?
-	serviceCacheKey := "sts"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*sts.STS), nil
+	serviceCacheKey := scopedCacheKey(d, "sts")
+	if cachedData, ok := getCachedServiceClient(d, "sts", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(stsiface.STSAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, GetDefaultAwsRegion(d))
@@ -1574,20 +1648,20 @@ func StsService(ctx context.Context, d *plugin.QueryData) (*sts.STS, error) {
 	svc := sts.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(stsiface.STSAPI), nil
 }
 
 // TaggignResourceService returns the service connection for AWS ResourceTaggingAPI service
-func TaggignResourceService(ctx context.Context, d *plugin.QueryData) (*resourcegroupstaggingapi.ResourceGroupsTaggingAPI, error) {
+func TaggignResourceService(ctx context.Context, d *plugin.QueryData) (resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed TaggignResourceService")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("resourcetaggingapi-%s", region)
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("resourcetaggingapi-%s", region))
 
-	if cacheData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cacheData.(*resourcegroupstaggingapi.ResourceGroupsTaggingAPI), nil
+	if cacheData, ok := getCachedServiceClient(d, "resourcegroupstaggingapi", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cacheData).(resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1597,16 +1671,16 @@ func TaggignResourceService(ctx context.Context, d *plugin.QueryData) (*resource
 	svc := resourcegroupstaggingapi.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI), nil
 }
 
 // WAFService returns the service connection for AWS WAF service
-func WAFService(ctx context.Context, d *plugin.QueryData) (*waf.WAF, error) {
+func WAFService(ctx context.Context, d *plugin.QueryData) (wafiface.WAFAPI, error) {
 
 	// have we already created and cached the service?
-	serviceCacheKey := "waf"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*waf.WAF), nil
+	serviceCacheKey := scopedCacheKey(d, "waf")
+	if cachedData, ok := getCachedServiceClient(d, "waf", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(wafiface.WAFAPI), nil
 	}
 
 	// so it was not in cache - create service
@@ -1617,18 +1691,18 @@ func WAFService(ctx context.Context, d *plugin.QueryData) (*waf.WAF, error) {
 	svc := waf.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(wafiface.WAFAPI), nil
 }
 
 // WAFv2Service returns the service connection for AWS WAFv2 service
-func WAFv2Service(ctx context.Context, d *plugin.QueryData, region string) (*wafv2.WAFV2, error) {
+func WAFv2Service(ctx context.Context, d *plugin.QueryData, region string) (wafv2iface.WAFV2API, error) {
 	if region == "" {
 		return nil, fmt.Errorf("region must be passed WAFv2")
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("wafv2-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*wafv2.WAFV2), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("wafv2-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "wafv2", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(wafv2iface.WAFV2API), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1638,19 +1712,19 @@ func WAFv2Service(ctx context.Context, d *plugin.QueryData, region string) (*waf
 	svc := wafv2.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(wafv2iface.WAFV2API), nil
 }
 
 // WellArchitectedService returns the service connection for AWS Well-Architected service
-func WellArchitectedService(ctx context.Context, d *plugin.QueryData) (*wellarchitected.WellArchitected, error) {
+func WellArchitectedService(ctx context.Context, d *plugin.QueryData) (wellarchitectediface.WellArchitectedAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed WellArchitectedService")
+		return nil, fmt.Errorf("region must be passed WellArchitectedService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("wellarchitected-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*wellarchitected.WellArchitected), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("wellarchitected-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "wellarchitected", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(wellarchitectediface.WellArchitectedAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1660,19 +1734,19 @@ func WellArchitectedService(ctx context.Context, d *plugin.QueryData) (*wellarch
 	svc := wellarchitected.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(wellarchitectediface.WellArchitectedAPI), nil
 }
 
 // WorkspacesService returns the service connection for AWS Workspaces service
-func WorkspacesService(ctx context.Context, d *plugin.QueryData) (*workspaces.WorkSpaces, error) {
+func WorkspacesService(ctx context.Context, d *plugin.QueryData) (workspacesiface.WorkSpacesAPI, error) {
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	if region == "" {
-		return nil, fmt.Errorf("region must be passed WorkspacesService")
+		return nil, fmt.Errorf("region must be passed WorkspacesService: %w", errNoServiceRegion)
 	}
 	// have we already created and cached the service?
-	serviceCacheKey := fmt.Sprintf("workspaces-%s", region)
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(*workspaces.WorkSpaces), nil
+	serviceCacheKey := scopedCacheKey(d, fmt.Sprintf("workspaces-%s", region))
+	if cachedData, ok := getCachedServiceClient(d, "workspaces", serviceCacheKey); ok {
+		return withQueryContext(ctx, d, cachedData).(workspacesiface.WorkSpacesAPI), nil
 	}
 	// so it was not in cache - create service
 	sess, err := getSession(ctx, d, region)
@@ -1682,34 +1756,55 @@ func WorkspacesService(ctx context.Context, d *plugin.QueryData) (*workspaces.Wo
 	svc := workspaces.New(sess)
 	d.ConnectionManager.Cache.Set(serviceCacheKey, svc)
 
-	return svc, nil
+	return withQueryContext(ctx, d, svc).(workspacesiface.WorkSpacesAPI), nil
 }
 
 func getSession(ctx context.Context, d *plugin.QueryData, region string) (*session.Session, error) {
-	return getSessionWithMaxRetries(ctx, d, region, 9)
+	maxRetries := 9
+	if awsConfig := GetConfig(d.Connection); awsConfig.MaxErrorRetryAttempts != nil {
+		maxRetries = *awsConfig.MaxErrorRetryAttempts
+	}
+	return getSessionWithMaxRetries(ctx, d, region, maxRetries)
 }
 
 func getSessionWithMaxRetries(ctx context.Context, d *plugin.QueryData, region string, maxRetries int) (*session.Session, error) {
-	sessionCacheKey := fmt.Sprintf("session-%s", region)
+	sessionCacheKey := scopedCacheKey(d, fmt.Sprintf("session-%s", region))
 	if cachedData, ok := d.ConnectionManager.Cache.Get(sessionCacheKey); ok {
 		return cachedData.(*session.Session), nil
 	}
 
 	// If seesion was not in cache - create a session and save to cache
-
 	// get aws config info
 	awsConfig := GetConfig(d.Connection)
 
+	// recorded responses are replayed as they are, retrying them can't help
+	httpMode, _ := getHttpRecordReplayMode(awsConfig)
+	if httpMode == httpModeReplay {
+		maxRetries = 0
+	}
+
+	// retry delays in the connection config are in milliseconds
+	minRetryDelay := defaultMinRetryDelay
+	if awsConfig.MinErrorRetryDelay != nil {
+		minRetryDelay = time.Duration(*awsConfig.MinErrorRetryDelay) * time.Millisecond
+	}
+	maxRetryDelay := defaultMaxRetryDelay
+	if awsConfig.MaxErrorRetryDelay != nil {
+		maxRetryDelay = time.Duration(*awsConfig.MaxErrorRetryDelay) * time.Millisecond
+	}
+
 	// session default configuration
 	sessionOptions := session.Options{
 		SharedConfigState: session.SharedConfigEnable,
 		Config: aws.Config{
 			Region: &region,
-			// As per the logic used in retryRules of NewConnectionErrRetryer with minimum delay of 25ms and maximum
-			// number of retries as 9 (our default), the maximum delay will not be more than approximately 3 minutes to avoid steampipe
-			// waiting too long to render result
+			// As per the logic used in RetryRules of ConnectionErrRetryer, with the default minimum delay of 25ms
+			// growing 3x per retry and 9 retries, a call waits up to about 5 minutes in total between its attempts.
+			// Throttled calls back off from 4x the minimum delay, each delay capped at the maximum delay (5 minutes
+			// by default), so they wait up to about 12 minutes. Lower max_error_retry_attempts or max_error_retry_delay
+			// for queries to fail faster. The requests of a cancelled query are not retried, see withQueryContext.
 			MaxRetries: aws.Int(maxRetries),
-			Retryer:    NewConnectionErrRetryer(maxRetries, ctx),
+			Retryer:    NewConnectionErrRetryer(maxRetries, minRetryDelay, maxRetryDelay),
 		},
 	}
 
@@ -1717,11 +1812,20 @@ func getSessionWithMaxRetries(ctx context.Context, d *plugin.QueryData, region s
 		sessionOptions.Profile = *awsConfig.Profile
 	}
 
-	if awsConfig.AccessKey != nil && awsConfig.SecretKey == nil {
-		return nil, fmt.Errorf("Partial credentials found in connection config, missing: secret_key")
-	} else if awsConfig.SecretKey != nil && awsConfig.AccessKey == nil {
-		return nil, fmt.Errorf("Partial credentials found in connection config, missing: access_key")
-	} else if awsConfig.AccessKey != nil && awsConfig.SecretKey != nil {
+	// custom endpoints, e.g. LocalStack or VPC interface endpoints
+	if awsConfig.EndpointUrl != nil || awsConfig.Endpoints != nil || awsConfig.UseFIPSEndpoint != nil || awsConfig.UseDualStackEndpoint != nil {
+		resolver, err := getEndpointResolver(awsConfig)
+		if err != nil {
+			return nil, err
+		}
+		sessionOptions.Config.EndpointResolver = resolver
+	}
+	if awsConfig.S3ForcePathStyle != nil {
+		sessionOptions.Config.S3ForcePathStyle = awsConfig.S3ForcePathStyle
+	}
+
+	// partial credentials are rejected by validateConfig
+	if awsConfig.AccessKey != nil && awsConfig.SecretKey != nil {
 		sessionOptions.Config.Credentials = credentials.NewStaticCredentials(
 			*awsConfig.AccessKey, *awsConfig.SecretKey, "",
 		)
@@ -1733,114 +1837,228 @@ func getSessionWithMaxRetries(ctx context.Context, d *plugin.QueryData, region s
 		}
 	}
 
-	sess, err := session.NewSessionWithOptions(sessionOptions)
+	// SSO, web identity and credential_process sources, which the SDK would resolve with opaque errors
+	sourceCreds, err := getCredentialSourceCredentials(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if sourceCreds != nil {
+		sessionOptions.Config.Credentials = sourceCreds
+	}
+
+	// with the proxy, CA bundle, timeouts and connection pool of the connection config, and the
+	// handlers which the sessions of the assumed role and organization credentials inherit
+	sess, err := newHttpSession(ctx, d, sessionOptions)
 	if err != nil {
 		plugin.Logger(ctx).Error("getSessionWithMaxRetries", "new_session_with_options", err)
 		return nil, err
 	}
 
+	// if a role is configured, use the base credentials to assume it
+	if awsConfig.AssumeRoleArn != nil {
+		creds, err := getAssumeRoleCredentials(ctx, d, sess)
+		if err != nil {
+			return nil, err
+		}
+		sess = sess.Copy(&aws.Config{Credentials: creds})
+	}
+
+	// if the connection fans out across an organization, switch to the member account being queried
+	if accountId := getMatrixAccountId(d); accountId != "" {
+		creds, err := getOrganizationAccountCredentials(ctx, d, sess, accountId)
+		if err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			sess = sess.Copy(&aws.Config{Credentials: creds})
+		}
+	}
+
 	// save session in cache
 	d.ConnectionManager.Cache.Set(sessionCacheKey, sess)
 
 	return sess, nil
 }
 
-// GetDefaultAwsRegion returns the default region for AWS partiton
-// if not set by Env variable or in aws profile
-func GetDefaultAwsRegion(d *plugin.QueryData) string {
-	allAwsRegions := []string{
-		"af-south-1", "ap-east-1", "ap-northeast-1", "ap-northeast-2", "ap-northeast-3", "ap-south-1", "ap-southeast-1", "ap-southeast-2", "ca-central-1", "eu-central-1", "eu-north-1", "eu-south-1", "eu-west-1", "eu-west-2", "eu-west-3", "me-south-1", "sa-east-1", "us-east-1", "us-east-2", "us-west-1", "us-west-2", "us-gov-east-1", "us-gov-west-1", "cn-north-1", "cn-northwest-1"}
+// getEndpointResolver returns an endpoint resolver which applies the endpoint settings of the
+// connection config. Per service overrides in `endpoints` are keyed by the SDK endpoint ID of the
+// service (e.g. "s3", "ec2", "monitoring") and take precedence over `endpoint_url`.
+func getEndpointResolver(awsConfig awsConfig) (endpoints.Resolver, error) {
+	serviceEndpoints := map[string]string{}
+	for _, entry := range awsConfig.Endpoints {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("Invalid endpoints entry %q in connection config, expected: <service>=<url>", entry)
+		}
+		serviceEndpoints[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
 
-	// have we already created and cached the service?
-	serviceCacheKey := "GetDefaultAwsRegion"
-	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
-		return cachedData.(string)
+	useFIPS := awsConfig.UseFIPSEndpoint != nil && *awsConfig.UseFIPSEndpoint
+	useDualStack := awsConfig.UseDualStackEndpoint != nil && *awsConfig.UseDualStackEndpoint
+
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		if url, ok := serviceEndpoints[service]; ok {
+			return endpoints.ResolvedEndpoint{URL: url, SigningRegion: region}, nil
+		}
+		if awsConfig.EndpointUrl != nil {
+			return endpoints.ResolvedEndpoint{URL: *awsConfig.EndpointUrl, SigningRegion: region}, nil
+		}
+
+		if useDualStack {
+			// only applied to services which are known to have dualstack endpoints
+			opts = append(opts, endpoints.UseDualStackOption)
+		}
+		if useFIPS {
+			// FIPS endpoints are modelled as pseudo regions, which are named either way round
+			for _, fipsRegion := range []string{"fips-" + region, region + "-fips"} {
+				strictOpts := append(append([]func(*endpoints.Options){}, opts...), endpoints.StrictMatchingOption)
+				if resolved, err := endpoints.DefaultResolver().EndpointFor(service, fipsRegion, strictOpts...); err == nil {
+					return resolved, nil
+				}
+			}
+		}
+		return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+	}), nil
+}
+
+// getAssumeRoleCredentials returns the credentials for the role set in assume_role_arn,
+// assuming each role in assume_role_chain first. The credentials are shared by the
+// sessions of all regions and refresh themselves before they expire.
+func getAssumeRoleCredentials(ctx context.Context, d *plugin.QueryData, baseSession *session.Session) (*credentials.Credentials, error) {
+	cacheKey := connectionCacheKey(d, "AssumeRoleCredentials")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*credentials.Credentials), nil
 	}
 
 	// get aws config info
 	awsConfig := GetConfig(d.Connection)
 
-	var regions []string
-	var region string
+	if awsConfig.MfaSerial != nil && awsConfig.MfaTokenCommand == nil {
+		return nil, fmt.Errorf("MFA serial found in connection config, missing: mfa_token_command")
+	}
 
-	if awsConfig.Regions != nil {
-		regions = awsConfig.Regions
-		region = regions[0]
-	} else {
-		session, err := session.NewSessionWithOptions(session.Options{
-			SharedConfigState: session.SharedConfigEnable,
-		})
-		if err != nil {
-			panic(err)
-		}
-		if session != nil && session.Config != nil {
-			region = *session.Config.Region
-		}
+	roleArns := append(append([]string{}, awsConfig.AssumeRoleChain...), *awsConfig.AssumeRoleArn)
 
-		if region != "" {
-			regions = []string{region}
+	var creds *credentials.Credentials
+	for i, roleArn := range roleArns {
+		hopSession := baseSession
+		if creds != nil {
+			hopSession = baseSession.Copy(&aws.Config{Credentials: creds})
 		}
-	}
+		isFirstHop, isLastHop := i == 0, i == len(roleArns)-1
 
-	invalidPatterns := []string{}
-	for _, namePattern := range regions {
-		validRegions := []string{}
-		for _, validRegion := range allAwsRegions {
-			if ok, _ := path.Match(namePattern, validRegion); ok {
-				validRegions = append(validRegions, validRegion)
+		creds = stscreds.NewCredentials(hopSession, roleArn, func(p *stscreds.AssumeRoleProvider) {
+			if awsConfig.RoleSessionName != nil {
+				p.RoleSessionName = *awsConfig.RoleSessionName
 			}
-		}
-		if len(validRegions) == 0 {
-			invalidPatterns = append(invalidPatterns, namePattern)
-		}
+			if awsConfig.DurationSeconds != nil {
+				p.Duration = time.Duration(*awsConfig.DurationSeconds) * time.Second
+			}
+			// External ID is required by the role we finally land in, usually owned by a third party
+			if isLastHop && awsConfig.ExternalId != nil {
+				p.ExternalID = awsConfig.ExternalId
+			}
+			// MFA belongs to the base identity, so it can only be presented on the first hop
+			if isFirstHop && awsConfig.MfaSerial != nil {
+				p.SerialNumber = awsConfig.MfaSerial
+				p.TokenProvider = mfaTokenCommandProvider(*awsConfig.MfaTokenCommand)
+			}
+		})
 	}
 
-	if len(invalidPatterns) > 0 {
-		panic("\nconnection config have invalid \"regions\": " + strings.Join(invalidPatterns, ", ") + ". Edit your connection configuration file and then restart Steampipe")
+	// Retrieve the credentials now, so a bad role fails once with a clear error
+	// instead of inside every hydrate call
+	if _, err := creds.GetWithContext(ctx); err != nil {
+		plugin.Logger(ctx).Error("getAssumeRoleCredentials", "assume_role_error", err)
+		return nil, err
 	}
 
-	// most of the global services (like IAM, s3, Route53, etc..) in both cloud are targeting the respective regions
-	if strings.HasPrefix(region, "us-gov") && !helpers.StringSliceContains(allAwsRegions, region) {
-		region = "us-gov-west-1"
-	} else if strings.HasPrefix(region, "cn") && !helpers.StringSliceContains(allAwsRegions, region) {
-		region = "cn-northwest-1"
-	} else if !helpers.StringSliceContains(allAwsRegions, region) {
-		region = "us-east-1"
+	d.ConnectionManager.Cache.Set(cacheKey, creds)
+	return creds, nil
+}
+
+// mfaTokenCommandProvider returns an MFA token provider which runs the given command
+// and uses its output as the token code. The plugin has no terminal attached, so the
+// token can't be read from stdin.
+func mfaTokenCommandProvider(command string) func() (string, error) {
+	return func() (string, error) {
+		output, err := exec.Command("sh", "-c", command).Output()
+		if err != nil {
+			return "", fmt.Errorf("mfa_token_command failed: %v", err)
+		}
+		return strings.TrimSpace(string(output)), nil
 	}
+}
+
+// GetDefaultAwsRegion returns the default region for AWS partiton
+// if not set by Env variable or in aws profile
+func GetDefaultAwsRegion(d *plugin.QueryData) string {
+	// have we already created and cached the service?
+	serviceCacheKey := connectionCacheKey(d, "GetDefaultAwsRegion")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(serviceCacheKey); ok {
+		return cachedData.(string)
+	}
+
+	// get aws config info, the regions have been checked by validateConfig,
+	// and failing to load the SDK config is reported there too
+	regions, _ := getConfigRegions(GetConfig(d.Connection))
+	region := getDefaultRegionFromList(regions)
 
 	d.ConnectionManager.Cache.Set(serviceCacheKey, region)
 	return region
 }
 
+const (
+	// default minimum delay before the first retry, which grows by 3^n with each retry
+	defaultMinRetryDelay = 25 * time.Millisecond
+	// default cap for a single retry delay
+	defaultMaxRetryDelay = client.DefaultRetryerMaxRetryDelay
+	// throttled requests start backing off from this multiple of the minimum retry delay
+	throttleRetryDelayMultiplier = 4
+)
+
+// throttling error codes which are not already recognised by request.IsErrorThrottle
+var throttlingErrorCodes = []string{"SlowDown", "BandwidthLimitExceeded"}
+
 // Function from https://github.com/panther-labs/panther/blob/v1.16.0/pkg/awsretry/connection_retryer.go
-func NewConnectionErrRetryer(maxRetries int, ctx context.Context) *ConnectionErrRetryer {
-	var minRetryDelay time.Duration = 25 * time.Millisecond
+func NewConnectionErrRetryer(maxRetries int, minRetryDelay time.Duration, maxRetryDelay time.Duration) *ConnectionErrRetryer {
 	rand.Seed(time.Now().UnixNano()) // reseting state of rand to generate different random values
 	return &ConnectionErrRetryer{
-		ctx: ctx,
 		DefaultRetryer: client.DefaultRetryer{
-			NumMaxRetries: maxRetries,    // MUST be set or all retrying is skipped!
-			MinRetryDelay: minRetryDelay, // Set default minimum retry delay to 25ms
+			NumMaxRetries:    maxRetries, // MUST be set or all retrying is skipped!
+			MinRetryDelay:    minRetryDelay,
+			MaxRetryDelay:    maxRetryDelay,
+			MinThrottleDelay: minRetryDelay * throttleRetryDelayMultiplier,
+			MaxThrottleDelay: maxRetryDelay,
 		},
 	}
 }
 
 // ConnectionErrRetryer wraps the SDK's built in DefaultRetryer adding customization
-// to retry `connection reset by peer` errors.
+// to retry `connection reset by peer` errors, and to back off harder on throttling errors.
 // Note: This retryer should be used for either idempotent operations or for operations
 // where performing duplicate requests to AWS is acceptable.
 // See also: https://github.com/aws/aws-sdk-go/issues/3027#issuecomment-567269161
 type ConnectionErrRetryer struct {
 	client.DefaultRetryer
-	ctx context.Context
 }
 
 func (r ConnectionErrRetryer) ShouldRetry(req *request.Request) bool {
+	// Requests run with the query context, see withQueryContext, so they are not
+	// retried once the query has been cancelled
+	if req.Context().Err() != nil {
+		return false
+	}
+
 	if req.Error != nil {
 		if strings.Contains(req.Error.Error(), "connection reset by peer") {
 			return true
 		}
 
+		if isThrottlingError(req.Error) {
+			return true
+		}
+
 		var awsErr awserr.Error
 		if errors.As(req.Error, &awsErr) {
 			/*
@@ -1868,6 +2086,13 @@ func (r ConnectionErrRetryer) ShouldRetry(req *request.Request) bool {
 func (d ConnectionErrRetryer) RetryRules(r *request.Request) time.Duration {
 	retryCount := r.RetryCount
 	minDelay := d.MinRetryDelay
+	maxDelay := d.MaxRetryDelay
+
+	// Retrying throttled requests quickly only adds to the load on the API, so back off harder
+	if isThrottlingError(r.Error) {
+		minDelay = d.MinThrottleDelay
+		maxDelay = d.MaxThrottleDelay
+	}
 
 	// If errors are caused by load, retries can be ineffective if all API request retry at the same time.
 	// To avoid this problem added a jitter of "+/-20%" with delay time.
@@ -1877,5 +2102,23 @@ func (d ConnectionErrRetryer) RetryRules(r *request.Request) time.Duration {
 	// Creates a new exponential backoff using the starting value of
 	// minDelay and (minDelay * 3^retrycount) * jitter on each failure
 	// as example (23.25ms, 63ms, 238.5ms, 607.4ms, 2s, 5.22s, 20.31s...) up to max.
-	return time.Duration(int(float64(int(minDelay.Nanoseconds())*int(math.Pow(3, float64(retryCount)))) * jitter))
+	delay := time.Duration(float64(minDelay.Nanoseconds()) * math.Pow(3, float64(retryCount)) * jitter)
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func isThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if request.IsErrorThrottle(err) {
+		return true
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return helpers.StringSliceContains(throttlingErrorCodes, awsErr.Code())
+	}
+	return false
 }
@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// serviceClients holds the clients registered for services, by the name of their SDK package,
+// e.g. ec2 or s3. The service constructors return a registered client instead of creating one
+// with a session, so tests can run the hydrate functions against fake implementations of the
+// SDK interfaces.
+var serviceClients = struct {
+	sync.RWMutex
+	byService map[string]interface{}
+}{byService: map[string]interface{}{}}
+
+// registerServiceClient registers the client the constructor of a service returns for all
+// connections and regions, and returns a function which unregisters it, e.g.
+//
+//	defer registerServiceClient("ec2", &fakeEC2{})()
+//
+// The client must implement the SDK interface of the service, e.g. ec2iface.EC2API.
+func registerServiceClient(service string, client interface{}) func() {
+	serviceClients.Lock()
+	defer serviceClients.Unlock()
+	serviceClients.byService[service] = client
+
+	return func() {
+		serviceClients.Lock()
+		defer serviceClients.Unlock()
+		delete(serviceClients.byService, service)
+	}
+}
+
+// getCachedServiceClient returns the client registered for a service, or the client
+// cached under a key by its constructor
+func getCachedServiceClient(d *plugin.QueryData, service string, cacheKey string) (interface{}, bool) {
+	serviceClients.RLock()
+	client, ok := serviceClients.byService[service]
+	serviceClients.RUnlock()
+	if ok {
+		return client, true
+	}
+
+	return d.ConnectionManager.Cache.Get(cacheKey)
+}
+
+// withQueryContext returns a copy of a cached service client whose requests run with the context
+// of the query, unless they are made with a context of their own (the *WithContext API variants).
+// The requests of a cancelled query are then aborted rather than retried, and their API calls are
+// counted for the table being queried, see withApiCallTable. The copy shares the session,
+// configuration and handlers of the cached client, and costs a copy of its handler lists.
+// Registered clients, e.g. fakes, are returned as they are.
+func withQueryContext(ctx context.Context, d *plugin.QueryData, svc interface{}) interface{} {
+	// the SDK service clients are pointers to a struct embedding *client.Client, e.g. *ec2.EC2
+	value := reflect.ValueOf(svc)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return svc
+	}
+	field := value.Elem().FieldByName("Client")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&client.Client{}) || field.IsNil() {
+		return svc
+	}
+
+	queryClient := *field.Interface().(*client.Client)
+	queryClient.Handlers = queryClient.Handlers.Copy()
+	queryClient.Handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: "steampipe.QueryContextHandler",
+		Fn: func(r *request.Request) {
+			requestCtx := r.Context()
+			if requestCtx == aws.BackgroundContext() {
+				requestCtx = ctx
+			}
+			r.SetContext(withApiCallTable(requestCtx, d))
+		},
+	})
+
+	copied := reflect.New(value.Elem().Type())
+	copied.Elem().Set(value.Elem())
+	copied.Elem().FieldByName("Client").Set(reflect.ValueOf(&queryClient))
+	return copied.Interface()
+}
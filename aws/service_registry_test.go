@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/awstesting/unit"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestWithQueryContext(t *testing.T) {
+	// a client which fails each attempt with a retryable error, and cancels the query after the
+	// first one
+	newFailingClient := func(attempts *int, cancel context.CancelFunc) *sts.STS {
+		config := request.WithRetryer(aws.NewConfig().WithMaxRetries(3), NewConnectionErrRetryer(3, time.Millisecond, time.Millisecond))
+		svc := sts.New(unit.Session, config)
+		svc.Handlers.Send.Clear()
+		svc.Handlers.Send.PushBack(func(r *request.Request) {
+			*attempts++
+			cancel()
+			r.HTTPResponse = &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+			r.Error = awserr.New("ServiceUnavailable", "Service is unavailable.", nil)
+		})
+		return svc
+	}
+
+	d := &plugin.QueryData{}
+
+	for _, testCase := range []struct {
+		name string
+		// whether the client is bound to the query context
+		bound    bool
+		expected int
+	}{
+		{"client without the query context", false, 4},
+		{"client with the query context", true, 1},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(newTestContext())
+			defer cancel()
+
+			attempts := 0
+			var svc stsiface.STSAPI = newFailingClient(&attempts, cancel)
+			if testCase.bound {
+				svc = withQueryContext(ctx, d, svc).(stsiface.STSAPI)
+			}
+			if _, err := svc.GetCallerIdentity(&sts.GetCallerIdentityInput{}); err == nil {
+				t.Fatal("expected an error")
+			}
+			if attempts != testCase.expected {
+				t.Errorf("expected %d attempts, got %d", testCase.expected, attempts)
+			}
+		})
+	}
+
+	t.Run("registered client", func(t *testing.T) {
+		fake := &fakeSTS{}
+		if svc := withQueryContext(newTestContext(), d, fake); svc != fake {
+			t.Errorf("expected the registered client, got %v", svc)
+		}
+	})
+}
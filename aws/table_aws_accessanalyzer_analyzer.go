@@ -111,7 +111,9 @@ func listAccessAnalyzers(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		&accessanalyzer.ListAnalyzersInput{},
 		func(page *accessanalyzer.ListAnalyzersOutput, isLast bool) bool {
 			for _, analyzer := range page.Analyzers {
-				d.StreamListItem(ctx, analyzer)
+				if !streamListItem(ctx, d, analyzer) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -114,7 +114,7 @@ func listAccountAlias(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, err
 	}
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
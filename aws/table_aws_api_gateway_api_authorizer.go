@@ -163,7 +163,7 @@ func getAPIGatewayAuthorizerAkas(ctx context.Context, d *plugin.QueryData, h *pl
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	apiAuthorizer := h.Item.(*authorizerRowData)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -115,7 +115,9 @@ func listAPIKeys(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		&apigateway.GetApiKeysInput{},
 		func(page *apigateway.GetApiKeysOutput, lastPage bool) bool {
 			for _, items := range page.Items {
-				d.StreamListItem(ctx, items)
+				if !streamListItem(ctx, d, items) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -152,7 +154,7 @@ func getAwsAPIKeysAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	item := h.Item.(*apigateway.ApiKey)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
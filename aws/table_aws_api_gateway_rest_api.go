@@ -144,7 +144,9 @@ func listRestAPI(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		&apigateway.GetRestApisInput{},
 		func(page *apigateway.GetRestApisOutput, lastPage bool) bool {
 			for _, items := range page.Items {
-				d.StreamListItem(ctx, items)
+				if !streamListItem(ctx, d, items) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -181,7 +183,7 @@ func getAwsRestAPITurbotData(ctx context.Context, d *plugin.QueryData, h *plugin
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	item := h.Item.(*apigateway.RestApi)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
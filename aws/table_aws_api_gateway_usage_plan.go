@@ -96,7 +96,9 @@ func listUsagePlans(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		&apigateway.GetUsagePlansInput{},
 		func(page *apigateway.GetUsagePlansOutput, lastPage bool) bool {
 			for _, plan := range page.Items {
-				d.StreamListItem(ctx, plan)
+				if !streamListItem(ctx, d, plan) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -135,7 +137,7 @@ func getUsagePlanAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	usagePlan := h.Item.(*apigateway.UsagePlan)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
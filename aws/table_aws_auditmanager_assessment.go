@@ -24,7 +24,7 @@ func tableAwsAuditManagerAssessment(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listAwsAuditManagerAssessments,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(auditmanager.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -162,7 +162,9 @@ func listAwsAuditManagerAssessments(ctx context.Context, d *plugin.QueryData, _
 		&auditmanager.ListAssessmentsInput{},
 		func(page *auditmanager.ListAssessmentsOutput, isLast bool) bool {
 			for _, assessment := range page.AssessmentMetadata {
-				d.StreamListItem(ctx, assessment)
+				if !streamListItem(ctx, d, assessment) {
+					return false
+				}
 			}
 			return !isLast
 		},
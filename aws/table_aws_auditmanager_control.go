@@ -24,7 +24,7 @@ func tableAwsAuditManagerControl(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listAuditManagerControls,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(auditmanager.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -147,7 +147,9 @@ func listAuditManagerControls(ctx context.Context, d *plugin.QueryData, _ *plugi
 		},
 		func(page *auditmanager.ListControlsOutput, lastPage bool) bool {
 			for _, items := range page.ControlMetadataList {
-				d.StreamListItem(ctx, items)
+				if !streamListItem(ctx, d, items) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -164,7 +166,9 @@ func listAuditManagerControls(ctx context.Context, d *plugin.QueryData, _ *plugi
 		},
 		func(page *auditmanager.ListControlsOutput, lastPage bool) bool {
 			for _, items := range page.ControlMetadataList {
-				d.StreamListItem(ctx, items)
+				if !streamListItem(ctx, d, items) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -25,7 +25,7 @@ func tableAwsAuditManagerEvidenceFolder(_ context.Context) *plugin.Table {
 			ParentHydrate: listAwsAuditManagerAssessments,
 			Hydrate:       listAuditManagerEvidenceFolders,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(auditmanager.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -163,7 +163,9 @@ func listAuditManagerEvidenceFolders(ctx context.Context, d *plugin.QueryData, h
 		&auditmanager.GetEvidenceFoldersByAssessmentInput{AssessmentId: &assessmentID},
 		func(page *auditmanager.GetEvidenceFoldersByAssessmentOutput, isLast bool) bool {
 			for _, folder := range page.EvidenceFolders {
-				d.StreamListItem(ctx, folder)
+				if !streamListItem(ctx, d, folder) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -210,7 +212,7 @@ func getAuditManagerEvidenceFolderARN(ctx context.Context, d *plugin.QueryData,
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	evidenceFolderID := *h.Item.(*auditmanager.AssessmentEvidenceFolder).Id
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -25,7 +25,7 @@ func tableAwsAuditManagerFramework(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listAuditManagerFrameworks,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(auditmanager.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -146,7 +146,9 @@ func listAuditManagerFrameworks(ctx context.Context, d *plugin.QueryData, _ *plu
 		&auditmanager.ListAssessmentFrameworksInput{FrameworkType: aws.String("Standard")},
 		func(page *auditmanager.ListAssessmentFrameworksOutput, lastPage bool) bool {
 			for _, framework := range page.FrameworkMetadataList {
-				d.StreamListItem(ctx, framework)
+				if !streamListItem(ctx, d, framework) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -161,7 +163,9 @@ func listAuditManagerFrameworks(ctx context.Context, d *plugin.QueryData, _ *plu
 		&auditmanager.ListAssessmentFrameworksInput{FrameworkType: aws.String("Custom")},
 		func(page *auditmanager.ListAssessmentFrameworksOutput, lastPage bool) bool {
 			for _, framework := range page.FrameworkMetadataList {
-				d.StreamListItem(ctx, framework)
+				if !streamListItem(ctx, d, framework) {
+					return false
+				}
 			}
 			return !lastPage
 		},
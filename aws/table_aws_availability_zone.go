@@ -166,7 +166,7 @@ func getAwsAvailabilityZoneAkas(ctx context.Context, d *plugin.QueryData, h *plu
 	plugin.Logger(ctx).Trace("getAwsAvailabilityZoneAkas")
 	zone := h.Item.(*ec2.AvailabilityZone)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -113,7 +113,9 @@ func listAwsBackupPlans(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		&backup.ListBackupPlansInput{IncludeDeleted: &includeDeleted},
 		func(page *backup.ListBackupPlansOutput, lastPage bool) bool {
 			for _, plan := range page.BackupPlansList {
-				d.StreamListItem(ctx, plan)
+				if !streamListItem(ctx, d, plan) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -69,7 +69,9 @@ func listAwsBackupProtectedResources(ctx context.Context, d *plugin.QueryData, _
 		&backup.ListProtectedResourcesInput{},
 		func(page *backup.ListProtectedResourcesOutput, lastPage bool) bool {
 			for _, resource := range page.Results {
-				d.StreamListItem(ctx, resource)
+				if !streamListItem(ctx, d, resource) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -152,7 +152,9 @@ func listAwsBackupRecoveryPoints(ctx context.Context, d *plugin.QueryData, h *pl
 		&backup.ListRecoveryPointsByBackupVaultInput{BackupVaultName: vault.BackupVaultName},
 		func(page *backup.ListRecoveryPointsByBackupVaultOutput, lastPage bool) bool {
 			for _, point := range page.RecoveryPoints {
-				d.StreamListItem(ctx, point)
+				if !streamListItem(ctx, d, point) {
+					return false
+				}
 			}
 			return !lastPage
 		},
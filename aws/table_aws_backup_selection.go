@@ -118,7 +118,9 @@ func listBackupSelections(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		&backup.ListBackupSelectionsInput{BackupPlanId: plan.BackupPlanId},
 		func(page *backup.ListBackupSelectionsOutput, lastPage bool) bool {
 			for _, selection := range page.BackupSelectionsList {
-				d.StreamListItem(ctx, selection)
+				if !streamListItem(ctx, d, selection) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -169,7 +171,7 @@ func getBackupSelectionARN(ctx context.Context, d *plugin.QueryData, h *plugin.H
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	data := selectionID(h.Item)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -116,7 +116,9 @@ func listAwsBackupVaults(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		&backup.ListBackupVaultsInput{},
 		func(page *backup.ListBackupVaultsOutput, lastPage bool) bool {
 			for _, vault := range page.BackupVaultList {
-				d.StreamListItem(ctx, vault)
+				if !streamListItem(ctx, d, vault) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -77,19 +77,10 @@ func listCloudControlResources(ctx context.Context, d *plugin.QueryData, _ *plug
 	typeName := d.KeyColumnQuals["type_name"].GetStringValue()
 	resourceModel := d.KeyColumnQuals["resource_model"].GetStringValue()
 
-	// Set MaxResults to the maximum number allowed
+	// Set MaxResults to the maximum number allowed, or less if the user has only requested a small number of rows
 	input := cloudcontrolapi.ListResourcesInput{
 		TypeName:   types.String(typeName),
-		MaxResults: types.Int64(100),
-	}
-
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxResults {
-			input.MaxResults = limit
-		}
+		MaxResults: listPageSize(d, 1, 100),
 	}
 
 	if len(resourceModel) > 0 {
@@ -102,13 +93,10 @@ func listCloudControlResources(ctx context.Context, d *plugin.QueryData, _ *plug
 				identifier := resource.Identifier
 				properties := resource.Properties
 
-				d.StreamListItem(ctx, &cloudControlResource{
+				if !streamListItem(ctx, d, &cloudControlResource{
 					Identifier: identifier,
 					Properties: properties,
-				})
-
-				// Check if context has been cancelled or if the limit has been hit (if specified)
-				if d.QueryStatus.RowsRemaining(ctx) == 0 {
+				}) {
 					return false
 				}
 			}
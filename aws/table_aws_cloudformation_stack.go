@@ -183,7 +183,9 @@ func listCloudFormationStacks(ctx context.Context, d *plugin.QueryData, _ *plugi
 		&cloudformation.DescribeStacksInput{},
 		func(page *cloudformation.DescribeStacksOutput, lastPage bool) bool {
 			for _, stack := range page.Stacks {
-				d.StreamListItem(ctx, stack)
+				if !streamListItem(ctx, d, stack) {
+					return false
+				}
 			}
 			return !lastPage
 		},
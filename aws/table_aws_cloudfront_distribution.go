@@ -241,7 +241,9 @@ func listAwsCloudFrontDistributions(ctx context.Context, d *plugin.QueryData, _
 		&cloudfront.ListDistributionsInput{},
 		func(page *cloudfront.ListDistributionsOutput, isLast bool) bool {
 			for _, distribution := range page.DistributionList.Items {
-				d.StreamListItem(ctx, distribution)
+				if !streamListItem(ctx, d, distribution) {
+					return false
+				}
 			}
 			return !isLast
 		},
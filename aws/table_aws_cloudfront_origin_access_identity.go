@@ -99,7 +99,9 @@ func listCloudFrontOriginAccessIdentities(ctx context.Context, d *plugin.QueryDa
 		&cloudfront.ListCloudFrontOriginAccessIdentitiesInput{},
 		func(page *cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, isLast bool) bool {
 			for _, identity := range page.CloudFrontOriginAccessIdentityList.Items {
-				d.StreamListItem(ctx, identity)
+				if !streamListItem(ctx, d, identity) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -142,7 +144,7 @@ func getCloudFrontOriginAccessIdentityARN(ctx context.Context, d *plugin.QueryDa
 	plugin.Logger(ctx).Trace("getCloudFrontOriginAccessIdentityARN")
 	originAccessIdentityData := *originAccessIdentityID(h.Item)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
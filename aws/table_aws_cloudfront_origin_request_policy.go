@@ -162,7 +162,7 @@ func getCloudFrontOriginRequestPolicyAkas(ctx context.Context, d *plugin.QueryDa
 	plugin.Logger(ctx).Trace("getCloudFrontOriginRequestPolicyAkas")
 	policyID := *originRequestPolicyID(h.Item)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -209,7 +209,9 @@ func listCloudWatchAlarms(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		&cloudwatch.DescribeAlarmsInput{},
 		func(page *cloudwatch.DescribeAlarmsOutput, isLast bool) bool {
 			for _, alarms := range page.MetricAlarms {
-				d.StreamListItem(ctx, alarms)
+				if !streamListItem(ctx, d, alarms) {
+					return false
+				}
 			}
 			return !isLast
 		},
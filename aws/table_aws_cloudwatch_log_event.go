@@ -70,16 +70,8 @@ func listCloudwatchLogEvents(ctx context.Context, d *plugin.QueryData, _ *plugin
 
 	input := cloudwatchlogs.FilterLogEventsInput{
 		LogGroupName: aws.String(equalQuals["log_group_name"].GetStringValue()),
-		// Default to the maximum allowed
-		Limit: aws.Int64(10000),
-	}
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.Limit {
-			input.Limit = limit
-		}
+		// The maximum allowed, or less if the user has only requested a small number of rows
+		Limit: listPageSize(d, 1, 10000),
 	}
 
 	if equalQuals["log_stream_name"] != nil {
@@ -118,17 +110,13 @@ func listCloudwatchLogEvents(ctx context.Context, d *plugin.QueryData, _ *plugin
 
 	err = svc.FilterLogEventsPages(
 		&input,
-		func(page *cloudwatchlogs.FilterLogEventsOutput, _ bool) bool {
+		func(page *cloudwatchlogs.FilterLogEventsOutput, lastPage bool) bool {
 			for _, logEvent := range page.Events {
-				d.StreamListItem(ctx, logEvent)
-			}
-			// Abort if we've been cancelled, which probably means we've reached the requested limit
-			select {
-			case <-ctx.Done():
-				return false
-			default:
-				return true
+				if !streamListItem(ctx, d, logEvent) {
+					return false
+				}
 			}
+			return !lastPage
 		},
 	)
 
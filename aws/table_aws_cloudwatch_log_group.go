@@ -97,7 +97,9 @@ func listCloudwatchLogGroups(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&cloudwatchlogs.DescribeLogGroupsInput{},
 		func(page *cloudwatchlogs.DescribeLogGroupsOutput, isLast bool) bool {
 			for _, logGroup := range page.LogGroups {
-				d.StreamListItem(ctx, logGroup)
+				if !streamListItem(ctx, d, logGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
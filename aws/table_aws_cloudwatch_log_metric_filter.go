@@ -91,7 +91,9 @@ func listCloudwatchLogMetricFilters(ctx context.Context, d *plugin.QueryData, _
 		&cloudwatchlogs.DescribeMetricFiltersInput{},
 		func(page *cloudwatchlogs.DescribeMetricFiltersOutput, isLast bool) bool {
 			for _, metricFilter := range page.MetricFilters {
-				d.StreamListItem(ctx, metricFilter)
+				if !streamListItem(ctx, d, metricFilter) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -137,7 +139,7 @@ func getCloudwatchLogMetricFilterAkas(ctx context.Context, d *plugin.QueryData,
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	metricFilter := h.Item.(*cloudwatchlogs.MetricFilter)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
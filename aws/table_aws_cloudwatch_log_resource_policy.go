@@ -3,7 +3,6 @@ package aws
 import (
 	"context"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -67,17 +66,9 @@ func listCloudwatchLogResourcePolicies(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
-	// Set MaxItems to the maximum number allowed
+	// Set Limit to the maximum number allowed, or less if the user has only requested a small number of rows
 	input := cloudwatchlogs.DescribeResourcePoliciesInput{
-		Limit: aws.Int64(50),
-	}
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.Limit {
-			input.Limit = limit
-		}
+		Limit: listPageSize(d, 1, 50),
 	}
 
 	for {
@@ -89,7 +80,9 @@ func listCloudwatchLogResourcePolicies(ctx context.Context, d *plugin.QueryData,
 
 		// Stream results
 		for _, policy := range resp.ResourcePolicies {
-			d.StreamListItem(ctx, policy)
+			if !streamListItem(ctx, d, policy) {
+				return nil, nil
+			}
 		}
 
 		if resp.NextToken == nil {
@@ -132,7 +132,9 @@ func listCodepipelinePipelines(ctx context.Context, d *plugin.QueryData, _ *plug
 		&codepipeline.ListPipelinesInput{},
 		func(page *codepipeline.ListPipelinesOutput, isLast bool) bool {
 			for _, result := range page.Pipelines {
-				d.StreamListItem(ctx, result)
+				if !streamListItem(ctx, d, result) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -221,7 +223,7 @@ func pipelineARN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get region, partition, account id
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return ""
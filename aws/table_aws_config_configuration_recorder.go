@@ -167,7 +167,7 @@ func getAwsConfigurationRecorderARN(ctx context.Context, d *plugin.QueryData, h
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	configurationRecorder := h.Item.(*configservice.ConfigurationRecorder)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -125,7 +125,9 @@ func listConfigRules(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		&configservice.DescribeConfigRulesInput{},
 		func(page *configservice.DescribeConfigRulesOutput, lastPage bool) bool {
 			for _, rule := range page.ConfigRules {
-				d.StreamListItem(ctx, rule)
+				if !streamListItem(ctx, d, rule) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -49,15 +49,9 @@ func listCostForecastDaily(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 	logger := plugin.Logger(ctx)
 	logger.Trace("listCostForecast")
 
-	// Create session
-	svc, err := CostExplorerService(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
 	params := buildCostForecastInput(d.KeyColumnQuals, "DAILY")
 
-	output, err := svc.GetCostForecast(params)
+	output, err := getCostForecast(ctx, d, params)
 	if err != nil {
 		logger.Error("listCostForecast", "err", err)
 		return nil, err
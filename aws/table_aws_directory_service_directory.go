@@ -259,7 +259,7 @@ func getDirectoryARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	plugin.Logger(ctx).Trace("getDirectoryARN")
 	directory := h.Item.(*directoryservice.DirectoryDescription)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -193,7 +193,9 @@ func listDmsReplicationInstances(ctx context.Context, d *plugin.QueryData, _ *pl
 		params,
 		func(page *databasemigrationservice.DescribeReplicationInstancesOutput, isLast bool) bool {
 			for _, replicationInstance := range page.ReplicationInstances {
-				d.StreamListItem(ctx, replicationInstance)
+				if !streamListItem(ctx, d, replicationInstance) {
+					return false
+				}
 			}
 			return !isLast
 		},
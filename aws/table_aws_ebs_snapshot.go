@@ -21,7 +21,8 @@ func tableAwsEBSSnapshot(_ context.Context) *plugin.Table {
 			Hydrate:           getAwsEBSSnapshot,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listAwsEBSSnapshots,
+			Hydrate:    listAwsEBSSnapshots,
+			KeyColumns: ec2FilterKeyColumns(ebsSnapshotFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -134,6 +135,18 @@ func tableAwsEBSSnapshot(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeSnapshots
+var ebsSnapshotFilterColumns = []ec2FilterColumn{
+	{Column: "description", Filter: "description", Type: proto.ColumnType_STRING},
+	{Column: "encrypted", Filter: "encrypted", Type: proto.ColumnType_BOOL},
+	{Column: "owner_alias", Filter: "owner-alias", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "status", Type: proto.ColumnType_STRING},
+	{Column: "volume_id", Filter: "volume-id", Type: proto.ColumnType_STRING},
+	{Column: "volume_size", Filter: "volume-size", Type: proto.ColumnType_INT},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listAwsEBSSnapshots(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -146,15 +159,23 @@ func listAwsEBSSnapshots(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		return nil, err
 	}
 
+	input := &ec2.DescribeSnapshotsInput{
+		OwnerIds:   []*string{aws.String("self")},
+		MaxResults: listPageSize(d, 5, 1000),
+	}
+	filters := buildEc2Filters(d.KeyColumnQuals, ebsSnapshotFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeSnapshotsPages(
-		&ec2.DescribeSnapshotsInput{
-			OwnerIds: []*string{aws.String("self")},
-		},
+		input,
 		func(page *ec2.DescribeSnapshotsOutput, isLast bool) bool {
 			for _, snapshot := range page.Snapshots {
-				d.StreamListItem(ctx, snapshot)
-
+				if !streamListItem(ctx, d, snapshot) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -225,7 +246,7 @@ func getEBSSnapshotARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	plugin.Logger(ctx).Trace("getEBSSnapshotARN")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	snapshotData := h.Item.(*ec2.Snapshot)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
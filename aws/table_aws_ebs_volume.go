@@ -22,7 +22,8 @@ func tableAwsEBSVolume(_ context.Context) *plugin.Table {
 			Hydrate:           getEBSVolume,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEBSVolume,
+			Hydrate:    listEBSVolume,
+			KeyColumns: ec2FilterKeyColumns(ebsVolumeFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -147,6 +148,19 @@ func tableAwsEBSVolume(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVolumes
+var ebsVolumeFilterColumns = []ec2FilterColumn{
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "encrypted", Filter: "encrypted", Type: proto.ColumnType_BOOL},
+	{Column: "fast_restored", Filter: "fast-restored", Type: proto.ColumnType_BOOL},
+	{Column: "multi_attach_enabled", Filter: "multi-attach-enabled", Type: proto.ColumnType_BOOL},
+	{Column: "size", Filter: "size", Type: proto.ColumnType_INT},
+	{Column: "snapshot_id", Filter: "snapshot-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "status", Type: proto.ColumnType_STRING},
+	{Column: "volume_type", Filter: "volume-type", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEBSVolume(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -159,12 +173,20 @@ func listEBSVolume(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		return nil, err
 	}
 
+	input := &ec2.DescribeVolumesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ebsVolumeFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeVolumesPages(
-		&ec2.DescribeVolumesInput{},
+		input,
 		func(page *ec2.DescribeVolumesOutput, isLast bool) bool {
 			for _, volume := range page.Volumes {
-				d.StreamListItem(ctx, volume)
+				if !streamListItem(ctx, d, volume) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -263,7 +285,7 @@ func getEBSVolumeARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	volume := h.Item.(*ec2.Volume)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -23,7 +23,8 @@ func tableAwsEc2Ami(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2Ami,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2Amis,
+			Hydrate:    listEc2Amis,
+			KeyColumns: ec2FilterKeyColumns(ec2AmiFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -185,6 +186,28 @@ func tableAwsEc2Ami(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeImages
+var ec2AmiFilterColumns = []ec2FilterColumn{
+	{Column: "name", Filter: "name", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "image_type", Filter: "image-type", Type: proto.ColumnType_STRING},
+	{Column: "image_location", Filter: "manifest-location", Type: proto.ColumnType_STRING},
+	{Column: "architecture", Filter: "architecture", Type: proto.ColumnType_STRING},
+	{Column: "description", Filter: "description", Type: proto.ColumnType_STRING},
+	{Column: "ena_support", Filter: "ena-support", Type: proto.ColumnType_BOOL},
+	{Column: "hypervisor", Filter: "hypervisor", Type: proto.ColumnType_STRING},
+	{Column: "image_owner_alias", Filter: "owner-alias", Type: proto.ColumnType_STRING},
+	{Column: "kernel_id", Filter: "kernel-id", Type: proto.ColumnType_STRING},
+	{Column: "platform", Filter: "platform", Type: proto.ColumnType_STRING},
+	{Column: "public", Filter: "is-public", Type: proto.ColumnType_BOOL},
+	{Column: "ramdisk_id", Filter: "ramdisk-id", Type: proto.ColumnType_STRING},
+	{Column: "root_device_name", Filter: "root-device-name", Type: proto.ColumnType_STRING},
+	{Column: "root_device_type", Filter: "root-device-type", Type: proto.ColumnType_STRING},
+	{Column: "sriov_net_support", Filter: "sriov-net-support", Type: proto.ColumnType_STRING},
+	{Column: "virtualization_type", Filter: "virtualization-type", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEc2Amis(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -197,13 +220,25 @@ func listEc2Amis(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		return nil, err
 	}
 
-	resp, err := svc.DescribeImages(&ec2.DescribeImagesInput{
+	input := &ec2.DescribeImagesInput{
 		Owners: []*string{aws.String("self")},
-	})
+	}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2AmiFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
+	// DescribeImages returns all the images in one response, so only the streaming stops at the limit
+	resp, err := svc.DescribeImages(input)
+	if err != nil {
+		return nil, err
+	}
 	for _, image := range resp.Images {
-		d.StreamListItem(ctx, image)
+		if !streamListItem(ctx, d, image) {
+			break
+		}
 	}
-	return nil, err
+	return nil, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -261,7 +296,7 @@ func getAwsEc2AmiAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	plugin.Logger(ctx).Trace("getAwsEc2AmiAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	image := h.Item.(*ec2.Image)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
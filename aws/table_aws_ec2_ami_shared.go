@@ -24,7 +24,7 @@ func tableAwsEc2AmiShared(_ context.Context) *plugin.Table {
 		},
 		List: &plugin.ListConfig{
 			Hydrate:           listAmisByOwner,
-			KeyColumns:        plugin.SingleColumn("owner_id"),
+			KeyColumns:        append(plugin.SingleColumn("owner_id"), ec2FilterKeyColumns(ec2AmiFilterColumns)...),
 			ShouldIgnoreError: isNotFoundError([]string{"InvalidAMIID.NotFound", "InvalidAMIID.Unavailable", "InvalidAMIID.Malformed"}),
 		},
 		GetMatrixItem: BuildRegionList,
@@ -194,9 +194,16 @@ func listAmisByOwner(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	resp, err := svc.DescribeImages(&ec2.DescribeImagesInput{
+	input := &ec2.DescribeImagesInput{
 		Owners: []*string{aws.String(owner_id)},
-	})
+	}
+	// same filters as aws_ec2_ami
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2AmiFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
+	resp, err := svc.DescribeImages(input)
 	for _, image := range resp.Images {
 		d.StreamListItem(ctx, image)
 	}
@@ -272,7 +272,9 @@ func listAwsEc2AutoscalingGroup(ctx context.Context, d *plugin.QueryData, _ *plu
 		&autoscaling.DescribeAutoScalingGroupsInput{},
 		func(page *autoscaling.DescribeAutoScalingGroupsOutput, isLast bool) bool {
 			for _, autoscalingGroup := range page.AutoScalingGroups {
-				d.StreamListItem(ctx, autoscalingGroup)
+				if !streamListItem(ctx, d, autoscalingGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -23,7 +23,8 @@ func tableAwsEc2CapacityReservation(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2CapacityReservation,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2CapacityReservations,
+			Hydrate:    listEc2CapacityReservations,
+			KeyColumns: ec2FilterKeyColumns(ec2CapacityReservationFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -147,6 +148,19 @@ func tableAwsEc2CapacityReservation(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeCapacityReservations
+var ec2CapacityReservationFilterColumns = []ec2FilterColumn{
+	{Column: "instance_type", Filter: "instance-type", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "availability_zone_id", Filter: "availability-zone-id", Type: proto.ColumnType_STRING},
+	{Column: "instance_platform", Filter: "instance-platform", Type: proto.ColumnType_STRING},
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "tenancy", Filter: "tenancy", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "end_date_type", Filter: "end-date-type", Type: proto.ColumnType_STRING},
+	{Column: "instance_match_criteria", Filter: "instance-match-criteria", Type: proto.ColumnType_STRING},
+}
+
 //// LIST FUNCTION
 
 func listEc2CapacityReservations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -159,12 +173,20 @@ func listEc2CapacityReservations(ctx context.Context, d *plugin.QueryData, _ *pl
 		return nil, err
 	}
 
+	input := &ec2.DescribeCapacityReservationsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2CapacityReservationFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeCapacityReservationsPages(
-		&ec2.DescribeCapacityReservationsInput{},
+		input,
 		func(page *ec2.DescribeCapacityReservationsOutput, isLast bool) bool {
 			for _, reservation := range page.CapacityReservations {
-				d.StreamListItem(ctx, reservation)
+				if !streamListItem(ctx, d, reservation) {
+					return false
+				}
 			}
 			return !isLast
 		},
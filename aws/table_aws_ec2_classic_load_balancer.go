@@ -273,7 +273,9 @@ func listEc2ClassicLoadBalancers(ctx context.Context, d *plugin.QueryData, _ *pl
 		&elb.DescribeLoadBalancersInput{},
 		func(page *elb.DescribeLoadBalancersOutput, isLast bool) bool {
 			for _, classicLoadBalancer := range page.LoadBalancerDescriptions {
-				d.StreamListItem(ctx, classicLoadBalancer)
+				if !streamListItem(ctx, d, classicLoadBalancer) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -362,7 +364,7 @@ func getEc2ClassicLoadBalancerARN(ctx context.Context, d *plugin.QueryData, h *p
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	classicLoadBalancer := h.Item.(*elb.LoadBalancerDescription)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
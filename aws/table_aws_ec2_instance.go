@@ -27,27 +27,8 @@ func tableAwsEc2Instance(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2Instance,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2Instance,
-			KeyColumns: []*plugin.KeyColumn{
-				{Name: "hypervisor", Require: plugin.Optional},
-				{Name: "iam_instance_profile_arn", Require: plugin.Optional},
-				{Name: "image_id", Require: plugin.Optional},
-				{Name: "instance_lifecycle", Require: plugin.Optional},
-				{Name: "instance_state", Require: plugin.Optional},
-				{Name: "instance_type", Require: plugin.Optional},
-				{Name: "monitoring_state", Require: plugin.Optional},
-				{Name: "outpost_arn", Require: plugin.Optional},
-				{Name: "placement_availability_zone", Require: plugin.Optional},
-				{Name: "placement_group_name", Require: plugin.Optional},
-				{Name: "public_dns_name", Require: plugin.Optional},
-				{Name: "ram_disk_id", Require: plugin.Optional},
-				{Name: "root_device_name", Require: plugin.Optional},
-				{Name: "root_device_type", Require: plugin.Optional},
-				{Name: "subnet_id", Require: plugin.Optional},
-				{Name: "placement_tenancy", Require: plugin.Optional},
-				{Name: "virtualization_type", Require: plugin.Optional},
-				{Name: "vpc_id", Require: plugin.Optional},
-			},
+			Hydrate:    listEc2Instance,
+			KeyColumns: ec2FilterKeyColumns(ec2InstanceFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -335,6 +316,29 @@ func tableAwsEc2Instance(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeInstances
+var ec2InstanceFilterColumns = []ec2FilterColumn{
+	{Column: "hypervisor", Filter: "hypervisor", Type: proto.ColumnType_STRING},
+	{Column: "iam_instance_profile_arn", Filter: "iam-instance-profile.arn", Type: proto.ColumnType_STRING},
+	{Column: "image_id", Filter: "image-id", Type: proto.ColumnType_STRING},
+	{Column: "instance_lifecycle", Filter: "instance-lifecycle", Type: proto.ColumnType_STRING},
+	{Column: "instance_state", Filter: "instance-state-name", Type: proto.ColumnType_STRING},
+	{Column: "instance_type", Filter: "instance-type", Type: proto.ColumnType_STRING},
+	{Column: "monitoring_state", Filter: "monitoring-state", Type: proto.ColumnType_STRING},
+	{Column: "outpost_arn", Filter: "outpost-arn", Type: proto.ColumnType_STRING},
+	{Column: "placement_availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "placement_group_name", Filter: "placement-group-name", Type: proto.ColumnType_STRING},
+	{Column: "public_dns_name", Filter: "dns-name", Type: proto.ColumnType_STRING},
+	{Column: "ram_disk_id", Filter: "ramdisk-id", Type: proto.ColumnType_STRING},
+	{Column: "root_device_name", Filter: "root-device-name", Type: proto.ColumnType_STRING},
+	{Column: "root_device_type", Filter: "root-device-type", Type: proto.ColumnType_STRING},
+	{Column: "subnet_id", Filter: "subnet-id", Type: proto.ColumnType_STRING},
+	{Column: "placement_tenancy", Filter: "tenancy", Type: proto.ColumnType_STRING},
+	{Column: "virtualization_type", Filter: "virtualization-type", Type: proto.ColumnType_STRING},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEc2Instance(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -348,7 +352,10 @@ func listEc2Instance(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 
 	input := ec2.DescribeInstancesInput{
-		MaxResults: types.Int64(1000),
+		// select * from aws_ec2_instance limit 1
+		// Error: InvalidParameterValue: Value ( 1 ) for parameter maxResults is invalid. Expecting a value greater than 5.
+		// 		status code: 400, request id: a84912d9-f5fd-403f-8e37-7f7b3f6faba6
+		MaxResults: listPageSize(d, 5, 1000),
 	}
 	filters := buildEc2InstanceFilter(d.KeyColumnQuals)
 
@@ -356,31 +363,12 @@ func listEc2Instance(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		input.Filters = filters
 	}
 
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxResults {
-			// select * from aws_ec2_instance limit 1
-			// Error: InvalidParameterValue: Value ( 1 ) for parameter maxResults is invalid. Expecting a value greater than 5.
-			// 		status code: 400, request id: a84912d9-f5fd-403f-8e37-7f7b3f6faba6
-			if *limit < 5 {
-				input.MaxResults = types.Int64(5)
-			} else {
-				input.MaxResults = limit
-			}
-		}
-	}
-
 	// List call
 	err = svc.DescribeInstancesPages(&input, func(page *ec2.DescribeInstancesOutput, isLast bool) bool {
 		if page.Reservations != nil && len(page.Reservations) > 0 {
 			for _, reservation := range page.Reservations {
 				for _, instance := range reservation.Instances {
-					d.StreamListItem(ctx, instance)
-					// Check if context has been cancelled or if the limit has been hit (if specified)
-					// if there is a limit, it will return the number of rows required to reach this limit
-					if d.QueryStatus.RowsRemaining(ctx) == 0 {
+					if !streamListItem(ctx, d, instance) {
 						return false
 					}
 				}
@@ -435,7 +423,7 @@ func getEc2InstanceARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	instance := h.Item.(*ec2.Instance)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		plugin.Logger(ctx).Error("getEc2InstanceARN", "getCommonColumnsCached_error", err)
@@ -664,44 +652,7 @@ func ec2InstanceStateChangeTime(_ context.Context, d *transform.TransformData) (
 
 // build ec2 instance list call input filter
 func buildEc2InstanceFilter(equalQuals plugin.KeyColumnEqualsQualMap) []*ec2.Filter {
-	filters := make([]*ec2.Filter, 0)
-
-	filterQuals := map[string]string{
-		"hypervisor":                  "hypervisor",
-		"iam_instance_profile_arn":    "iam-instance-profile.arn",
-		"image_id":                    "image-id",
-		"instance_lifecycle":          "instance-lifecycle",
-		"instance_state":              "instance-state-name",
-		"instance_type":               "instance-type",
-		"monitoring_state":            "monitoring-state",
-		"outpost_arn":                 "outpost-arn",
-		"placement_availability_zone": "availability-zone",
-		"placement_group_name":        "placement-group-name",
-		"public_dns_name":             "dns-name",
-		"ram_disk_id":                 "ramdisk-id",
-		"root_device_name":            "root-device-name",
-		"root_device_type":            "root-device-type",
-		"subnet_id":                   "subnet-id",
-		"placement_tenancy":           "tenancy",
-		"virtualization_type":         "virtualization-type",
-		"vpc_id":                      "vpc-id",
-	}
-
-	for columnName, filterName := range filterQuals {
-		if equalQuals[columnName] != nil {
-			filter := ec2.Filter{
-				Name: types.String(filterName),
-			}
-			value := equalQuals[columnName]
-			if value.GetStringValue() != "" {
-				filter.Values = []*string{types.String(equalQuals[columnName].GetStringValue())}
-			} else if value.GetListValue() != nil {
-				filter.Values = getListValues(value.GetListValue())
-			}
-			filters = append(filters, &filter)
-		}
-	}
-	return filters
+	return buildEc2Filters(equalQuals, ec2InstanceFilterColumns)
 }
 
 func getListValues(listValue *proto.QualValueList) []*string {
@@ -87,7 +87,9 @@ func listAwsAvailableInstanceTypes(ctx context.Context, d *plugin.QueryData, h *
 		params,
 		func(page *ec2.DescribeInstanceTypeOfferingsOutput, isLast bool) bool {
 			for _, instanceTypeOffering := range page.InstanceTypeOfferings {
-				d.StreamListItem(ctx, instanceTypeOffering)
+				if !streamListItem(ctx, d, instanceTypeOffering) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -105,7 +107,7 @@ func listAwsAvailableInstanceTypes(ctx context.Context, d *plugin.QueryData, h *
 func getAwsInstanceAvailableAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getAwsInstanceAvailableAkas")
 	instanceType := h.Item.(*ec2.InstanceTypeOffering)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestBuildEc2InstanceFilter(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		quals    plugin.KeyColumnEqualsQualMap
+		expected map[string][]string
+	}{
+		{
+			name:     "no quals",
+			quals:    plugin.KeyColumnEqualsQualMap{},
+			expected: map[string][]string{},
+		},
+		{
+			name: "string quals",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"instance_type":               proto.NewQualValue("t3.micro"),
+				"placement_availability_zone": proto.NewQualValue("us-east-1a"),
+			},
+			expected: map[string][]string{
+				"availability-zone": {"us-east-1a"},
+				"instance-type":     {"t3.micro"},
+			},
+		},
+		{
+			name: "list qual",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"instance_state": {Value: &proto.QualValue_ListValue{ListValue: &proto.QualValueList{Values: []*proto.QualValue{
+					proto.NewQualValue("running"),
+					proto.NewQualValue("stopped"),
+				}}}},
+			},
+			expected: map[string][]string{
+				"instance-state-name": {"running", "stopped"},
+			},
+		},
+		{
+			name: "columns without a filter",
+			quals: plugin.KeyColumnEqualsQualMap{
+				"instance_id": proto.NewQualValue("i-0123456789abcdef0"),
+				"region":      proto.NewQualValue("us-east-1"),
+				"vpc_id":      proto.NewQualValue("vpc-0123456789abcdef0"),
+			},
+			expected: map[string][]string{
+				"vpc-id": {"vpc-0123456789abcdef0"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := ec2FiltersByName(buildEc2InstanceFilter(testCase.quals))
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected filters %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestListEc2Instance(t *testing.T) {
+	var instances []*ec2.Instance
+	for _, id := range []string{"i-00000000000000001", "i-00000000000000002", "i-00000000000000003", "i-00000000000000004", "i-00000000000000005", "i-00000000000000006", "i-00000000000000007"} {
+		instances = append(instances, &ec2.Instance{
+			InstanceId:            aws.String(id),
+			InstanceType:          aws.String("t3.micro"),
+			State:                 &ec2.InstanceState{Name: aws.String("running")},
+			StateTransitionReason: aws.String(""),
+		})
+	}
+
+	for _, testCase := range []struct {
+		name               string
+		quals              map[string]string
+		limit              int64
+		expectedRows       int
+		expectedMaxResults int64
+		expectedFilters    map[string][]string
+	}{
+		{
+			name:               "all instances",
+			expectedRows:       7,
+			expectedMaxResults: 1000,
+			expectedFilters:    map[string][]string{},
+		},
+		{
+			name:               "filtered by instance type",
+			quals:              map[string]string{"instance_type": "t3.micro"},
+			expectedRows:       7,
+			expectedMaxResults: 1000,
+			expectedFilters:    map[string][]string{"instance-type": {"t3.micro"}},
+		},
+		{
+			name:               "limit below the minimum page size",
+			limit:              2,
+			expectedRows:       2,
+			expectedMaxResults: 5,
+			expectedFilters:    map[string][]string{},
+		},
+		{
+			name:               "limit",
+			limit:              6,
+			expectedRows:       6,
+			expectedMaxResults: 6,
+			expectedFilters:    map[string][]string{},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeEc2 := &fakeEC2{Regions: []string{"us-east-1"}, Instances: instances}
+			defer registerServiceClient("ec2", fakeEc2)()
+			defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "123456789012")})()
+
+			p := newFakeTableTestPlugin(t, "test_list_ec2_instance_"+t.Name())
+			stream := executeTableTestQuery(t, p, "aws_ec2_instance", []string{"instance_id", "instance_type", "region"}, testCase.quals, testCase.limit)
+
+			if len(stream.rows) != testCase.expectedRows {
+				t.Errorf("expected %d rows, got %d", testCase.expectedRows, len(stream.rows))
+			}
+			for _, row := range stream.rows {
+				if row["region"] != "us-east-1" || row["instance_type"] != "t3.micro" {
+					t.Errorf("unexpected row %v", row)
+				}
+			}
+
+			inputs := fakeEc2.DescribeInstancesInputs()
+			if len(inputs) != 1 {
+				t.Fatalf("expected 1 DescribeInstances call, got %d", len(inputs))
+			}
+			if maxResults := aws.Int64Value(inputs[0].MaxResults); maxResults != testCase.expectedMaxResults {
+				t.Errorf("expected MaxResults %d, got %d", testCase.expectedMaxResults, maxResults)
+			}
+			if filters := ec2FiltersByName(inputs[0].Filters); !reflect.DeepEqual(filters, testCase.expectedFilters) {
+				t.Errorf("expected filters %v, got %v", testCase.expectedFilters, filters)
+			}
+		})
+	}
+}
+
+// ec2FiltersByName returns the sorted values of filters by name, as filters are built in any order
+func ec2FiltersByName(filters []*ec2.Filter) map[string][]string {
+	filtersByName := map[string][]string{}
+	for _, filter := range filters {
+		values := aws.StringValueSlice(filter.Values)
+		sort.Strings(values)
+		filtersByName[aws.StringValue(filter.Name)] = values
+	}
+	return filtersByName
+}
@@ -23,7 +23,8 @@ func tableAwsEc2KeyPair(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2KeyPair,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2KeyPairs,
+			Hydrate:    listEc2KeyPairs,
+			KeyColumns: ec2FilterKeyColumns(ec2KeyPairFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -71,6 +72,13 @@ func tableAwsEc2KeyPair(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeKeyPairs
+var ec2KeyPairFilterColumns = []ec2FilterColumn{
+	{Column: "key_pair_id", Filter: "key-pair-id", Type: proto.ColumnType_STRING},
+	{Column: "key_fingerprint", Filter: "fingerprint", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEc2KeyPairs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -83,7 +91,13 @@ func listEc2KeyPairs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	resp, err := svc.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{})
+	input := &ec2.DescribeKeyPairsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2KeyPairFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
+	resp, err := svc.DescribeKeyPairs(input)
 
 	for _, keyPair := range resp.KeyPairs {
 		d.StreamListItem(ctx, keyPair)
@@ -122,7 +136,7 @@ func getAwsEc2KeyPairAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	plugin.Logger(ctx).Trace("getAwsEc2KeyPairAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	keyPair := h.Item.(*ec2.KeyPairInfo)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -179,7 +179,9 @@ func listAwsEc2LaunchConfigurations(ctx context.Context, d *plugin.QueryData, _
 		&autoscaling.DescribeLaunchConfigurationsInput{},
 		func(page *autoscaling.DescribeLaunchConfigurationsOutput, isLast bool) bool {
 			for _, launchConfiguration := range page.LaunchConfigurations {
-				d.StreamListItem(ctx, launchConfiguration)
+				if !streamListItem(ctx, d, launchConfiguration) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -102,7 +102,9 @@ func listEc2LoadBalancers(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		&elbv2.DescribeLoadBalancersInput{},
 		func(page *elbv2.DescribeLoadBalancersOutput, isLast bool) bool {
 			for _, loadBalancer := range page.LoadBalancers {
-				d.StreamListItem(ctx, loadBalancer)
+				if !streamListItem(ctx, d, loadBalancer) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -23,7 +23,8 @@ func tableAwsEc2NetworkInterface(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2NetworkInterface,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2NetworkInterfaces,
+			Hydrate:    listEc2NetworkInterfaces,
+			KeyColumns: ec2FilterKeyColumns(ec2NetworkInterfaceFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -223,6 +224,33 @@ func tableAwsEc2NetworkInterface(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeNetworkInterfaces
+var ec2NetworkInterfaceFilterColumns = []ec2FilterColumn{
+	{Column: "status", Filter: "status", Type: proto.ColumnType_STRING},
+	{Column: "interface_type", Filter: "interface-type", Type: proto.ColumnType_STRING},
+	{Column: "description", Filter: "description", Type: proto.ColumnType_STRING},
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "association_allocation_id", Filter: "association.allocation-id", Type: proto.ColumnType_STRING},
+	{Column: "association_id", Filter: "association.association-id", Type: proto.ColumnType_STRING},
+	{Column: "association_ip_owner_id", Filter: "association.ip-owner-id", Type: proto.ColumnType_STRING},
+	{Column: "association_public_dns_name", Filter: "association.public-dns-name", Type: proto.ColumnType_STRING},
+	{Column: "association_public_ip", Filter: "association.public-ip", Type: proto.ColumnType_IPADDR},
+	{Column: "attached_instance_id", Filter: "attachment.instance-id", Type: proto.ColumnType_STRING},
+	{Column: "attached_instance_owner_id", Filter: "attachment.instance-owner-id", Type: proto.ColumnType_STRING},
+	{Column: "attachment_id", Filter: "attachment.attachment-id", Type: proto.ColumnType_STRING},
+	{Column: "attachment_status", Filter: "attachment.status", Type: proto.ColumnType_STRING},
+	{Column: "delete_on_instance_termination", Filter: "attachment.delete-on-termination", Type: proto.ColumnType_BOOL},
+	{Column: "device_index", Filter: "attachment.device-index", Type: proto.ColumnType_INT},
+	{Column: "mac_address", Filter: "mac-address", Type: proto.ColumnType_STRING},
+	{Column: "private_dns_name", Filter: "private-dns-name", Type: proto.ColumnType_STRING},
+	{Column: "private_ip_address", Filter: "private-ip-address", Type: proto.ColumnType_IPADDR},
+	{Column: "requester_id", Filter: "requester-id", Type: proto.ColumnType_STRING},
+	{Column: "requester_managed", Filter: "requester-managed", Type: proto.ColumnType_BOOL},
+	{Column: "source_dest_check", Filter: "source-dest-check", Type: proto.ColumnType_BOOL},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEc2NetworkInterfaces(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -235,12 +263,20 @@ func listEc2NetworkInterfaces(ctx context.Context, d *plugin.QueryData, _ *plugi
 		return nil, err
 	}
 
+	input := &ec2.DescribeNetworkInterfacesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2NetworkInterfaceFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeNetworkInterfacesPages(
-		&ec2.DescribeNetworkInterfacesInput{},
+		input,
 		func(page *ec2.DescribeNetworkInterfacesOutput, isLast bool) bool {
 			for _, networkInterface := range page.NetworkInterfaces {
-				d.StreamListItem(ctx, networkInterface)
+				if !streamListItem(ctx, d, networkInterface) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -282,7 +318,7 @@ func getAwsEc2NetworkInterfaceAkas(ctx context.Context, d *plugin.QueryData, h *
 	plugin.Logger(ctx).Trace("getAwsEc2NetworkInterfaceTurbotData")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	networkInterface := h.Item.(*ec2.NetworkInterface)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
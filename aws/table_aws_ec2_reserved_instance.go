@@ -23,7 +23,8 @@ func tableAwsEc2ReservedInstance(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2ReservedInstance,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2ReservedInstances,
+			Hydrate:    listEc2ReservedInstances,
+			KeyColumns: ec2FilterKeyColumns(ec2ReservedInstanceFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -156,6 +157,17 @@ func tableAwsEc2ReservedInstance(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeReservedInstances
+var ec2ReservedInstanceFilterColumns = []ec2FilterColumn{
+	{Column: "instance_type", Filter: "instance-type", Type: proto.ColumnType_STRING},
+	{Column: "instance_state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "duration", Filter: "duration", Type: proto.ColumnType_INT},
+	{Column: "scope", Filter: "scope", Type: proto.ColumnType_STRING},
+	{Column: "product_description", Filter: "product-description", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listEc2ReservedInstances(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -169,6 +181,10 @@ func listEc2ReservedInstances(ctx context.Context, d *plugin.QueryData, _ *plugi
 	}
 
 	param := &ec2.DescribeReservedInstancesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2ReservedInstanceFilterColumns)
+	if len(filters) != 0 {
+		param.Filters = filters
+	}
 	// List call
 	result, err := svc.DescribeReservedInstances(param)
 	if err != nil {
@@ -215,7 +231,7 @@ func getEc2ReservedInstanceARN(ctx context.Context, d *plugin.QueryData, h *plug
 	instance := h.Item.(*ec2.ReservedInstances)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -164,7 +164,9 @@ func listEc2TargetGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		&elbv2.DescribeTargetGroupsInput{},
 		func(page *elbv2.DescribeTargetGroupsOutput, isLast bool) bool {
 			for _, targetGroup := range page.TargetGroups {
-				d.StreamListItem(ctx, targetGroup)
+				if !streamListItem(ctx, d, targetGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
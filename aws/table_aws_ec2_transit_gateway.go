@@ -23,7 +23,8 @@ func tableAwsEc2TransitGateway(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2TransitGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2TransitGateways,
+			Hydrate:    listEc2TransitGateways,
+			KeyColumns: ec2FilterKeyColumns(ec2TransitGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -147,6 +148,20 @@ func tableAwsEc2TransitGateway(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeTransitGateways
+var ec2TransitGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "amazon_side_asn", Filter: "options.amazon-side-asn", Type: proto.ColumnType_INT},
+	{Column: "association_default_route_table_id", Filter: "options.association-default-route-table-id", Type: proto.ColumnType_STRING},
+	{Column: "auto_accept_shared_attachments", Filter: "options.auto-accept-shared-attachments", Type: proto.ColumnType_STRING},
+	{Column: "default_route_table_association", Filter: "options.default-route-table-association", Type: proto.ColumnType_STRING},
+	{Column: "default_route_table_propagation", Filter: "options.default-route-table-propagation", Type: proto.ColumnType_STRING},
+	{Column: "dns_support", Filter: "options.dns-support", Type: proto.ColumnType_STRING},
+	{Column: "propagation_default_route_table_id", Filter: "options.propagation-default-route-table-id", Type: proto.ColumnType_STRING},
+	{Column: "vpn_ecmp_support", Filter: "options.vpn-ecmp-support", Type: proto.ColumnType_STRING},
+}
+
 //// LIST FUNCTION
 
 func listEc2TransitGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -158,12 +173,20 @@ func listEc2TransitGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		return nil, err
 	}
 
+	input := &ec2.DescribeTransitGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2TransitGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeTransitGatewaysPages(
-		&ec2.DescribeTransitGatewaysInput{},
+		input,
 		func(page *ec2.DescribeTransitGatewaysOutput, isLast bool) bool {
 			for _, transitGateway := range page.TransitGateways {
-				d.StreamListItem(ctx, transitGateway)
+				if !streamListItem(ctx, d, transitGateway) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -126,7 +126,7 @@ func getAwsEc2TransitGatewayRouteAka(ctx context.Context, d *plugin.QueryData, h
 	plugin.Logger(ctx).Trace("getAwsEc2TransitGatewayRouteAka")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	route := h.Item.(*RouteDetails)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -23,7 +23,8 @@ func tableAwsEc2TransitGatewayRouteTable(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2TransitGatewayRouteTable,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2TransitGatewayRouteTable,
+			Hydrate:    listEc2TransitGatewayRouteTable,
+			KeyColumns: ec2FilterKeyColumns(ec2TransitGatewayRouteTableFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -86,6 +87,14 @@ func tableAwsEc2TransitGatewayRouteTable(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeTransitGatewayRouteTables
+var ec2TransitGatewayRouteTableFilterColumns = []ec2FilterColumn{
+	{Column: "transit_gateway_id", Filter: "transit-gateway-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "default_association_route_table", Filter: "default-association-route-table", Type: proto.ColumnType_BOOL},
+	{Column: "default_propagation_route_table", Filter: "default-propagation-route-table", Type: proto.ColumnType_BOOL},
+}
+
 //// LIST FUNCTION
 
 func listEc2TransitGatewayRouteTable(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -97,12 +106,20 @@ func listEc2TransitGatewayRouteTable(ctx context.Context, d *plugin.QueryData, _
 		return nil, err
 	}
 
+	input := &ec2.DescribeTransitGatewayRouteTablesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2TransitGatewayRouteTableFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeTransitGatewayRouteTablesPages(
-		&ec2.DescribeTransitGatewayRouteTablesInput{},
+		input,
 		func(page *ec2.DescribeTransitGatewayRouteTablesOutput, isLast bool) bool {
 			for _, transitGatewayRouteTable := range page.TransitGatewayRouteTables {
-				d.StreamListItem(ctx, transitGatewayRouteTable)
+				if !streamListItem(ctx, d, transitGatewayRouteTable) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -142,7 +159,7 @@ func getAwsEc2TransitGatewayRouteTableTurbotData(ctx context.Context, d *plugin.
 	plugin.Logger(ctx).Trace("getAwsEc2TransitGatewayRouteTableTurbotData")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	transitGatewayRouteTable := h.Item.(*ec2.TransitGatewayRouteTable)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
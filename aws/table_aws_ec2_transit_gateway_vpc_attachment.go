@@ -20,7 +20,8 @@ func tableAwsEc2TransitGatewayVpcAttachment(_ context.Context) *plugin.Table {
 			Hydrate:           getEc2TransitGatewayVpcAttachment,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listEc2TransitGatewayVpcAttachment,
+			Hydrate:    listEc2TransitGatewayVpcAttachment,
+			KeyColumns: ec2FilterKeyColumns(ec2TransitGatewayVpcAttachmentFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -107,6 +108,18 @@ func tableAwsEc2TransitGatewayVpcAttachment(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeTransitGatewayAttachments
+var ec2TransitGatewayVpcAttachmentFilterColumns = []ec2FilterColumn{
+	{Column: "transit_gateway_id", Filter: "transit-gateway-id", Type: proto.ColumnType_STRING},
+	{Column: "transit_gateway_owner_id", Filter: "transit-gateway-owner-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "resource_id", Filter: "resource-id", Type: proto.ColumnType_STRING},
+	{Column: "resource_type", Filter: "resource-type", Type: proto.ColumnType_STRING},
+	{Column: "resource_owner_id", Filter: "resource-owner-id", Type: proto.ColumnType_STRING},
+	{Column: "association_state", Filter: "association.state", Type: proto.ColumnType_STRING},
+	{Column: "association_transit_gateway_route_table_id", Filter: "association.transit-gateway-route-table-id", Type: proto.ColumnType_STRING},
+}
+
 //// LIST FUNCTION
 
 func listEc2TransitGatewayVpcAttachment(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -119,12 +132,20 @@ func listEc2TransitGatewayVpcAttachment(ctx context.Context, d *plugin.QueryData
 		return nil, err
 	}
 
+	input := &ec2.DescribeTransitGatewayAttachmentsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, ec2TransitGatewayVpcAttachmentFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeTransitGatewayAttachmentsPages(
-		&ec2.DescribeTransitGatewayAttachmentsInput{},
+		input,
 		func(page *ec2.DescribeTransitGatewayAttachmentsOutput, isLast bool) bool {
 			for _, transitGatewayAttachment := range page.TransitGatewayAttachments {
-				d.StreamListItem(ctx, transitGatewayAttachment)
+				if !streamListItem(ctx, d, transitGatewayAttachment) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -169,7 +190,7 @@ func getAwsEc2TransitGatewayVpcAttachmentAkas(ctx context.Context, d *plugin.Que
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	transitGatewayAttachment := h.Item.(*ec2.TransitGatewayAttachment)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
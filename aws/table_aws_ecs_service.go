@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -261,7 +262,7 @@ func listEcsServices(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	return nil, nil
 }
 
-func getServiceDataAsync(serviceData []*string, clusterARN *string, svc *ecs.ECS, wg *sync.WaitGroup, serviceCh chan *ecs.DescribeServicesOutput, errorCh chan error) {
+func getServiceDataAsync(serviceData []*string, clusterARN *string, svc ecsiface.ECSAPI, wg *sync.WaitGroup, serviceCh chan *ecs.DescribeServicesOutput, errorCh chan error) {
 	defer wg.Done()
 	rowData, err := getEcsService(serviceData, clusterARN, svc)
 	if err != nil {
@@ -273,7 +274,7 @@ func getServiceDataAsync(serviceData []*string, clusterARN *string, svc *ecs.ECS
 
 // Describes the specified services running in your cluster.
 // Below API can describe up to 10 services in a single operation.
-func getEcsService(serviceData []*string, clusterARN *string, svc *ecs.ECS) (*ecs.DescribeServicesOutput, error) {
+func getEcsService(serviceData []*string, clusterARN *string, svc ecsiface.ECSAPI) (*ecs.DescribeServicesOutput, error) {
 	params := &ecs.DescribeServicesInput{
 		Services: serviceData,
 		Cluster:  clusterARN,
@@ -294,12 +294,7 @@ func listEcsTasks(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 
 	// If the requested number of items is less than the paging max limit
 	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxResults {
-			input.MaxResults = limit
-		}
-	}
+	input.MaxResults = listPageSize(d, 1, *input.MaxResults)
 
 	var taskArns [][]*string
 
@@ -115,7 +115,9 @@ func listEfsAccessPoints(ctx context.Context, d *plugin.QueryData, _ *plugin.Hyd
 		&efs.DescribeAccessPointsInput{},
 		func(page *efs.DescribeAccessPointsOutput, isLast bool) bool {
 			for _, accessPoint := range page.AccessPoints {
-				d.StreamListItem(ctx, accessPoint)
+				if !streamListItem(ctx, d, accessPoint) {
+					return false
+				}
 			}
 			return !isLast
 		},
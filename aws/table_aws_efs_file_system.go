@@ -163,7 +163,9 @@ func listElasticFileSystem(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		&efs.DescribeFileSystemsInput{},
 		func(page *efs.DescribeFileSystemsOutput, isLast bool) bool {
 			for _, fileSystem := range page.FileSystems {
-				d.StreamListItem(ctx, fileSystem)
+				if !streamListItem(ctx, d, fileSystem) {
+					return false
+				}
 			}
 			return !isLast
 		},
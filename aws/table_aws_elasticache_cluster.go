@@ -195,7 +195,9 @@ func listElastiCacheClusters(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&elasticache.DescribeCacheClustersInput{},
 		func(page *elasticache.DescribeCacheClustersOutput, isLast bool) bool {
 			for _, cacheCluster := range page.CacheClusters {
-				d.StreamListItem(ctx, cacheCluster)
+				if !streamListItem(ctx, d, cacheCluster) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -85,7 +85,9 @@ func listElastiCacheParameterGroup(ctx context.Context, d *plugin.QueryData, _ *
 		&elasticache.DescribeCacheParameterGroupsInput{},
 		func(page *elasticache.DescribeCacheParameterGroupsOutput, isLast bool) bool {
 			for _, parameterGroup := range page.CacheParameterGroups {
-				d.StreamListItem(ctx, parameterGroup)
+				if !streamListItem(ctx, d, parameterGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
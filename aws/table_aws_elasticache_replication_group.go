@@ -176,7 +176,9 @@ func listElastiCacheReplicationGroups(ctx context.Context, d *plugin.QueryData,
 		&elasticache.DescribeReplicationGroupsInput{},
 		func(page *elasticache.DescribeReplicationGroupsOutput, isLast bool) bool {
 			for _, replicationGroup := range page.ReplicationGroups {
-				d.StreamListItem(ctx, replicationGroup)
+				if !streamListItem(ctx, d, replicationGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
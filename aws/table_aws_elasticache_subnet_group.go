@@ -85,7 +85,9 @@ func listElastiCacheSubnetGroups(ctx context.Context, d *plugin.QueryData, _ *pl
 		&elasticache.DescribeCacheSubnetGroupsInput{},
 		func(page *elasticache.DescribeCacheSubnetGroupsOutput, isLast bool) bool {
 			for _, cacheSubnetGroup := range page.CacheSubnetGroups {
-				d.StreamListItem(ctx, cacheSubnetGroup)
+				if !streamListItem(ctx, d, cacheSubnetGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -242,7 +242,9 @@ func listEmrClusters(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		&emr.ListClustersInput{},
 		func(page *emr.ListClustersOutput, isLast bool) bool {
 			for _, cluster := range page.Clusters {
-				d.StreamListItem(ctx, cluster)
+				if !streamListItem(ctx, d, cluster) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -177,7 +177,9 @@ func listEmrInstanceGroups(ctx context.Context, d *plugin.QueryData, h *plugin.H
 		},
 		func(page *emr.ListInstanceGroupsOutput, isLast bool) bool {
 			for _, instanceGroup := range page.InstanceGroups {
-				d.StreamListItem(ctx, instanceGroupDetails{*instanceGroup, *clusterID})
+				if !streamListItem(ctx, d, instanceGroupDetails{*instanceGroup, *clusterID}) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -191,7 +193,7 @@ func getEmrInstanceGroupARN(ctx context.Context, d *plugin.QueryData, h *plugin.
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	data := h.Item.(instanceGroupDetails)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
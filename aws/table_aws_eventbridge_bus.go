@@ -3,7 +3,6 @@ package aws
 import (
 	"context"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eventbridge"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
@@ -92,16 +91,8 @@ func listAwsEventBridgeBuses(ctx context.Context, d *plugin.QueryData, _ *plugin
 
 	// List call
 	input := eventbridge.ListEventBusesInput{
-		// Default to the maximum allowed
-		Limit: aws.Int64(100),
-	}
-
-	// Reduce the basic request limit down if the user has only requested a small number of rows
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.Limit {
-			input.Limit = limit
-		}
+		// The maximum allowed, or less if the user has only requested a small number of rows
+		Limit: listPageSize(d, 1, 100),
 	}
 
 	for {
@@ -112,11 +103,13 @@ func listAwsEventBridgeBuses(ctx context.Context, d *plugin.QueryData, _ *plugin
 		}
 
 		for _, bus := range response.EventBuses {
-			d.StreamListItem(ctx, &eventbridge.DescribeEventBusOutput{
+			if !streamListItem(ctx, d, &eventbridge.DescribeEventBusOutput{
 				Name:   bus.Name,
 				Arn:    bus.Arn,
 				Policy: bus.Policy,
-			})
+			}) {
+				return nil, nil
+			}
 		}
 
 		if response.NextToken == nil {
@@ -170,7 +170,9 @@ func listFsxFileSystems(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		&fsx.DescribeFileSystemsInput{},
 		func(page *fsx.DescribeFileSystemsOutput, isLast bool) bool {
 			for _, fileSystem := range page.FileSystems {
-				d.StreamListItem(ctx, fileSystem)
+				if !streamListItem(ctx, d, fileSystem) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -77,7 +77,9 @@ func listUserAccessKeys(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 		params,
 		func(page *iam.ListAccessKeysOutput, isLast bool) bool {
 			for _, key := range page.AccessKeyMetadata {
-				d.StreamListItem(ctx, key)
+				if !streamListItem(ctx, d, key) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -204,13 +204,7 @@ func listCredentialReports(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 	logger := plugin.Logger(ctx)
 	logger.Trace("listCredentialReports")
 
-	// Create Session
-	svc, err := IAMService(ctx, d)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := svc.GetCredentialReport(&iam.GetCredentialReportInput{})
+	resp, err := getCredentialReport(ctx, d)
 	if err != nil {
 		if a, ok := err.(awserr.Error); ok {
 			if helpers.StringSliceContains([]string{"ReportNotPresent"}, a.Code()) {
@@ -270,3 +264,27 @@ func passwordStatus(_ context.Context, d *transform.TransformData) (interface{},
 	}
 	return pwdStatus, nil
 }
+
+//// UTILITY FUNCTIONS
+
+// getCredentialReport returns the credential report of the account, from the disk cache if enabled
+func getCredentialReport(ctx context.Context, d *plugin.QueryData) (*iam.GetCredentialReportOutput, error) {
+	cacheKey := scopedCacheKey(d, "GetCredentialReport")
+	resp := &iam.GetCredentialReportOutput{}
+	if diskCacheGet(ctx, d, cacheKey, resp) {
+		return resp, nil
+	}
+
+	// Create Session
+	svc, err := IAMService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err = svc.GetCredentialReport(&iam.GetCredentialReportInput{})
+	if err != nil {
+		return nil, err
+	}
+	diskCacheSet(ctx, d, cacheKey, resp, credentialReportDiskCacheTTL)
+	return resp, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 )
 
@@ -121,7 +122,9 @@ func listIamGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		&iam.ListGroupsInput{},
 		func(page *iam.ListGroupsOutput, lastPage bool) bool {
 			for _, group := range page.Groups {
-				d.StreamListItem(ctx, group)
+				if !streamListItem(ctx, d, group) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -164,6 +167,14 @@ func getAwsIamGroupAttachedPolicies(ctx context.Context, d *plugin.QueryData, h
 	plugin.Logger(ctx).Trace("getAwsIamGroupAttachedPolicies")
 	group := h.Item.(*iam.Group)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.group(*group.GroupName); detail != nil {
+		return attachedIamPolicyArns(detail.AttachedManagedPolicies), nil
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -194,6 +205,18 @@ func getAwsIamGroupUsers(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	plugin.Logger(ctx).Trace("getAwsIamGroupUsers")
 	group := h.Item.(*iam.Group)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if details.group(*group.GroupName) != nil {
+		// the snapshot has the group memberships of the users
+		if users := details.groupUsers(*group.GroupName); users != nil {
+			return &iam.GetGroupOutput{Group: group, Users: users}, nil
+		}
+		return iam.GetGroupOutput{}, nil
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -219,6 +242,14 @@ func listAwsIamGroupInlinePolicies(ctx context.Context, d *plugin.QueryData, h *
 	plugin.Logger(ctx).Trace("getAwsIamGroupInlinePolicies")
 	group := h.Item.(*iam.Group)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.group(*group.GroupName); detail != nil {
+		return &iam.ListGroupPoliciesOutput{PolicyNames: inlineIamPolicyNames(detail.GroupPolicyList)}, nil
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -240,6 +271,15 @@ func listAwsIamGroupInlinePolicies(ctx context.Context, d *plugin.QueryData, h *
 func getAwsIamGroupInlinePolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getAwsIamGroupInlinePolicies")
 	group := h.Item.(*iam.Group)
+
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.group(*group.GroupName); detail != nil {
+		return inlineIamPolicies(detail.GroupPolicyList)
+	}
+
 	listGroupPoliciesOutput := h.HydrateResults["listAwsIamGroupInlinePolicies"].(*iam.ListGroupPoliciesOutput)
 
 	// Create Session
@@ -277,7 +317,7 @@ func getAwsIamGroupInlinePolicies(ctx context.Context, d *plugin.QueryData, h *p
 	return groupPolicies, nil
 }
 
-func getGroupPolicyDataAsync(policy *string, groupName *string, svc *iam.IAM, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
+func getGroupPolicyDataAsync(policy *string, groupName *string, svc iamiface.IAMAPI, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
 	defer wg.Done()
 
 	rowData, err := getGroupInlinePolicy(policy, groupName, svc)
@@ -288,7 +328,7 @@ func getGroupPolicyDataAsync(policy *string, groupName *string, svc *iam.IAM, wg
 	}
 }
 
-func getGroupInlinePolicy(policyName *string, groupName *string, svc *iam.IAM) (map[string]interface{}, error) {
+func getGroupInlinePolicy(policyName *string, groupName *string, svc iamiface.IAMAPI) (map[string]interface{}, error) {
 	groupPolicy := make(map[string]interface{})
 	params := &iam.GetGroupPolicyInput{
 		PolicyName: policyName,
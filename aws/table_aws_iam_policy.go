@@ -2,12 +2,14 @@ package aws
 
 import (
 	"context"
+	"net/url"
 	"strings"
 
 	"github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 )
@@ -109,6 +111,13 @@ func tableAwsIamPolicy(_ context.Context) *plugin.Table {
 				Hydrate:     getPolicyVersion,
 				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "actions_expanded",
+				Description: "The concrete actions allowed or denied by the policy, with their access level. Wildcards and NotAction are expanded with the actions of the aws_iam_action table.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getPolicyVersion,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(policyToCanonical).Transform(policyToExpandedActions),
+			},
 			{
 				Name:        "tags_src",
 				Description: "A list of tags attached with the IAM policy.",
@@ -151,24 +160,14 @@ func listIamPolicies(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	}
 
 	input := buildIamPolicyFilter(d.KeyColumnQuals, d.Quals)
-	input.MaxItems = types.Int64(100)
-
 	// If the requested number of items is less than the paging max limit
 	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxItems {
-			input.MaxItems = limit
-		}
-	}
+	input.MaxItems = listPageSize(d, 1, 100)
 
 	// List call
 	err = svc.ListPoliciesPages(&input, func(page *iam.ListPoliciesOutput, lastPage bool) bool {
 		for _, policy := range page.Policies {
-			d.StreamListItem(ctx, policy)
-			// Check if context has been cancelled or if the limit has been hit (if specified)
-			// if there is a limit, it will return the number of rows required to reach this limit
-			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			if !streamListItem(ctx, d, policy) {
 				return false
 			}
 		}
@@ -214,6 +213,17 @@ func getPolicyVersion(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	plugin.Logger(ctx).Trace("getPolicyVersion")
 	policy := h.Item.(*iam.Policy)
 
+	details, err := getIamPolicyAuthorizationDetails(ctx, d, *policy.Arn)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.policy(*policy.Arn); detail != nil {
+		// the snapshot is older than the listed policy if their default versions differ
+		if version := defaultIamPolicyVersion(detail); version != nil && types.SafeString(version.VersionId) == types.SafeString(policy.DefaultVersionId) {
+			return &iam.GetPolicyVersionOutput{PolicyVersion: version}, nil
+		}
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -240,7 +250,7 @@ func isPolicyAwsManaged(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 
 	policy := h.Item.(*iam.Policy)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -285,6 +295,54 @@ func attachementCountToBool(_ context.Context, d *transform.TransformData) (inte
 
 //// UTILITY FUNCTIONS
 
+// getIamManagedPolicyDocument returns the unescaped document of the default version of a
+// managed policy
+func getIamManagedPolicyDocument(ctx context.Context, d *plugin.QueryData, policyArn string) (string, error) {
+	details, err := getIamPolicyAuthorizationDetails(ctx, d, policyArn)
+	if err != nil {
+		return "", err
+	}
+
+	var document string
+	if detail := details.policy(policyArn); detail != nil && defaultIamPolicyVersion(detail) != nil {
+		document = types.SafeString(defaultIamPolicyVersion(detail).Document)
+	} else {
+		// Create Session
+		svc, err := IAMService(ctx, d)
+		if err != nil {
+			return "", err
+		}
+
+		policy, err := svc.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(policyArn)})
+		if err != nil {
+			return "", err
+		}
+		version, err := svc.GetPolicyVersion(&iam.GetPolicyVersionInput{
+			PolicyArn: policy.Policy.Arn,
+			VersionId: policy.Policy.DefaultVersionId,
+		})
+		if err != nil {
+			return "", err
+		}
+		document = types.SafeString(version.PolicyVersion.Document)
+	}
+
+	return url.QueryUnescape(document)
+}
+
+// getIamManagedPolicy returns the default version of a managed policy in canonical form
+func getIamManagedPolicy(ctx context.Context, d *plugin.QueryData, policyArn string) (Policy, error) {
+	document, err := getIamManagedPolicyDocument(ctx, d, policyArn)
+	if err != nil {
+		return Policy{}, err
+	}
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		return Policy{}, err
+	}
+	return policy.(Policy), nil
+}
+
 func buildIamPolicyFilter(equalQuals plugin.KeyColumnEqualsQualMap, quals plugin.KeyColumnQualMap) iam.ListPoliciesInput {
 	input := iam.ListPoliciesInput{}
 
@@ -0,0 +1,168 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamPolicyAction(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_policy_action",
+		Description: "AWS IAM Policy Action, the concrete actions allowed or denied by the statements of managed policies",
+		List: &plugin.ListConfig{
+			Hydrate: listIamPolicyActions,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "policy_arn", Require: plugin.Optional},
+				{Name: "is_aws_managed", Require: plugin.Optional, Operators: []string{"<>", "="}},
+				{Name: "is_attached", Require: plugin.Optional, Operators: []string{"<>", "="}},
+			},
+		},
+		Columns: awsColumns([]*plugin.Column{
+			{
+				Name:        "policy_arn",
+				Description: "The Amazon Resource Name (ARN) specifying the iam policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_name",
+				Description: "The friendly name that identifies the iam policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_aws_managed",
+				Description: "Specifies whether the policy is AWS Managed or Customer Managed. If true policy is aws managed otherwise customer managed.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "is_attached",
+				Description: "Specifies whether the policy is attached to at least one IAM user, group, or role.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "statement_index",
+				Description: "The index of the statement in the policy, starting at 0.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "sid",
+				Description: "The Sid of the statement.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "effect",
+				Description: "The effect of the statement, Allow or Deny.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action",
+				Description: "The concrete action matched by the Action, or not matched by the NotAction, of the statement.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "access_level",
+				Description: "The access level of the action, e.g. List, Read, Write, Tagging or Permissions management. Empty if the action is not in the aws_iam_action table.",
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+type awsIamPolicyAction struct {
+	PolicyArn      string
+	PolicyName     string
+	IsAwsManaged   bool
+	IsAttached     bool
+	StatementIndex int
+	Sid            string
+	Effect         string
+	Action         string
+	AccessLevel    string
+}
+
+//// LIST FUNCTION
+
+func listIamPolicyActions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := IAMService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	policyArn := d.KeyColumnQuals["policy_arn"].GetStringValue()
+	if policyArn != "" {
+		op, err := svc.GetPolicy(&iam.GetPolicyInput{PolicyArn: aws.String(policyArn)})
+		if err != nil {
+			if a, ok := err.(awserr.Error); ok && a.Code() == iam.ErrCodeNoSuchEntityException {
+				return nil, nil
+			}
+			return nil, err
+		}
+		_, err = streamIamPolicyActions(ctx, d, op.Policy)
+		return nil, err
+	}
+
+	input := buildIamPolicyFilter(d.KeyColumnQuals, d.Quals)
+	input.MaxItems = aws.Int64(100)
+
+	var streamErr error
+	err = svc.ListPoliciesPages(&input, func(page *iam.ListPoliciesOutput, lastPage bool) bool {
+		for _, policy := range page.Policies {
+			more, err := streamIamPolicyActions(ctx, d, policy)
+			if err != nil {
+				streamErr = err
+				return false
+			}
+			if !more {
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, streamErr
+}
+
+//// UTILITY FUNCTIONS
+
+// streamIamPolicyActions streams a row per statement and concrete action of the default version
+// of a managed policy, and returns false once the query has all the rows it needs
+func streamIamPolicyActions(ctx context.Context, d *plugin.QueryData, policy *iam.Policy) (bool, error) {
+	document, err := getIamManagedPolicyDocument(ctx, d, *policy.Arn)
+	if err != nil {
+		return false, err
+	}
+	canonical, err := canonicalPolicy(document)
+	if err != nil {
+		return false, err
+	}
+
+	catalogue := getIamActionCatalogue()
+	for i, statement := range canonical.(Policy).Statements {
+		for _, action := range catalogue.expandStatementActions(statement) {
+			if !streamListItem(ctx, d, awsIamPolicyAction{
+				PolicyArn:      *policy.Arn,
+				PolicyName:     aws.StringValue(policy.PolicyName),
+				IsAwsManaged:   strings.Contains(*policy.Arn, ":iam::aws:policy/"),
+				IsAttached:     aws.Int64Value(policy.AttachmentCount) > 0,
+				StatementIndex: i,
+				Sid:            statement.Sid,
+				Effect:         statement.Effect,
+				Action:         action,
+				AccessLevel:    catalogue.accessLevel(action),
+			}) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,206 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamPolicyEvaluation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_policy_evaluation",
+		Description: "AWS IAM Policy Evaluation, evaluates policy documents for a request locally, without calling AWS",
+		List: &plugin.ListConfig{
+			Hydrate: listIamPolicyEvaluations,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "policy", Require: plugin.Required},
+				{Name: "action", Require: plugin.Required},
+				{Name: "resource_arn", Require: plugin.Optional},
+				{Name: "principal_arn", Require: plugin.Optional},
+				{Name: "context", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			// "Key" Columns
+			{
+				Name:        "policy",
+				Description: "The policy document to evaluate, or an array of policy documents which are evaluated together, e.g. the policies of a principal.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromQual("policy"),
+			},
+			{
+				Name:        "action",
+				Description: "The action of the request, e.g. s3:GetObject.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_arn",
+				Description: "The resource of the request. Defaults to *, which only matches statements for all resources.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_arn",
+				Description: "The principal making the request, matched against the Principal and NotPrincipal of resource-based policies.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "context",
+				Description: "The condition keys of the request and their values, e.g. {\"aws:SourceIp\": \"203.0.113.7\", \"aws:MultiFactorAuthPresent\": \"true\"}.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromQual("context"),
+			},
+			{
+				Name:        "decision",
+				Description: "The decision of the evaluation: allowed, explicitDeny or implicitDeny.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Result.Decision"),
+			},
+			{
+				Name:        "matched_statement_ids",
+				Description: "The Sids of the statements which decided the request, i.e. the denying statements of an explicit deny, or the allowing statements of an allow.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Result.MatchedStatements").Transform(matchedStatementIds),
+			},
+			{
+				Name:        "matched_statements",
+				Description: "The statements which decided the request, with the index of their policy in the array of policy documents.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Result.MatchedStatements"),
+			},
+		},
+	}
+}
+
+type awsIamPolicyEvaluation struct {
+	Action       string
+	ResourceArn  string
+	PrincipalArn string
+	Result       *iamEvaluationResult
+}
+
+//// LIST FUNCTION
+
+func listIamPolicyEvaluations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("listIamPolicyEvaluations")
+
+	policies, err := parseIamPolicyDocuments(d.KeyColumnQuals["policy"].GetJsonbValue())
+	if err != nil {
+		return nil, err
+	}
+	requestContext, err := parseIamRequestContext(d.KeyColumnQuals["context"].GetJsonbValue())
+	if err != nil {
+		return nil, err
+	}
+
+	// action and resource_arn quals may be lists, e.g. action in ('s3:GetObject', 's3:PutObject')
+	resourceArns := getQualListStringValues(d.KeyColumnQuals["resource_arn"])
+	if len(resourceArns) == 0 {
+		resourceArns = []string{"*"}
+	}
+	principalArn := d.KeyColumnQuals["principal_arn"].GetStringValue()
+
+	for _, action := range getQualListStringValues(d.KeyColumnQuals["action"]) {
+		for _, resourceArn := range resourceArns {
+			result, err := evaluateIamPolicies(policies, iamEvaluationRequest{
+				PrincipalArn: principalArn,
+				Action:       action,
+				ResourceArn:  resourceArn,
+				Context:      requestContext,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			d.StreamListItem(ctx, awsIamPolicyEvaluation{
+				Action:       action,
+				ResourceArn:  resourceArn,
+				PrincipalArn: principalArn,
+				Result:       result,
+			})
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func matchedStatementIds(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return matchedIamStatementSids(d.Value.([]iamMatchedStatement)), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// parseIamPolicyDocuments parses a policy document, or an array of policy documents, into canonical policies
+func parseIamPolicyDocuments(document string) ([]Policy, error) {
+	var raw interface{}
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return nil, fmt.Errorf("invalid policy: %s", err.Error())
+	}
+
+	documents := []interface{}{raw}
+	if rawDocuments, ok := raw.([]interface{}); ok {
+		documents = rawDocuments
+	}
+
+	var policies []Policy
+	for _, rawDocument := range documents {
+		policyDocument, err := json.Marshal(rawDocument)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := canonicalPolicy(string(policyDocument))
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy.(Policy))
+	}
+	return policies, nil
+}
+
+// parseIamRequestContext parses the condition keys of a request, a JSON object of string or
+// array values, into values by lower case key
+func parseIamRequestContext(document string) (map[string][]string, error) {
+	requestContext := map[string][]string{}
+	if document == "" {
+		return requestContext, nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return nil, fmt.Errorf("invalid context, expected an object of condition keys and their values: %s", err.Error())
+	}
+	for key, value := range raw {
+		if value == nil {
+			continue
+		}
+		values, err := toSliceOfStrings(value)
+		if err != nil {
+			return nil, err
+		}
+		requestContext[strings.ToLower(key)] = values
+	}
+	return requestContext, nil
+}
+
+// getQualListStringValues returns the values of an = or in qual of a string column
+func getQualListStringValues(value *proto.QualValue) []string {
+	if value == nil {
+		return nil
+	}
+	if listValue := value.GetListValue(); listValue != nil {
+		var values []string
+		for _, item := range listValue.Values {
+			values = append(values, item.GetStringValue())
+		}
+		return values
+	}
+	return []string{value.GetStringValue()}
+}
@@ -0,0 +1,499 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+// Whether the service control policies of the organization were applied to the permissions
+const (
+	iamServiceControlPoliciesApplied = "applied"
+	// SCPs don't restrict the users and roles of the management account
+	iamServiceControlPoliciesManagementAccount = "managementAccount"
+	iamServiceControlPoliciesNotInOrganization = "notInOrganization"
+	iamServiceControlPoliciesNotEnabled        = "notEnabled"
+	// SCPs don't restrict service-linked roles
+	iamServiceControlPoliciesServiceLinkedRole = "serviceLinkedRole"
+	// the credentials are not allowed to read the SCPs, e.g. outside the management account
+	iamServiceControlPoliciesUnavailable = "unavailable"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamPrincipalEffectivePermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_principal_effective_permission",
+		Description: "AWS IAM Principal Effective Permission, the actions and resources the identity policies of users and roles allow, after explicit denies, permissions boundaries and service control policies",
+		List: &plugin.ListConfig{
+			Hydrate: listIamPrincipalEffectivePermissions,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "principal_arn", Require: plugin.Optional},
+				{Name: "context", Require: plugin.Optional},
+			},
+		},
+		Columns: awsColumns([]*plugin.Column{
+			{
+				Name:        "principal_arn",
+				Description: "The ARN of the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_name",
+				Description: "The name of the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal, user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action",
+				Description: "The action pattern allowed by the statement, or * if the statement has a NotAction.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.Action"),
+			},
+			{
+				Name:        "excluded_actions",
+				Description: "The NotAction of the statement.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.ExcludedActions"),
+			},
+			{
+				Name:        "resource",
+				Description: "The resource pattern allowed by the statement, or * if the statement has a NotResource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.Resource"),
+			},
+			{
+				Name:        "excluded_resources",
+				Description: "The NotResource of the statement.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.ExcludedResources"),
+			},
+			{
+				Name:        "condition",
+				Description: "The conditions of the statement, which are not evaluated.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.Condition"),
+			},
+			{
+				Name:        "sid",
+				Description: "The Sid of the statement.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.Sid"),
+			},
+			{
+				Name:        "policy_type",
+				Description: "The type of the policy of the statement: inline, managed, group_inline or group_managed.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.AllowedBy.Type"),
+			},
+			{
+				Name:        "policy_name",
+				Description: "The name of the policy of the statement.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.AllowedBy.PolicyName"),
+			},
+			{
+				Name:        "policy_arn",
+				Description: "The ARN of the managed policy of the statement.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.AllowedBy.PolicyArn").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "group_name",
+				Description: "The group of the policy of the statement, for group policies.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.AllowedBy.GroupName").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "decision",
+				Description: "Whether all, some or none of the actions of the pattern are effectively allowed: allowed, partiallyAllowed or denied.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Permission.Decision"),
+			},
+			{
+				Name:        "effective_actions",
+				Description: "The actions of the pattern which are effectively allowed.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.EffectiveActions"),
+			},
+			{
+				Name:        "conditional_actions",
+				Description: "The effective actions which a policy may still deny, as it only applies to part of the resource pattern, or its conditions use keys which are not in the context.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.ConditionalActions"),
+			},
+			{
+				Name:        "trimmed_by",
+				Description: "The policies which deny some of the actions of the pattern, in the evaluation order, with the actions they deny. Explicit denies name the policy and its statements, implicit denies the permissions boundary, or the root, organizational unit or account whose service control policies don't allow the actions. Conditional trims may deny the actions, which are kept.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Permission.TrimmedBy"),
+			},
+			{
+				Name:        "service_control_policy_status",
+				Description: "Whether the service control policies of the organization were applied: applied, managementAccount, notInOrganization, notEnabled, unavailable, or serviceLinkedRole for the service-linked roles, which they don't restrict.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "context",
+				Description: "The condition keys and their values the permissions boundary, service control policies and denies are evaluated with, e.g. {\"aws:RequestedRegion\": \"us-east-1\"}. aws:PrincipalArn and aws:PrincipalAccount default to those of the principal.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromQual("context"),
+			},
+		}),
+	}
+}
+
+type awsIamPrincipalEffectivePermission struct {
+	PrincipalArn               string
+	PrincipalName              string
+	PrincipalType              string
+	ServiceControlPolicyStatus string
+	Permission                 iamEffectivePermission
+}
+
+// awsIamPrincipal is a user or role of the IAM authorization details
+type awsIamPrincipal struct {
+	Arn                 string
+	Name                string
+	Type                string
+	InlinePolicies      []*iam.PolicyDetail
+	AttachedPolicies    []*iam.AttachedPolicy
+	GroupNames          []string
+	PermissionsBoundary *iam.AttachedPermissionsBoundary
+}
+
+// awsIamServiceControlPolicies are the service control policies of an account
+type awsIamServiceControlPolicies struct {
+	Levels []iamServiceControlPolicyLevel
+	Status string
+}
+
+//// LIST FUNCTION
+
+func listIamPrincipalEffectivePermissions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("listIamPrincipalEffectivePermissions")
+
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	// the policies of every user, group and role are needed, which are only loaded in one go
+	if details == nil {
+		return nil, fmt.Errorf("aws_iam_principal_effective_permission requires iam:GetAccountAuthorizationDetails")
+	}
+
+	requestContext, err := parseIamRequestContext(d.KeyColumnQuals["context"].GetJsonbValue())
+	if err != nil {
+		return nil, err
+	}
+
+	principalArn := d.KeyColumnQuals["principal_arn"].GetStringValue()
+	var serviceControlPolicies *awsIamServiceControlPolicies
+	for _, principal := range iamAuthorizationDetailsPrincipals(details) {
+		if principalArn != "" && principal.Arn != principalArn {
+			continue
+		}
+
+		// all principals are in the account of the connection
+		if serviceControlPolicies == nil {
+			serviceControlPolicies, err = getIamServiceControlPolicies(ctx, d, strings.Split(principal.Arn, ":")[4])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		sources, err := getIamPrincipalPolicySources(ctx, d, details, principal)
+		if err != nil {
+			return nil, err
+		}
+		serviceControlPolicyStatus := serviceControlPolicies.Status
+		if isIamServiceLinkedRoleArn(principal.Arn) {
+			serviceControlPolicyStatus = iamServiceControlPoliciesServiceLinkedRole
+		} else {
+			sources.ServiceControlPolicies = serviceControlPolicies.Levels
+		}
+
+		permissions, err := evaluateIamEffectivePermissions(getIamActionCatalogue(), principal.Arn, sources, requestContext)
+		if err != nil {
+			return nil, err
+		}
+		for _, permission := range permissions {
+			if !streamListItem(ctx, d, awsIamPrincipalEffectivePermission{
+				PrincipalArn:               principal.Arn,
+				PrincipalName:              principal.Name,
+				PrincipalType:              principal.Type,
+				ServiceControlPolicyStatus: serviceControlPolicyStatus,
+				Permission:                 permission,
+			}) {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// iamAuthorizationDetailsPrincipals returns the users and roles of a snapshot, sorted by ARN
+func iamAuthorizationDetailsPrincipals(details *iamAuthorizationDetails) []awsIamPrincipal {
+	var principals []awsIamPrincipal
+	for _, user := range details.users {
+		principals = append(principals, awsIamPrincipal{
+			Arn:                 *user.Arn,
+			Name:                *user.UserName,
+			Type:                "user",
+			InlinePolicies:      user.UserPolicyList,
+			AttachedPolicies:    user.AttachedManagedPolicies,
+			GroupNames:          aws.StringValueSlice(user.GroupList),
+			PermissionsBoundary: user.PermissionsBoundary,
+		})
+	}
+	for _, role := range details.roles {
+		principals = append(principals, awsIamPrincipal{
+			Arn:                 *role.Arn,
+			Name:                *role.RoleName,
+			Type:                "role",
+			InlinePolicies:      role.RolePolicyList,
+			AttachedPolicies:    role.AttachedManagedPolicies,
+			PermissionsBoundary: role.PermissionsBoundary,
+		})
+	}
+	sort.Slice(principals, func(i, j int) bool {
+		return principals[i].Arn < principals[j].Arn
+	})
+	return principals
+}
+
+// isIamServiceLinkedRoleArn returns true for the ARN of a service-linked role, whose path starts
+// with /aws-service-role/
+func isIamServiceLinkedRoleArn(arn string) bool {
+	segments := strings.SplitN(arn, ":", 6)
+	return len(segments) == 6 && strings.HasPrefix(segments[5], "role/aws-service-role/")
+}
+
+// getIamPrincipalPolicySources returns the identity policies of a user or role, including those of
+// the groups of a user, and its permissions boundary
+func getIamPrincipalPolicySources(ctx context.Context, d *plugin.QueryData, details *iamAuthorizationDetails, principal awsIamPrincipal) (iamPrincipalPolicySources, error) {
+	var sources iamPrincipalPolicySources
+
+	identity, err := getIamPolicySources(ctx, d, principal.InlinePolicies, principal.AttachedPolicies, "")
+	if err != nil {
+		return sources, err
+	}
+	sources.Identity = identity
+
+	for _, groupName := range principal.GroupNames {
+		group := details.group(groupName)
+		if group == nil {
+			// created after the snapshot was loaded
+			continue
+		}
+		groupSources, err := getIamPolicySources(ctx, d, group.GroupPolicyList, group.AttachedManagedPolicies, groupName)
+		if err != nil {
+			return sources, err
+		}
+		sources.Identity = append(sources.Identity, groupSources...)
+	}
+
+	if principal.PermissionsBoundary != nil && principal.PermissionsBoundary.PermissionsBoundaryArn != nil {
+		boundaryArn := *principal.PermissionsBoundary.PermissionsBoundaryArn
+		policy, err := getIamManagedPolicy(ctx, d, boundaryArn)
+		if err != nil {
+			return sources, err
+		}
+		sources.PermissionsBoundary = &iamPolicySource{
+			Type:       iamPolicySourcePermissionsBoundary,
+			PolicyName: boundaryArn[strings.LastIndex(boundaryArn, "/")+1:],
+			PolicyArn:  boundaryArn,
+			Policy:     policy,
+		}
+	}
+
+	return sources, nil
+}
+
+// getIamPolicySources returns inline and attached managed policies in canonical form. The
+// policies are group policies if groupName is set.
+func getIamPolicySources(ctx context.Context, d *plugin.QueryData, inlinePolicyDetails []*iam.PolicyDetail, attachedPolicies []*iam.AttachedPolicy, groupName string) ([]iamPolicySource, error) {
+	inlineType, managedType := iamPolicySourceInline, iamPolicySourceManaged
+	if groupName != "" {
+		inlineType, managedType = iamPolicySourceGroupInline, iamPolicySourceGroupManaged
+	}
+
+	var sources []iamPolicySource
+
+	inlinePolicies, err := inlineIamPolicies(inlinePolicyDetails)
+	if err != nil {
+		return nil, err
+	}
+	inlinePoliciesStd, err := inlinePoliciesToStd(ctx, &transform.TransformData{HydrateItem: inlinePolicies})
+	if err != nil {
+		return nil, err
+	}
+	if inlinePoliciesStd != nil {
+		for _, inlinePolicy := range inlinePoliciesStd.([]map[string]interface{}) {
+			sources = append(sources, iamPolicySource{
+				Type:       inlineType,
+				PolicyName: inlinePolicy["PolicyName"].(string),
+				GroupName:  groupName,
+				Policy:     inlinePolicy["PolicyDocument"].(Policy),
+			})
+		}
+	}
+
+	for _, attachedPolicy := range attachedPolicies {
+		policy, err := getIamManagedPolicy(ctx, d, *attachedPolicy.PolicyArn)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, iamPolicySource{
+			Type:       managedType,
+			PolicyName: aws.StringValue(attachedPolicy.PolicyName),
+			PolicyArn:  *attachedPolicy.PolicyArn,
+			GroupName:  groupName,
+			Policy:     policy,
+		})
+	}
+
+	return sources, nil
+}
+
+// getIamServiceControlPolicies returns the service control policies of each level of the
+// organization of an account. They are only applied if the credentials can read them.
+func getIamServiceControlPolicies(ctx context.Context, d *plugin.QueryData, accountId string) (*awsIamServiceControlPolicies, error) {
+	cacheKey := scopedCacheKey(d, "ServiceControlPolicies")
+	if cachedData, ok := d.ConnectionManager.Cache.Get(cacheKey); ok {
+		return cachedData.(*awsIamServiceControlPolicies), nil
+	}
+
+	serviceControlPolicies, err := loadIamServiceControlPolicies(ctx, d, accountId)
+	if err != nil {
+		if a, ok := err.(awserr.Error); ok && a.Code() == organizations.ErrCodeAWSOrganizationsNotInUseException {
+			serviceControlPolicies = &awsIamServiceControlPolicies{Status: iamServiceControlPoliciesNotInOrganization}
+		} else if isIamAccessDeniedError(err) {
+			plugin.Logger(ctx).Warn("getIamServiceControlPolicies", "service control policies are not applied", err)
+			serviceControlPolicies = &awsIamServiceControlPolicies{Status: iamServiceControlPoliciesUnavailable}
+		} else {
+			return nil, err
+		}
+	}
+
+	// as fresh as the IAM authorization details they are applied to
+	d.ConnectionManager.Cache.SetWithTTL(cacheKey, serviceControlPolicies, iamAuthorizationDetailsTTL)
+	return serviceControlPolicies, nil
+}
+
+func loadIamServiceControlPolicies(ctx context.Context, d *plugin.QueryData, accountId string) (*awsIamServiceControlPolicies, error) {
+	// Create session
+	svc, err := OrganizationService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := svc.DescribeOrganization(&organizations.DescribeOrganizationInput{})
+	if err != nil {
+		return nil, err
+	}
+	if aws.StringValue(organization.Organization.MasterAccountId) == accountId {
+		return &awsIamServiceControlPolicies{Status: iamServiceControlPoliciesManagementAccount}, nil
+	}
+
+	// the targets from the account up to the root
+	targetIds := []string{accountId}
+	for {
+		parents, err := svc.ListParents(&organizations.ListParentsInput{ChildId: aws.String(targetIds[len(targetIds)-1])})
+		if err != nil {
+			return nil, err
+		}
+		if len(parents.Parents) == 0 {
+			break
+		}
+		parent := parents.Parents[0]
+		targetIds = append(targetIds, *parent.Id)
+		if aws.StringValue(parent.Type) == organizations.ParentTypeRoot {
+			enabled, err := isIamServiceControlPolicyTypeEnabled(svc, *parent.Id)
+			if err != nil {
+				return nil, err
+			}
+			if !enabled {
+				return &awsIamServiceControlPolicies{Status: iamServiceControlPoliciesNotEnabled}, nil
+			}
+			break
+		}
+	}
+
+	serviceControlPolicies := &awsIamServiceControlPolicies{Status: iamServiceControlPoliciesApplied}
+	for i := len(targetIds) - 1; i >= 0; i-- {
+		level := iamServiceControlPolicyLevel{TargetId: targetIds[i]}
+		var summaries []*organizations.PolicySummary
+		err := svc.ListPoliciesForTargetPages(
+			&organizations.ListPoliciesForTargetInput{
+				Filter:   aws.String(organizations.PolicyTypeServiceControlPolicy),
+				TargetId: aws.String(targetIds[i]),
+			},
+			func(page *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+				summaries = append(summaries, page.Policies...)
+				return !lastPage
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range summaries {
+			op, err := svc.DescribePolicy(&organizations.DescribePolicyInput{PolicyId: summary.Id})
+			if err != nil {
+				return nil, err
+			}
+			policy, err := canonicalPolicy(aws.StringValue(op.Policy.Content))
+			if err != nil {
+				return nil, err
+			}
+			level.Policies = append(level.Policies, iamPolicySource{
+				Type:       iamPolicySourceServiceControl,
+				PolicyName: aws.StringValue(summary.Name),
+				PolicyArn:  aws.StringValue(summary.Arn),
+				TargetId:   targetIds[i],
+				Policy:     policy.(Policy),
+			})
+		}
+		serviceControlPolicies.Levels = append(serviceControlPolicies.Levels, level)
+	}
+
+	return serviceControlPolicies, nil
+}
+
+// isIamServiceControlPolicyTypeEnabled returns true if service control policies are enabled in
+// the root of an organization
+func isIamServiceControlPolicyTypeEnabled(svc organizationsiface.OrganizationsAPI, rootId string) (bool, error) {
+	enabled := false
+	err := svc.ListRootsPages(&organizations.ListRootsInput{}, func(page *organizations.ListRootsOutput, lastPage bool) bool {
+		for _, root := range page.Roots {
+			if aws.StringValue(root.Id) != rootId {
+				continue
+			}
+			for _, policyType := range root.PolicyTypes {
+				if aws.StringValue(policyType.Type) == organizations.PolicyTypeServiceControlPolicy && aws.StringValue(policyType.Status) == organizations.PolicyTypeStatusEnabled {
+					enabled = true
+				}
+			}
+		}
+		return !lastPage
+	})
+	return enabled, err
+}
@@ -0,0 +1,165 @@
+package aws
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+func TestListIamPrincipalEffectivePermissions(t *testing.T) {
+	inlinePolicy := `{"Version":"2012-10-17","Statement":[{"Sid":"Objects","Effect":"Allow","Action":["s3:GetObject","s3:PutObject","s3:DeleteObject"],"Resource":"*"}]}`
+	boundaryPolicy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:DeleteObject"],"Resource":"*"}]}`
+	boundaryArn := "arn:aws:iam::123456789012:policy/boundary"
+	authorizationDetails := &iam.GetAccountAuthorizationDetailsOutput{
+		RoleDetailList: []*iam.RoleDetail{
+			{
+				RoleName: aws.String("deployer"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/deployer"),
+				RolePolicyList: []*iam.PolicyDetail{
+					{PolicyName: aws.String("objects"), PolicyDocument: aws.String(url.QueryEscape(inlinePolicy))},
+				},
+				PermissionsBoundary: &iam.AttachedPermissionsBoundary{
+					PermissionsBoundaryArn:  aws.String(boundaryArn),
+					PermissionsBoundaryType: aws.String(iam.PermissionsBoundaryAttachmentTypePermissionsBoundaryPolicy),
+				},
+			},
+			{
+				RoleName: aws.String("AWSServiceRoleForS3"),
+				Path:     aws.String("/aws-service-role/s3.amazonaws.com/"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/aws-service-role/s3.amazonaws.com/AWSServiceRoleForS3"),
+				RolePolicyList: []*iam.PolicyDetail{
+					{PolicyName: aws.String("objects"), PolicyDocument: aws.String(url.QueryEscape(inlinePolicy))},
+				},
+			},
+			{
+				RoleName: aws.String("other"),
+				Arn:      aws.String("arn:aws:iam::123456789012:role/other"),
+				RolePolicyList: []*iam.PolicyDetail{
+					{PolicyName: aws.String("objects"), PolicyDocument: aws.String(url.QueryEscape(inlinePolicy))},
+				},
+			},
+		},
+		Policies: []*iam.ManagedPolicyDetail{
+			{
+				PolicyName:       aws.String("boundary"),
+				Arn:              aws.String(boundaryArn),
+				DefaultVersionId: aws.String("v1"),
+				PolicyVersionList: []*iam.PolicyVersion{
+					{VersionId: aws.String("v1"), IsDefaultVersion: aws.Bool(true), Document: aws.String(url.QueryEscape(boundaryPolicy))},
+				},
+			},
+		},
+	}
+	fullAwsAccess := &organizations.Policy{
+		PolicySummary: &organizations.PolicySummary{Id: aws.String("p-FullAWSAccess"), Name: aws.String("FullAWSAccess")},
+		Content:       aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"}]}`),
+	}
+	organization := &fakeOrganizations{
+		Organization: &organizations.Organization{MasterAccountId: aws.String("111111111111")},
+		Roots: []*organizations.Root{
+			{Id: aws.String("r-root"), PolicyTypes: []*organizations.PolicyTypeSummary{
+				{Type: aws.String(organizations.PolicyTypeServiceControlPolicy), Status: aws.String(organizations.PolicyTypeStatusEnabled)},
+			}},
+		},
+		Parents: map[string]*organizations.Parent{
+			"123456789012": {Id: aws.String("ou-1"), Type: aws.String(organizations.ParentTypeOrganizationalUnit)},
+			"ou-1":         {Id: aws.String("r-root"), Type: aws.String(organizations.ParentTypeRoot)},
+		},
+		// each target has at least one SCP
+		Policies: map[string][]*organizations.Policy{
+			"r-root":       {fullAwsAccess},
+			"123456789012": {fullAwsAccess},
+			"ou-1": {
+				{
+					PolicySummary: &organizations.PolicySummary{Id: aws.String("p-nodelete"), Name: aws.String("NoDelete")},
+					Content:       aws.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Sid":"NoDelete","Effect":"Deny","Action":"s3:Delete*","Resource":"*"}]}`),
+				},
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		name              string
+		principalArn      string
+		organizationError error
+		expectedStatus    string
+		expected          map[string]interface{}
+	}{
+		{
+			name:           "service control policies",
+			expectedStatus: iamServiceControlPoliciesApplied,
+			expected: map[string]interface{}{
+				"s3:getobject":    nil,
+				"s3:putobject":    []interface{}{map[string]interface{}{"Type": "permissions_boundary", "PolicyName": "boundary", "PolicyArn": boundaryArn, "Decision": "implicitDeny", "Actions": []interface{}{"s3:putobject"}}},
+				"s3:deleteobject": []interface{}{map[string]interface{}{"Type": "service_control_policy", "PolicyName": "NoDelete", "TargetId": "ou-1", "Sids": []interface{}{"NoDelete"}, "Decision": "explicitDeny", "Actions": []interface{}{"s3:deleteobject"}}},
+			},
+		},
+		{
+			name:           "service-linked role",
+			principalArn:   "arn:aws:iam::123456789012:role/aws-service-role/s3.amazonaws.com/AWSServiceRoleForS3",
+			expectedStatus: iamServiceControlPoliciesServiceLinkedRole,
+			// the SCP denying s3:Delete* doesn't apply
+			expected: map[string]interface{}{
+				"s3:getobject":    nil,
+				"s3:putobject":    nil,
+				"s3:deleteobject": nil,
+			},
+		},
+		{
+			name:              "not in an organization",
+			organizationError: awserr.New(organizations.ErrCodeAWSOrganizationsNotInUseException, "Your account is not a member of an organization.", nil),
+			expectedStatus:    iamServiceControlPoliciesNotInOrganization,
+			expected: map[string]interface{}{
+				"s3:getobject":    nil,
+				"s3:putobject":    []interface{}{map[string]interface{}{"Type": "permissions_boundary", "PolicyName": "boundary", "PolicyArn": boundaryArn, "Decision": "implicitDeny", "Actions": []interface{}{"s3:putobject"}}},
+				"s3:deleteobject": nil,
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			organization.OrganizationError = testCase.organizationError
+			defer registerServiceClient("iam", &fakeIAM{AuthorizationDetails: authorizationDetails})()
+			defer registerServiceClient("organizations", organization)()
+			defer registerServiceClient("ec2", &fakeEC2{Regions: []string{"us-east-1"}})()
+			defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "123456789012")})()
+
+			principalArn := testCase.principalArn
+			if principalArn == "" {
+				principalArn = "arn:aws:iam::123456789012:role/deployer"
+			}
+
+			p := newFakeTableTestPlugin(t, "test_iam_principal_effective_permission_"+testCase.name)
+			stream := executeTableTestQuery(t, p, "aws_iam_principal_effective_permission", []string{"principal_arn", "action", "decision", "trimmed_by", "service_control_policy_status"}, map[string]string{"principal_arn": principalArn}, 0)
+
+			if len(stream.rows) == 0 {
+				t.Fatal("expected the permissions of the principal")
+			}
+			trims := map[string]interface{}{}
+			for _, row := range stream.rows {
+				if row["principal_arn"] != principalArn {
+					t.Errorf("unexpected principal %v", row["principal_arn"])
+				}
+				if row["service_control_policy_status"] != testCase.expectedStatus {
+					t.Errorf("expected service control policy status %s, got %v", testCase.expectedStatus, row["service_control_policy_status"])
+				}
+				trims[row["action"].(string)] = row["trimmed_by"]
+
+				expectedDecision := iamEffectivePermissionAllowed
+				if testCase.expected[row["action"].(string)] != nil {
+					expectedDecision = iamEffectivePermissionDenied
+				}
+				if row["decision"] != expectedDecision {
+					t.Errorf("expected %s to be %s, got %v", row["action"], expectedDecision, row["decision"])
+				}
+			}
+			if !reflect.DeepEqual(trims, testCase.expected) {
+				t.Errorf("expected trims %v, got %v", testCase.expected, trims)
+			}
+		})
+	}
+}
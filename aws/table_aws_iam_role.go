@@ -13,6 +13,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 )
 
@@ -35,6 +36,10 @@ func tableAwsIamRole(_ context.Context) *plugin.Table {
 				Func:    getAwsIamRoleInlinePolicies,
 				Depends: []plugin.HydrateFunc{listAwsIamRoleInlinePolicies},
 			},
+			{
+				Func:    getAwsIamRoleExpandedActions,
+				Depends: []plugin.HydrateFunc{getAwsIamRoleInlinePolicies, getAwsIamRoleAttachedPolicies},
+			},
 		},
 		Columns: awsColumns([]*plugin.Column{
 			// "Key" Columns
@@ -143,6 +148,13 @@ func tableAwsIamRole(_ context.Context) *plugin.Table {
 				Hydrate:     getAwsIamRoleAttachedPolicies,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "actions_expanded",
+				Description: "The concrete actions allowed or denied by the inline and attached managed policies of the role, with their access level. Wildcards and NotAction are expanded with the actions of the aws_iam_action table. Permissions boundaries are not applied.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleExpandedActions,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "assume_role_policy",
 				Description: "The policy that grants an entity permission to assume the role.",
@@ -195,7 +207,9 @@ func listIamRoles(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		&iam.ListRolesInput{},
 		func(page *iam.ListRolesOutput, lastPage bool) bool {
 			for _, role := range page.Roles {
-				d.StreamListItem(ctx, role)
+				if !streamListItem(ctx, d, role) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -218,6 +232,19 @@ func getIamRole(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	if h.Item != nil {
 		data := h.Item.(*iam.Role)
 		name = types.SafeString(data.RoleName)
+
+		details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+		if err != nil {
+			return nil, err
+		}
+		if detail := details.role(name); detail != nil {
+			// the listed role has the other columns of GetRole
+			role := *data
+			role.PermissionsBoundary = detail.PermissionsBoundary
+			role.RoleLastUsed = detail.RoleLastUsed
+			role.Tags = detail.Tags
+			return &role, nil
+		}
 	} else {
 		name = d.KeyColumnQuals["name"].GetStringValue()
 		arn := d.KeyColumnQuals["arn"].GetStringValue()
@@ -243,14 +270,24 @@ func getAwsIamInstanceProfileData(ctx context.Context, d *plugin.QueryData, h *p
 	logger.Trace("getAwsIamInstanceProfileData")
 	role := h.Item.(*iam.Role)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	var associatedInstanceProfileArns []string
+	if detail := details.role(*role.RoleName); detail != nil {
+		for _, instanceProfile := range detail.InstanceProfileList {
+			associatedInstanceProfileArns = append(associatedInstanceProfileArns, *instanceProfile.Arn)
+		}
+		return associatedInstanceProfileArns, nil
+	}
+
 	// create service
 	svc, err := IAMService(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
-	var associatedInstanceProfileArns []string
-
 	params := &iam.ListInstanceProfilesForRoleInput{
 		RoleName: role.RoleName,
 	}
@@ -273,6 +310,14 @@ func getAwsIamRoleAttachedPolicies(ctx context.Context, d *plugin.QueryData, h *
 	logger.Trace("getAwsIamRoleAttachedPolicies")
 	role := h.Item.(*iam.Role)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.role(*role.RoleName); detail != nil {
+		return attachedIamPolicyArns(detail.AttachedManagedPolicies), nil
+	}
+
 	// create service
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -304,6 +349,14 @@ func listAwsIamRoleInlinePolicies(ctx context.Context, d *plugin.QueryData, h *p
 	logger.Trace("listAwsIamRoleInlinePolicies")
 	role := h.Item.(*iam.Role)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.role(*role.RoleName); detail != nil {
+		return &iam.ListRolePoliciesOutput{PolicyNames: inlineIamPolicyNames(detail.RolePolicyList)}, nil
+	}
+
 	// create service
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -326,6 +379,15 @@ func getAwsIamRoleInlinePolicies(ctx context.Context, d *plugin.QueryData, h *pl
 	logger := plugin.Logger(ctx)
 	logger.Trace("getAwsIamRoleInlinePolicies")
 	role := h.Item.(*iam.Role)
+
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.role(*role.RoleName); detail != nil {
+		return inlineIamPolicies(detail.RolePolicyList)
+	}
+
 	listRolePoliciesOutput := h.HydrateResults["listAwsIamRoleInlinePolicies"].(*iam.ListRolePoliciesOutput)
 
 	// Create Session
@@ -362,7 +424,7 @@ func getAwsIamRoleInlinePolicies(ctx context.Context, d *plugin.QueryData, h *pl
 	return rolePolicies, nil
 }
 
-func getRolePolicyDataAsync(policy *string, roleName *string, svc *iam.IAM, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
+func getRolePolicyDataAsync(policy *string, roleName *string, svc iamiface.IAMAPI, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
 	defer wg.Done()
 
 	rowData, err := getRoleInlinePolicy(policy, roleName, svc)
@@ -373,7 +435,7 @@ func getRolePolicyDataAsync(policy *string, roleName *string, svc *iam.IAM, wg *
 	}
 }
 
-func getRoleInlinePolicy(policyName *string, roleName *string, svc *iam.IAM) (map[string]interface{}, error) {
+func getRoleInlinePolicy(policyName *string, roleName *string, svc iamiface.IAMAPI) (map[string]interface{}, error) {
 	rolePolicy := make(map[string]interface{})
 	params := &iam.GetRolePolicyInput{
 		PolicyName: policyName,
@@ -406,6 +468,20 @@ func getRoleInlinePolicy(policyName *string, roleName *string, svc *iam.IAM) (ma
 	return rolePolicy, nil
 }
 
+func getAwsIamRoleExpandedActions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	plugin.Logger(ctx).Trace("getAwsIamRoleExpandedActions")
+
+	inlinePolicies, _ := h.HydrateResults["getAwsIamRoleInlinePolicies"].([]map[string]interface{})
+	attachedPolicyArns, _ := h.HydrateResults["getAwsIamRoleAttachedPolicies"].([]string)
+
+	policies, err := getIamPrincipalPolicies(ctx, d, inlinePolicies, attachedPolicyArns)
+	if err != nil {
+		return nil, err
+	}
+
+	return getIamActionCatalogue().expandPolicyActions(policies), nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func getIamRoleTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
@@ -0,0 +1,329 @@
+package aws
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+// Types of the principals trusted by a role
+const (
+	iamTrustedPrincipalAws      = "aws"
+	iamTrustedPrincipalService  = "service"
+	iamTrustedPrincipalSaml     = "saml"
+	iamTrustedPrincipalOidc     = "oidc"
+	iamTrustedPrincipalWildcard = "wildcard"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamRoleTrustRelationship(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_role_trust_relationship",
+		Description: "AWS IAM Role Trust Relationship, the principals trusted by the assume role policy of each role",
+		List: &plugin.ListConfig{
+			Hydrate: listIamRoleTrustRelationships,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "role_name", Require: plugin.Optional},
+			},
+		},
+		Columns: awsColumns([]*plugin.Column{
+			{
+				Name:        "role_name",
+				Description: "The friendly name that identifies the role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "role_arn",
+				Description: "The Amazon Resource Name (ARN) specifying the role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "statement_index",
+				Description: "The index of the statement in the assume role policy, starting at 0.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "sid",
+				Description: "The Sid of the statement.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "actions",
+				Description: "The actions the statement allows, e.g. sts:AssumeRole or sts:AssumeRoleWithWebIdentity.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the trusted principal: aws for an account or an IAM principal, service, saml or oidc for a federated identity provider, or wildcard for any principal.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal",
+				Description: "The trusted principal, e.g. an account ID, an IAM principal ARN, a service or an identity provider.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_account_id",
+				Description: "The account of an AWS principal or identity provider ARN.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "external_account_id",
+				Description: "The account of the principal if it is not the account of the role, e.g. the account of a third party.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "external_id_required",
+				Description: "True if the statement requires the sts:ExternalId of the request to have one of the values of external_ids.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "external_ids",
+				Description: "The external IDs the statement requires.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "mfa_required",
+				Description: "True if the statement requires the principal to be authenticated with MFA, with aws:MultiFactorAuthPresent or aws:MultiFactorAuthAge.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "oidc_provider",
+				Description: "The URL of the OIDC provider, e.g. token.actions.githubusercontent.com.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "oidc_subjects",
+				Description: "The subjects (sub) of the OIDC tokens the statement requires, e.g. repo:octo-org/octo-repo:ref:refs/heads/main. Empty if any subject of the provider can assume the role.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "oidc_audiences",
+				Description: "The audiences (aud) of the OIDC tokens the statement requires, e.g. sts.amazonaws.com.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "condition",
+				Description: "The conditions of the statement, in canonical form.",
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+type awsIamRoleTrustRelationship struct {
+	RoleName           string
+	RoleArn            string
+	StatementIndex     int
+	Sid                string
+	Actions            []string
+	PrincipalType      string
+	Principal          string
+	PrincipalAccountId string
+	ExternalAccountId  string
+	ExternalIdRequired bool
+	ExternalIds        []string
+	MfaRequired        bool
+	OidcProvider       string
+	OidcSubjects       []string
+	OidcAudiences      []string
+	Condition          map[string]interface{}
+}
+
+//// LIST FUNCTION
+
+func listIamRoleTrustRelationships(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create Session
+	svc, err := IAMService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	roleName := d.KeyColumnQuals["role_name"].GetStringValue()
+	if roleName != "" {
+		op, err := svc.GetRole(&iam.GetRoleInput{RoleName: aws.String(roleName)})
+		if err != nil {
+			if a, ok := err.(awserr.Error); ok && a.Code() == iam.ErrCodeNoSuchEntityException {
+				return nil, nil
+			}
+			return nil, err
+		}
+		_, err = streamIamRoleTrustRelationships(ctx, d, op.Role)
+		return nil, err
+	}
+
+	var streamErr error
+	err = svc.ListRolesPages(&iam.ListRolesInput{}, func(page *iam.ListRolesOutput, lastPage bool) bool {
+		for _, role := range page.Roles {
+			more, err := streamIamRoleTrustRelationships(ctx, d, role)
+			if err != nil {
+				streamErr = err
+				return false
+			}
+			if !more {
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, streamErr
+}
+
+//// UTILITY FUNCTIONS
+
+// streamIamRoleTrustRelationships streams the trust relationships of a role, and returns false
+// once the query has all the rows it needs
+func streamIamRoleTrustRelationships(ctx context.Context, d *plugin.QueryData, role *iam.Role) (bool, error) {
+	if role.AssumeRolePolicyDocument == nil {
+		return true, nil
+	}
+	document, err := url.QueryUnescape(*role.AssumeRolePolicyDocument)
+	if err != nil {
+		return false, err
+	}
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		return false, err
+	}
+
+	for _, relationship := range iamRoleTrustRelationships(policy.(Policy), *role.Arn) {
+		relationship.RoleName = aws.StringValue(role.RoleName)
+		if !streamListItem(ctx, d, relationship) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// iamRoleTrustRelationships returns a trust relationship per principal of the Allow statements
+// of the assume role policy of a role, in canonical form. A NotPrincipal trusts any principal but
+// the listed ones, so it is a wildcard principal.
+func iamRoleTrustRelationships(policy Policy, roleArn string) []awsIamRoleTrustRelationship {
+	roleAccountId := awsPrincipalAccountId(roleArn)
+
+	var relationships []awsIamRoleTrustRelationship
+	for i, statement := range policy.Statements {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		externalIds, externalIdRequired := iamRequiredConditionValues(statement.Condition, func(key string) bool {
+			return key == "sts:externalid"
+		})
+		mfaRequired := iamConditionsRequireMfa(statement.Condition)
+
+		principals := map[string][]string{}
+		if statement.NotPrincipal != nil {
+			principals[iamTrustedPrincipalWildcard] = []string{"*"}
+		} else {
+			for principalType, values := range statement.Principal {
+				principalValues, _ := values.([]string)
+				principals[principalType] = principalValues
+			}
+		}
+
+		for _, principalType := range []string{iamTrustedPrincipalWildcard, "AWS", "Service", "Federated", "CanonicalUser"} {
+			for _, principal := range principals[principalType] {
+				relationship := awsIamRoleTrustRelationship{
+					RoleArn:            roleArn,
+					StatementIndex:     i,
+					Sid:                statement.Sid,
+					Actions:            statement.Action,
+					Principal:          principal,
+					ExternalIdRequired: externalIdRequired,
+					ExternalIds:        externalIds,
+					MfaRequired:        mfaRequired,
+				}
+				if len(statement.Condition) > 0 {
+					relationship.Condition = statement.Condition
+				}
+
+				switch {
+				case principal == "*":
+					relationship.PrincipalType = iamTrustedPrincipalWildcard
+				case principalType == "Service":
+					relationship.PrincipalType = iamTrustedPrincipalService
+				case principalType == "Federated" && strings.Contains(principal, ":saml-provider/"):
+					relationship.PrincipalType = iamTrustedPrincipalSaml
+				case principalType == "Federated":
+					// an IAM OIDC provider, or a web identity provider such as cognito-identity.amazonaws.com
+					relationship.PrincipalType = iamTrustedPrincipalOidc
+					relationship.OidcProvider = principal
+					if index := strings.Index(principal, ":oidc-provider/"); index >= 0 {
+						relationship.OidcProvider = principal[index+len(":oidc-provider/"):]
+					}
+					provider := strings.ToLower(relationship.OidcProvider)
+					relationship.OidcSubjects, _ = iamRequiredConditionValues(statement.Condition, func(key string) bool {
+						return key == provider+":sub"
+					})
+					relationship.OidcAudiences, _ = iamRequiredConditionValues(statement.Condition, func(key string) bool {
+						return key == provider+":aud"
+					})
+				default:
+					relationship.PrincipalType = iamTrustedPrincipalAws
+				}
+
+				relationship.PrincipalAccountId = awsPrincipalAccountId(principal)
+				if relationship.PrincipalAccountId != roleAccountId {
+					relationship.ExternalAccountId = relationship.PrincipalAccountId
+				}
+				relationships = append(relationships, relationship)
+			}
+		}
+	}
+	return relationships
+}
+
+// iamRequiredConditionValues returns the values the conditions of a statement require for the
+// matching keys, sorted, and true if there are some. Only the conditions which deny requests
+// without the key are considered, i.e. not IfExists, ForAllValues or negated conditions.
+func iamRequiredConditionValues(conditions map[string]interface{}, matchesKey func(string) bool) ([]string, bool) {
+	var values []string
+	for operator, condition := range conditions {
+		name := strings.TrimPrefix(strings.ToLower(operator), "foranyvalue:")
+		definition, ok := iamConditionOperators[name]
+		if !ok || definition.negated {
+			continue
+		}
+		keys, _ := condition.(map[string]interface{})
+		for key, conditionValues := range keys {
+			if matchesKey(key) {
+				keyValues, _ := conditionValues.([]string)
+				values = append(values, keyValues...)
+			}
+		}
+	}
+	values = sortedUniqueStrings(values)
+	return values, len(values) > 0
+}
+
+// iamConditionsRequireMfa returns true if the conditions of a statement require the principal
+// to be authenticated with MFA
+func iamConditionsRequireMfa(conditions map[string]interface{}) bool {
+	for operator, condition := range conditions {
+		keys, _ := condition.(map[string]interface{})
+		for key, conditionValues := range keys {
+			values, _ := conditionValues.([]string)
+			switch {
+			case key != "aws:multifactorauthpresent" && key != "aws:multifactorauthage":
+			case strings.EqualFold(operator, "Bool") && key == "aws:multifactorauthpresent" && len(values) == 1 && strings.EqualFold(values[0], "true"):
+				return true
+			case strings.EqualFold(operator, "Null") && len(values) == 1 && strings.EqualFold(values[0], "false"):
+				return true
+			case key == "aws:multifactorauthage" && (strings.EqualFold(operator, "NumericLessThan") || strings.EqualFold(operator, "NumericLessThanEquals")):
+				return true
+			}
+		}
+	}
+	return false
+}
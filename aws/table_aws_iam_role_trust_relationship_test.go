@@ -0,0 +1,126 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIamRoleTrustRelationships(t *testing.T) {
+	roleArn := "arn:aws:iam::111111111111:role/trusted"
+
+	for _, testCase := range []struct {
+		name     string
+		policy   string
+		expected []awsIamRoleTrustRelationship
+	}{
+		{
+			name:   "service",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"Service": "ec2.amazonaws.com"}, "Action": "sts:AssumeRole"}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalService, Principal: "ec2.amazonaws.com"},
+			},
+		},
+		{
+			name:   "third party with external id",
+			policy: `{"Statement": {"Sid": "Vendor", "Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::222222222222:root", "arn:aws:iam::111111111111:role/admin"]}, "Action": "sts:AssumeRole", "Condition": {"StringEquals": {"sts:ExternalId": "secret"}}}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{Sid: "Vendor", PrincipalType: iamTrustedPrincipalAws, Principal: "arn:aws:iam::111111111111:role/admin", PrincipalAccountId: "111111111111", ExternalIdRequired: true, ExternalIds: []string{"secret"}},
+				{Sid: "Vendor", PrincipalType: iamTrustedPrincipalAws, Principal: "arn:aws:iam::222222222222:root", PrincipalAccountId: "222222222222", ExternalAccountId: "222222222222", ExternalIdRequired: true, ExternalIds: []string{"secret"}},
+			},
+		},
+		{
+			name:   "external id if exists is not required",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "222222222222"}, "Action": "sts:AssumeRole", "Condition": {"StringEqualsIfExists": {"sts:ExternalId": "secret"}}}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalAws, Principal: "222222222222", PrincipalAccountId: "222222222222", ExternalAccountId: "222222222222"},
+			},
+		},
+		{
+			name:   "mfa",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111111111111:root"}, "Action": "sts:AssumeRole", "Condition": {"Bool": {"aws:MultiFactorAuthPresent": "true"}}}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalAws, Principal: "arn:aws:iam::111111111111:root", PrincipalAccountId: "111111111111", MfaRequired: true},
+			},
+		},
+		{
+			name:   "mfa age",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::111111111111:root"}, "Action": "sts:AssumeRole", "Condition": {"NumericLessThan": {"aws:MultiFactorAuthAge": "3600"}}}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalAws, Principal: "arn:aws:iam::111111111111:root", PrincipalAccountId: "111111111111", MfaRequired: true},
+			},
+		},
+		{
+			name: "github actions",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"Federated": "arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com"}, "Action": "sts:AssumeRoleWithWebIdentity", "Condition": {
+				"StringEquals": {"token.actions.githubusercontent.com:aud": "sts.amazonaws.com"},
+				"StringLike": {"token.actions.githubusercontent.com:sub": ["repo:octo-org/octo-repo:ref:refs/heads/main", "repo:octo-org/octo-repo:environment:prod"]}
+			}}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{
+					PrincipalType:      iamTrustedPrincipalOidc,
+					Principal:          "arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com",
+					PrincipalAccountId: "111111111111",
+					OidcProvider:       "token.actions.githubusercontent.com",
+					OidcSubjects:       []string{"repo:octo-org/octo-repo:environment:prod", "repo:octo-org/octo-repo:ref:refs/heads/main"},
+					OidcAudiences:      []string{"sts.amazonaws.com"},
+				},
+			},
+		},
+		{
+			name:   "oidc without subject",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"Federated": "arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com"}, "Action": "sts:AssumeRoleWithWebIdentity"}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{
+					PrincipalType:      iamTrustedPrincipalOidc,
+					Principal:          "arn:aws:iam::111111111111:oidc-provider/token.actions.githubusercontent.com",
+					PrincipalAccountId: "111111111111",
+					OidcProvider:       "token.actions.githubusercontent.com",
+				},
+			},
+		},
+		{
+			name:   "saml",
+			policy: `{"Statement": {"Effect": "Allow", "Principal": {"Federated": "arn:aws:iam::111111111111:saml-provider/okta"}, "Action": "sts:AssumeRoleWithSAML"}}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalSaml, Principal: "arn:aws:iam::111111111111:saml-provider/okta", PrincipalAccountId: "111111111111"},
+			},
+		},
+		{
+			name:   "wildcard",
+			policy: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "sts:AssumeRole"}, {"Effect": "Deny", "Principal": "*", "Action": "sts:AssumeRole"}]}`,
+			expected: []awsIamRoleTrustRelationship{
+				{PrincipalType: iamTrustedPrincipalWildcard, Principal: "*"},
+			},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			policy, err := canonicalPolicy(testCase.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := iamRoleTrustRelationships(policy.(Policy), roleArn)
+
+			// only the principal and condition analysis is compared
+			for i := range actual {
+				if actual[i].RoleArn != roleArn {
+					t.Errorf("expected role %s, got %s", roleArn, actual[i].RoleArn)
+				}
+				actual[i].RoleArn = ""
+				actual[i].Actions = nil
+				actual[i].Condition = nil
+				if len(actual[i].ExternalIds) == 0 {
+					actual[i].ExternalIds = nil
+				}
+				if len(actual[i].OidcSubjects) == 0 {
+					actual[i].OidcSubjects = nil
+				}
+				if len(actual[i].OidcAudiences) == 0 {
+					actual[i].OidcAudiences = nil
+				}
+			}
+			if !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -163,7 +164,9 @@ func listIamUsers(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDat
 		&iam.ListUsersInput{},
 		func(page *iam.ListUsersOutput, lastPage bool) bool {
 			for _, user := range page.Users {
-				d.StreamListItem(ctx, user)
+				if !streamListItem(ctx, d, user) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -205,19 +208,31 @@ func getAwsIamUserData(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	plugin.Logger(ctx).Trace("getAwsIamUserData")
 	user := h.Item.(*iam.User)
 
-	// Create Session
-	svc, err := IAMService(ctx, d)
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
 	if err != nil {
 		return nil, err
 	}
 
-	params := &iam.GetUserInput{
-		UserName: user.UserName,
-	}
+	var userData *iam.GetUserOutput
+	if detail := details.user(*user.UserName); detail != nil {
+		userData = &iam.GetUserOutput{
+			User: &iam.User{Tags: detail.Tags, PermissionsBoundary: detail.PermissionsBoundary},
+		}
+	} else {
+		// Create Session
+		svc, err := IAMService(ctx, d)
+		if err != nil {
+			return nil, err
+		}
 
-	userData, _ := svc.GetUser(params)
-	if err != nil {
-		return nil, err
+		params := &iam.GetUserInput{
+			UserName: user.UserName,
+		}
+
+		userData, _ = svc.GetUser(params)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var tags []*iam.Tag
@@ -251,6 +266,14 @@ func getAwsIamUserAttachedPolicies(ctx context.Context, d *plugin.QueryData, h *
 	plugin.Logger(ctx).Trace("getAwsIamUserAttachedPolicies")
 	user := h.Item.(*iam.User)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.user(*user.UserName); detail != nil {
+		return attachedIamPolicyArns(detail.AttachedManagedPolicies), nil
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -281,6 +304,16 @@ func getAwsIamUserGroups(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	plugin.Logger(ctx).Trace("getAwsIamUserGroups")
 	user := h.Item.(*iam.User)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.user(*user.UserName); detail != nil {
+		if groups, ok := details.userGroups(detail); ok {
+			return &iam.ListGroupsForUserOutput{Groups: groups}, nil
+		}
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -325,6 +358,14 @@ func listAwsIamUserInlinePolicies(ctx context.Context, d *plugin.QueryData, h *p
 	plugin.Logger(ctx).Trace("listAwsIamUserInlinePolicies")
 	user := h.Item.(*iam.User)
 
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.user(*user.UserName); detail != nil {
+		return &iam.ListUserPoliciesOutput{PolicyNames: inlineIamPolicyNames(detail.UserPolicyList)}, nil
+	}
+
 	// Create Session
 	svc, err := IAMService(ctx, d)
 	if err != nil {
@@ -346,6 +387,15 @@ func listAwsIamUserInlinePolicies(ctx context.Context, d *plugin.QueryData, h *p
 func getAwsIamUserInlinePolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getAwsIamUserInlinePolicies")
 	user := h.Item.(*iam.User)
+
+	details, err := getIamAuthorizationDetails(ctx, d, iamAuthorizationDetailsLocal)
+	if err != nil {
+		return nil, err
+	}
+	if detail := details.user(*user.UserName); detail != nil {
+		return inlineIamPolicies(detail.UserPolicyList)
+	}
+
 	listUserPoliciesOutput := h.HydrateResults["listAwsIamUserInlinePolicies"].(*iam.ListUserPoliciesOutput)
 
 	// Create Session
@@ -383,7 +433,7 @@ func getAwsIamUserInlinePolicies(ctx context.Context, d *plugin.QueryData, h *pl
 	return userPolicies, nil
 }
 
-func getUserPolicyDataAsync(policy *string, userName *string, svc *iam.IAM, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
+func getUserPolicyDataAsync(policy *string, userName *string, svc iamiface.IAMAPI, wg *sync.WaitGroup, policyCh chan map[string]interface{}, errorCh chan error) {
 	defer wg.Done()
 
 	rowData, err := getUserInlinePolicy(policy, userName, svc)
@@ -394,7 +444,7 @@ func getUserPolicyDataAsync(policy *string, userName *string, svc *iam.IAM, wg *
 	}
 }
 
-func getUserInlinePolicy(policyName *string, userName *string, svc *iam.IAM) (map[string]interface{}, error) {
+func getUserInlinePolicy(policyName *string, userName *string, svc iamiface.IAMAPI) (map[string]interface{}, error) {
 	userPolicy := make(map[string]interface{})
 	params := &iam.GetUserPolicyInput{
 		PolicyName: policyName,
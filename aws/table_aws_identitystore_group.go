@@ -23,7 +23,7 @@ func tableAwsIdentityStoreGroup(_ context.Context) *plugin.Table {
 			KeyColumns: plugin.AllColumns([]string{"identity_store_id", "name"}),
 			Hydrate:    listIdentityStoreGroups,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(identitystore.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "identity_store_id",
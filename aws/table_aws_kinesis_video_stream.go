@@ -24,7 +24,7 @@ func tableAwsKinesisVideoStream(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listKinesisVideoStreams,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(kinesisvideo.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "stream_name",
@@ -112,7 +112,9 @@ func listKinesisVideoStreams(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&kinesisvideo.ListStreamsInput{},
 		func(page *kinesisvideo.ListStreamsOutput, isLast bool) bool {
 			for _, stream := range page.StreamInfoList {
-				d.StreamListItem(ctx, stream)
+				if !streamListItem(ctx, d, stream) {
+					return false
+				}
 			}
 			return !isLast
 		},
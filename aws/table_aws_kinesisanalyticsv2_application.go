@@ -24,7 +24,7 @@ func tableAwsKinesisAnalyticsV2Application(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listKinesisAnalyticsV2Applications,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(kinesisanalyticsv2.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "application_name",
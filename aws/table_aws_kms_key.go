@@ -188,7 +188,9 @@ func listKmsKeys(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		&kms.ListKeysInput{},
 		func(page *kms.ListKeysOutput, lastPage bool) bool {
 			for _, key := range page.Keys {
-				d.StreamListItem(ctx, key)
+				if !streamListItem(ctx, d, key) {
+					return false
+				}
 			}
 			return !lastPage
 		},
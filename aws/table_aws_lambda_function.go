@@ -236,7 +236,9 @@ func listAwsLambdaFunctions(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		&lambda.ListFunctionsInput{},
 		func(page *lambda.ListFunctionsOutput, lastPage bool) bool {
 			for _, function := range page.Functions {
-				d.StreamListItem(ctx, function)
+				if !streamListItem(ctx, d, function) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go/service/lambda"
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -102,25 +101,18 @@ func listLambdaLayers(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 		return nil, err
 	}
 
-	// Set MaxItems to the maximum number allowed
+	// Set MaxItems to the maximum number allowed, or less if the user has only requested a small number of rows
 	input := lambda.ListLayersInput{
-		MaxItems: types.Int64(50),
-	}
-
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxItems {
-			input.MaxItems = limit
-		}
+		MaxItems: listPageSize(d, 1, 50),
 	}
 
 	err = svc.ListLayersPages(
 		&input,
 		func(page *lambda.ListLayersOutput, lastPage bool) bool {
 			for _, layer := range page.Layers {
-				d.StreamListItem(ctx, layer)
+				if !streamListItem(ctx, d, layer) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -5,7 +5,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/lambda"
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -135,26 +134,17 @@ func listLambdaLayerVersions(ctx context.Context, d *plugin.QueryData, h *plugin
 
 	layerName := h.Item.(*lambda.LayersListItem).LayerName
 
-	// Set MaxItems to the maximum number allowed
+	// Set MaxItems to the maximum number allowed, or less if the user has only requested a small number of rows
 	input := lambda.ListLayerVersionsInput{
 		LayerName: layerName,
-		MaxItems:  types.Int64(50),
-	}
-
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxItems {
-			input.MaxItems = limit
-		}
+		MaxItems:  listPageSize(d, 1, 50),
 	}
 
 	err = svc.ListLayerVersionsPages(
 		&input,
 		func(page *lambda.ListLayerVersionsOutput, lastPage bool) bool {
 			for _, version := range page.LayerVersions {
-				d.StreamListItem(ctx, LayerVersionInfo{*layerName, lambda.GetLayerVersionOutput{
+				layerVersion := LayerVersionInfo{*layerName, lambda.GetLayerVersionOutput{
 					CompatibleArchitectures: version.CompatibleArchitectures,
 					CompatibleRuntimes:      version.CompatibleRuntimes,
 					CreatedDate:             version.CreatedDate,
@@ -162,7 +152,10 @@ func listLambdaLayerVersions(ctx context.Context, d *plugin.QueryData, h *plugin
 					LayerVersionArn:         version.LayerVersionArn,
 					LicenseInfo:             version.LicenseInfo,
 					Version:                 version.Version,
-				}})
+				}}
+				if !streamListItem(ctx, d, layerVersion) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -176,10 +176,15 @@ func listLambdaVersions(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	function := h.Item.(*lambda.FunctionConfiguration)
 
 	err = svc.ListVersionsByFunctionPages(
-		&lambda.ListVersionsByFunctionInput{FunctionName: function.FunctionName},
+		&lambda.ListVersionsByFunctionInput{
+			FunctionName: function.FunctionName,
+			MaxItems:     listPageSize(d, 1, 50),
+		},
 		func(page *lambda.ListVersionsByFunctionOutput, lastPage bool) bool {
 			for _, version := range page.Versions {
-				d.StreamLeafListItem(ctx, version)
+				if !streamLeafListItem(ctx, d, version) {
+					return false
+				}
 			}
 			return !lastPage
 		},
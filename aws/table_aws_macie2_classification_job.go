@@ -25,7 +25,7 @@ func tableAwsMacie2ClassificationJob(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listMacie2ClassificationJobs,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(macie2.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -157,7 +157,9 @@ func listMacie2ClassificationJobs(ctx context.Context, d *plugin.QueryData, _ *p
 		&macie2.ListClassificationJobsInput{},
 		func(page *macie2.ListClassificationJobsOutput, isLast bool) bool {
 			for _, job := range page.Items {
-				d.StreamListItem(ctx, job)
+				if !streamListItem(ctx, d, job) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -5,7 +5,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/mediastore"
-	"github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
@@ -24,7 +23,7 @@ func tableAwsMediaStoreContainer(_ context.Context) *plugin.Table {
 			Hydrate:           listMediaStoreContainers,
 			ShouldIgnoreError: isNotFoundError([]string{"ContainerInUseException"}),
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(mediastore.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
@@ -116,25 +115,18 @@ func listMediaStoreContainers(ctx context.Context, d *plugin.QueryData, h *plugi
 		return nil, err
 	}
 
-	// Set MaxResults to the maximum number allowed
+	// Set MaxResults to the maximum number allowed, or less if the user has only requested a small number of rows
 	input := mediastore.ListContainersInput{
-		MaxResults: types.Int64(100),
-	}
-
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
-		if *limit < *input.MaxResults {
-			input.MaxResults = limit
-		}
+		MaxResults: listPageSize(d, 1, 100),
 	}
 
 	err = svc.ListContainersPages(
 		&input,
 		func(page *mediastore.ListContainersOutput, lastPage bool) bool {
 			for _, container := range page.Containers {
-				d.StreamListItem(ctx, container)
+				if !streamListItem(ctx, d, container) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -106,7 +106,9 @@ func listOrganizationsAccounts(ctx context.Context, d *plugin.QueryData, _ *plug
 		params,
 		func(page *organizations.ListAccountsOutput, isLast bool) bool {
 			for _, account := range page.Accounts {
-				d.StreamListItem(ctx, account)
+				if !streamListItem(ctx, d, account) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsPartition(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_partition",
+		Description: "AWS Partition",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			Hydrate:    getAwsPartition,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listAwsPartitions,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The identifier of the partition (aws, aws-cn, aws-us-gov, aws-iso or aws-iso-b).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the partition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dns_suffix",
+				Description: "The base domain name of the service endpoints in the partition.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "global_region",
+				Description: "The region targeted by the global services of the partition, e.g. IAM.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "regions",
+				Description: "A map of the regions in the partition to their description.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "services",
+				Description: "A map of the endpoint ID of each service in the partition to the regions it is available in. Global services list their partition endpoint instead, e.g. aws-global.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+		},
+	}
+}
+
+type awsPartitionData struct {
+	Name         string
+	Description  string
+	DnsSuffix    string
+	GlobalRegion string
+	Regions      map[string]string
+	Services     map[string][]string
+}
+
+//// LIST FUNCTION
+
+func listAwsPartitions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	for _, partition := range endpoints.DefaultPartitions() {
+		d.StreamListItem(ctx, buildAwsPartitionData(partition))
+	}
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getAwsPartition(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	name := d.KeyColumnQuals["name"].GetStringValue()
+
+	for _, partition := range endpoints.DefaultPartitions() {
+		if partition.ID() == name {
+			return buildAwsPartitionData(partition), nil
+		}
+	}
+	return nil, nil
+}
+
+func buildAwsPartitionData(partition endpoints.Partition) awsPartitionData {
+	regions := map[string]string{}
+	for name, region := range partition.Regions() {
+		regions[name] = region.Description()
+	}
+
+	services := map[string][]string{}
+	for id, service := range partition.Services() {
+		var serviceRegions []string
+		for name := range service.Regions() {
+			serviceRegions = append(serviceRegions, name)
+		}
+		if len(serviceRegions) == 0 {
+			for name := range service.Endpoints() {
+				serviceRegions = append(serviceRegions, name)
+			}
+		}
+		sort.Strings(serviceRegions)
+		services[id] = serviceRegions
+	}
+
+	return awsPartitionData{
+		Name:         partition.ID(),
+		Description:  awsPartitionDetails[partition.ID()].Description,
+		DnsSuffix:    partition.DNSSuffix(),
+		GlobalRegion: getPartitionGlobalRegion(partition),
+		Regions:      regions,
+		Services:     services,
+	}
+}
@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsPluginApiCallStat(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_plugin_api_call_stat",
+		Description: "AWS API calls made by the plugin for the connection since the plugin started, per table, service, operation and region.",
+		List: &plugin.ListConfig{
+			Hydrate: listAwsPluginApiCallStats,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "table_name",
+				Description: "The table the calls were made for. Empty for calls made to set up the connection, e.g. to list its regions.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service",
+				Description: "The endpoint ID of the service called, e.g. s3 or ec2.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "operation",
+				Description: "The name of the API operation called, e.g. GetBucketTagging.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "region",
+				Description: "The region of the session the calls were made with.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "calls",
+				Description: "The number of calls made. Retries of a call are not counted as separate calls.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "errors",
+				Description: "The number of calls which failed after all retries. This includes errors which are ignored by the tables, e.g. not found errors.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "throttles",
+				Description: "The number of attempts which were throttled by AWS.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "retries",
+				Description: "The number of retries of the calls.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "total_duration_ms",
+				Description: "The total time of the calls in milliseconds, including retries and waits for the rate limit.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("TotalDuration").Transform(durationToMilliseconds),
+			},
+			{
+				Name:        "average_duration_ms",
+				Description: "The average time of a call in milliseconds.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.From(apiCallStatAverageDuration),
+			},
+			{
+				Name:        "max_duration_ms",
+				Description: "The time of the slowest call in milliseconds.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.FromField("MaxDuration").Transform(durationToMilliseconds),
+			},
+			{
+				Name:        "last_error_code",
+				Description: "The error code of the last call which failed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "first_call_time",
+				Description: "The time of the first call.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_call_time",
+				Description: "The time the last call completed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsPluginApiCallStats(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	for _, stat := range apiCallStats.list(d.Connection.Name) {
+		d.StreamListItem(ctx, stat)
+	}
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func durationToMilliseconds(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	duration := d.Value.(time.Duration)
+	return float64(duration) / float64(time.Millisecond), nil
+}
+
+func apiCallStatAverageDuration(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	stat := d.HydrateItem.(apiCallStat)
+	if stat.Calls == 0 {
+		return nil, nil
+	}
+	return float64(stat.TotalDuration) / float64(stat.Calls) / float64(time.Millisecond), nil
+}
@@ -0,0 +1,221 @@
+package aws
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+// awsPluginConnection is the row of the aws_plugin_connection table
+type awsPluginConnection struct {
+	Source        *credentialSource
+	AssumeRoleArn *string
+	DefaultRegion string
+	Regions       []string
+
+	ProviderName          string
+	AccessKeyId           string
+	CredentialsExpireTime *time.Time
+	CredentialsError      string
+
+	AccountId string
+	Arn       string
+	UserId    string
+	Partition string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsPluginConnection(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_plugin_connection",
+		Description: "The credential source and identity the plugin resolved for the connection, to diagnose credential problems.",
+		List: &plugin.ListConfig{
+			Hydrate: listAwsPluginConnection,
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "credential_source",
+				Description: "The source of the base credentials of the connection: access_key, sso, web_identity, credential_process, profile, environment or default.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.Type"),
+			},
+			{
+				Name:        "profile",
+				Description: "The profile the credential source was read from, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.Profile").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "provider_name",
+				Description: "The name of the SDK credentials provider which returned the credentials, e.g. SSOProvider or AssumeRoleProvider.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ProviderName").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "access_key_id",
+				Description: "The access key ID of the credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccessKeyId").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "credentials_expire_time",
+				Description: "The time the credentials expire, if they are temporary.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "credentials_error",
+				Description: "The error returned when retrieving the credentials or the caller identity, e.g. an expired SSO token.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CredentialsError").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "account_id",
+				Description: "The ID of the AWS account of the credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("AccountId").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "arn",
+				Description: "The ARN of the principal of the credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Arn").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "user_id",
+				Description: "The unique identifier of the principal of the credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("UserId").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "partition",
+				Description: "The AWS partition of the credentials, e.g. aws or aws-cn.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Partition").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "default_region",
+				Description: "The region used for global services and the caller identity.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "regions",
+				Description: "The regions entries of the connection config.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "assume_role_arn",
+				Description: "The role assumed with the base credentials, from assume_role_arn in the connection config.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "sso_session",
+				Description: "The sso_session of the profile, for IAM Identity Center credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.SSOSession").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "sso_start_url",
+				Description: "The start URL of the AWS access portal, for IAM Identity Center credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.SSOStartURL").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "sso_region",
+				Description: "The region of IAM Identity Center, for IAM Identity Center credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.SSORegion").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "sso_account_id",
+				Description: "The account the role is assigned in, for IAM Identity Center credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.SSOAccountId").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "sso_role_name",
+				Description: "The name of the role assigned to the user, for IAM Identity Center credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.SSORoleName").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "web_identity_token_file",
+				Description: "The file of the OIDC token, for web identity credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.WebIdentityTokenFile").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "web_identity_role_arn",
+				Description: "The role assumed with the OIDC token, for web identity credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.WebIdentityRoleArn").Transform(transform.NullIfZeroValue),
+			},
+			{
+				Name:        "credential_process",
+				Description: "The command which returns the credentials, for credential_process credentials.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Source.CredentialProcess").Transform(transform.NullIfZeroValue),
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsPluginConnection(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	awsConfig := GetConfig(d.Connection)
+	source, err := resolveCredentialSource(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &awsPluginConnection{
+		Source:        source,
+		AssumeRoleArn: awsConfig.AssumeRoleArn,
+		DefaultRegion: GetDefaultAwsRegion(d),
+		Regions:       awsConfig.Regions,
+	}
+
+	// the table is used to diagnose credential problems, so their errors are returned in a column
+	if err := getAwsPluginConnectionIdentity(ctx, d, h, row); err != nil {
+		row.CredentialsError = err.Error()
+	}
+
+	d.StreamListItem(ctx, row)
+	return nil, nil
+}
+
+// getAwsPluginConnectionIdentity sets the credentials and the caller identity of the connection
+func getAwsPluginConnectionIdentity(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, row *awsPluginConnection) error {
+	sess, err := getSession(ctx, d, row.DefaultRegion)
+	if err != nil {
+		return err
+	}
+
+	value, err := sess.Config.Credentials.GetWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	row.ProviderName = value.ProviderName
+	row.AccessKeyId = value.AccessKeyID
+	if expireTime, err := sess.Config.Credentials.ExpiresAt(); err == nil && !expireTime.IsZero() {
+		row.CredentialsExpireTime = &expireTime
+	}
+
+	getCallerIdentityCached := plugin.HydrateFunc(getCallerIdentity).WithCache(getCallerIdentityCacheKey)
+	callerIdentityData, err := getCallerIdentityCached(ctx, d, h)
+	if err != nil {
+		return err
+	}
+	callerIdentity := callerIdentityData.(*sts.GetCallerIdentityOutput)
+	row.AccountId = *callerIdentity.Account
+	row.Arn = *callerIdentity.Arn
+	row.UserId = *callerIdentity.UserId
+	row.Partition = strings.Split(*callerIdentity.Arn, ":")[1]
+	return nil
+}
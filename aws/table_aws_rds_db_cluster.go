@@ -345,7 +345,9 @@ func listRDSDBClusters(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydra
 		&rds.DescribeDBClustersInput{},
 		func(page *rds.DescribeDBClustersOutput, isLast bool) bool {
 			for _, dbCluster := range page.DBClusters {
-				d.StreamListItem(ctx, dbCluster)
+				if !streamListItem(ctx, d, dbCluster) {
+					return false
+				}
 			}
 			return !isLast
 		},
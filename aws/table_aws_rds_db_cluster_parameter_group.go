@@ -105,7 +105,9 @@ func listRDSDBClusterParameterGroups(ctx context.Context, d *plugin.QueryData, _
 		&rds.DescribeDBClusterParameterGroupsInput{},
 		func(page *rds.DescribeDBClusterParameterGroupsOutput, isLast bool) bool {
 			for _, dbClusterParameterGroup := range page.DBClusterParameterGroups {
-				d.StreamListItem(ctx, dbClusterParameterGroup)
+				if !streamListItem(ctx, d, dbClusterParameterGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
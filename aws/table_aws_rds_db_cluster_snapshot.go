@@ -191,23 +191,10 @@ func listRDSDBClusterSnapshots(ctx context.Context, d *plugin.QueryData, _ *plug
 	}
 
 	input := rds.DescribeDBClusterSnapshotsInput{
-		MaxRecords: types.Int64(100),
-	}
-
-	// If the requested number of items is less than the paging max limit
-	// set the limit to that instead
-	limit := d.QueryContext.Limit
-	if d.QueryContext.Limit != nil {
 		// select * from aws_rds_db_cluster_snapshot limit 3
 		// Error: InvalidParameterValue: Invalid value 3 for MaxRecords. Must be between 20 and 100
 		// 	status code: 400, request id: c39eead1-96e0-49c8-a927-aa9a3131836d
-		if *limit < *input.MaxRecords {
-			if *limit < 20 {
-				input.MaxRecords = types.Int64(100)
-			} else {
-				input.MaxRecords = limit
-			}
-		}
+		MaxRecords: listPageSize(d, 20, 100),
 	}
 	filters := buildRdsDbClusterSnapshotFilter(d.KeyColumnQuals)
 
@@ -218,10 +205,7 @@ func listRDSDBClusterSnapshots(ctx context.Context, d *plugin.QueryData, _ *plug
 	// List call
 	err = svc.DescribeDBClusterSnapshotsPages(&input, func(page *rds.DescribeDBClusterSnapshotsOutput, isLast bool) bool {
 		for _, dbClusterSnapshot := range page.DBClusterSnapshots {
-			d.StreamListItem(ctx, dbClusterSnapshot)
-			// Check if context has been cancelled or if the limit has been hit (if specified)
-			// if there is a limit, it will return the number of rows required to reach this limit
-			if d.QueryStatus.RowsRemaining(ctx) == 0 {
+			if !streamListItem(ctx, d, dbClusterSnapshot) {
 				return false
 			}
 		}
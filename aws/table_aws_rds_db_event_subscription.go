@@ -113,7 +113,9 @@ func listRDSDBEventSubscriptions(ctx context.Context, d *plugin.QueryData, _ *pl
 		&rds.DescribeEventSubscriptionsInput{},
 		func(page *rds.DescribeEventSubscriptionsOutput, isLast bool) bool {
 			for _, eventSubscription := range page.EventSubscriptionsList {
-				d.StreamListItem(ctx, eventSubscription)
+				if !streamListItem(ctx, d, eventSubscription) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -434,7 +434,9 @@ func listRDSDBInstances(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		&rds.DescribeDBInstancesInput{},
 		func(page *rds.DescribeDBInstancesOutput, isLast bool) bool {
 			for _, dbInstance := range page.DBInstances {
-				d.StreamListItem(ctx, dbInstance)
+				if !streamListItem(ctx, d, dbInstance) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -118,7 +118,9 @@ func listRDSDBOptionGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		&rds.DescribeOptionGroupsInput{},
 		func(page *rds.DescribeOptionGroupsOutput, isLast bool) bool {
 			for _, optionGroup := range page.OptionGroupsList {
-				d.StreamListItem(ctx, optionGroup)
+				if !streamListItem(ctx, d, optionGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -105,7 +105,9 @@ func listRDSDBParameterGroups(ctx context.Context, d *plugin.QueryData, _ *plugi
 		&rds.DescribeDBParameterGroupsInput{},
 		func(page *rds.DescribeDBParameterGroupsOutput, isLast bool) bool {
 			for _, dbParameterGroup := range page.DBParameterGroups {
-				d.StreamListItem(ctx, dbParameterGroup)
+				if !streamListItem(ctx, d, dbParameterGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -225,10 +225,14 @@ func listRDSDBSnapshots(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 
 	// List call
 	err = svc.DescribeDBSnapshotsPages(
-		&rds.DescribeDBSnapshotsInput{},
+		&rds.DescribeDBSnapshotsInput{
+			MaxRecords: listPageSize(d, 20, 100),
+		},
 		func(page *rds.DescribeDBSnapshotsOutput, isLast bool) bool {
 			for _, dbSnapshot := range page.DBSnapshots {
-				d.StreamListItem(ctx, dbSnapshot)
+				if !streamListItem(ctx, d, dbSnapshot) {
+					return false
+				}
 			}
 			return !isLast
 		},
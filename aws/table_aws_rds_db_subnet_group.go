@@ -109,7 +109,9 @@ plugin.Logger(ctx).Trace("listRDSDBSubnetGroups")
 		&rds.DescribeDBSubnetGroupsInput{},
 		func(page *rds.DescribeDBSubnetGroupsOutput, isLast bool) bool {
 			for _, dbSubnetGroup := range page.DBSubnetGroups {
-				d.StreamListItem(ctx, dbSubnetGroup)
+				if !streamListItem(ctx, d, dbSubnetGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
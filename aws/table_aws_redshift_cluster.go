@@ -331,7 +331,9 @@ func listRedshiftClusters(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		&redshift.DescribeClustersInput{},
 		func(page *redshift.DescribeClustersOutput, isLast bool) bool {
 			for _, cluster := range page.Clusters {
-				d.StreamListItem(ctx, cluster)
+				if !streamListItem(ctx, d, cluster) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -429,7 +431,7 @@ func getRedshiftClusterARN(ctx context.Context, d *plugin.QueryData, h *plugin.H
 	cluster := h.Item.(*redshift.Cluster)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -167,7 +167,7 @@ func getAwsRedshiftEventSubscriptionAkas(ctx context.Context, d *plugin.QueryDat
 	plugin.Logger(ctx).Trace("getAwsRedshiftEventSubscriptionAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	parameterData := h.Item.(*redshift.EventSubscription)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
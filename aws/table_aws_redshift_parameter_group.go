@@ -168,7 +168,7 @@ func getAwsRedshiftParameterGroupAkas(ctx context.Context, d *plugin.QueryData,
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	parameterData := h.Item.(*redshift.ClusterParameterGroup)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
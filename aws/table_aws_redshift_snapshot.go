@@ -234,10 +234,14 @@ func listAwsRedshiftSnapshots(ctx context.Context, d *plugin.QueryData, _ *plugi
 
 	// List call
 	err = svc.DescribeClusterSnapshotsPages(
-		&redshift.DescribeClusterSnapshotsInput{},
+		&redshift.DescribeClusterSnapshotsInput{
+			MaxRecords: listPageSize(d, 20, 100),
+		},
 		func(page *redshift.DescribeClusterSnapshotsOutput, isLast bool) bool {
 			for _, snapshot := range page.Snapshots {
-				d.StreamListItem(ctx, snapshot)
+				if !streamListItem(ctx, d, snapshot) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -281,7 +285,7 @@ func getAwsRedshiftSnapshotAkas(ctx context.Context, d *plugin.QueryData, h *plu
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	snapshot := h.Item.(*redshift.Snapshot)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
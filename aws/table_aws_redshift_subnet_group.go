@@ -100,7 +100,9 @@ func listRedshiftSubnetGroup(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&redshift.DescribeClusterSubnetGroupsInput{},
 		func(page *redshift.DescribeClusterSubnetGroupsOutput, isLast bool) bool {
 			for _, subnetGroup := range page.ClusterSubnetGroups {
-				d.StreamListItem(ctx, subnetGroup)
+				if !streamListItem(ctx, d, subnetGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -138,7 +140,7 @@ func getRedshiftSubnetGroupAkas(ctx context.Context, d *plugin.QueryData, h *plu
 	plugin.Logger(ctx).Trace("getRedshiftSubnetGroupAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	data := h.Item.(*redshift.ClusterSubnetGroup)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,545 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/glacier"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsResourcePolicy(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_resource_policy",
+		Description: "AWS Resource Policy, the resource-based policies of the resources of all services, with public and cross-account access analysis",
+		List: &plugin.ListConfig{
+			Hydrate: listAwsResourcePolicies,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItem: BuildRegionList,
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "resource_type",
+				Description: "The CloudFormation type of the resource, e.g. AWS::S3::Bucket or AWS::SQS::Queue.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_arn",
+				Description: "The Amazon Resource Name (ARN) of the resource. CloudWatch Logs resource policies have no ARN, they are identified as arn:<partition>:logs:<region>:<account>:resource-policy/<name>.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_name",
+				Description: "The name of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy",
+				Description: "The resource-based policy of the resource.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "policy_std",
+				Description: "Contains the policy in a canonical form for easier searching.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Policy").Transform(policyToCanonical),
+			},
+			{
+				Name:        "is_public",
+				Description: "True if a statement allows any principal, with a wildcard principal or a NotPrincipal, and no condition restricts it to an account, organization, network or source resource.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Access.IsPublic"),
+			},
+			{
+				Name:        "external_account_ids",
+				Description: "The accounts, other than the account of the resource, which the policy grants access to, as principals or in conditions such as aws:SourceAccount or aws:PrincipalAccount.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Access.ExternalAccountIds"),
+			},
+			{
+				Name:        "allowed_organization_ids",
+				Description: "The organizations whose principals the policy grants access to with the aws:PrincipalOrgID condition key.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Access.AllowedOrganizationIds"),
+			},
+			{
+				Name:        "conditions_restrict_access",
+				Description: "True if the policy has statements allowing any principal and conditions, e.g. on aws:SourceVpce, aws:PrincipalOrgID or aws:SourceArn, restrict all of them.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Access.ConditionsRestrictAccess"),
+			},
+		}),
+	}
+}
+
+type awsResourcePolicy struct {
+	ResourceType string
+	ResourceArn  string
+	ResourceName string
+	Policy       string
+	Access       resourcePolicyAccess
+}
+
+// resourcePolicyLister streams the resources of a type which have a resource-based policy, in
+// the region of the query. stream returns false once the query has all the rows it needs.
+type resourcePolicyLister func(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error
+
+// resourcePolicyListers are the listers of each resource type, in the order of the rows
+var resourcePolicyListers = []struct {
+	resourceType string
+	list         resourcePolicyLister
+}{
+	{"AWS::ECR::Repository", listEcrRepositoryResourcePolicies},
+	{"AWS::Events::EventBus", listEventBusResourcePolicies},
+	{"AWS::Glacier::Vault", listGlacierVaultResourcePolicies},
+	{"AWS::KMS::Key", listKmsKeyResourcePolicies},
+	{"AWS::Lambda::Function", listLambdaFunctionResourcePolicies},
+	{"AWS::Logs::ResourcePolicy", listCloudWatchLogsResourcePolicies},
+	{"AWS::S3::Bucket", listS3BucketResourcePolicies},
+	{"AWS::SNS::Topic", listSnsTopicResourcePolicies},
+	{"AWS::SQS::Queue", listSqsQueueResourcePolicies},
+	{"AWS::SecretsManager::Secret", listSecretsManagerSecretResourcePolicies},
+}
+
+// resourcePolicyAccessDeniedCodes are the errors of a service the credentials have no access to
+var resourcePolicyAccessDeniedCodes = []string{"AccessDenied", "AccessDeniedException", "AuthorizationError"}
+
+// resourcePolicyNotFoundCodes are the errors of a resource without a policy, or deleted since it
+// was listed
+var resourcePolicyNotFoundCodes = []string{
+	"NoSuchBucket",
+	"NoSuchBucketPolicy",
+	"NotFoundException",
+	"RepositoryNotFoundException",
+	"RepositoryPolicyNotFoundException",
+	"ResourceNotFoundException",
+	"AWS.SimpleQueueService.NonExistentQueue",
+}
+
+//// LIST FUNCTION
+
+func listAwsResourcePolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.KeyColumnQualString(matrixKeyRegion)
+	plugin.Logger(ctx).Trace("listAwsResourcePolicies", "region", region)
+
+	// the account of the resources, to tell external accounts from it
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
+	commonData, err := getCommonColumnsCached(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	accountId := commonData.(*awsCommonColumnData).AccountId
+
+	resourceTypes := getQualListStringValues(d.KeyColumnQuals["resource_type"])
+
+	more := true
+	stream := func(item awsResourcePolicy) (bool, error) {
+		canonical, err := canonicalPolicy(item.Policy)
+		if err != nil {
+			// the policies of the other resources are listed when one can't be parsed
+			plugin.Logger(ctx).Warn("listAwsResourcePolicies", "resource_arn", item.ResourceArn, "ignored_error", err)
+			return true, nil
+		}
+		item.Access = analyzeResourcePolicy(canonical.(Policy), accountId)
+		more = streamListItem(ctx, d, item)
+		return more, nil
+	}
+
+	for _, lister := range resourcePolicyListers {
+		if len(resourceTypes) > 0 && !helpers.StringSliceContains(resourceTypes, lister.resourceType) {
+			continue
+		}
+		if err := lister.list(ctx, d, stream); err != nil {
+			// the other services are listed when the credentials have no access to one of them
+			if isNotFoundError(resourcePolicyAccessDeniedCodes)(err) {
+				plugin.Logger(ctx).Warn("listAwsResourcePolicies", "resource_type", lister.resourceType, "region", region, "ignored_error", err)
+				continue
+			}
+			return nil, err
+		}
+		if !more {
+			break
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func listEcrRepositoryResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := EcrService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{}, func(page *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+		for _, repository := range page.Repositories {
+			op, err := svc.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{RepositoryName: repository.RepositoryName})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			more, err := streamResourcePolicy(stream, "AWS::ECR::Repository", aws.StringValue(repository.RepositoryArn), aws.StringValue(repository.RepositoryName), op.PolicyText)
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listEventBusResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := EventBridgeService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	input := &eventbridge.ListEventBusesInput{}
+	for {
+		op, err := svc.ListEventBuses(input)
+		if err != nil {
+			return err
+		}
+		for _, bus := range op.EventBuses {
+			more, err := streamResourcePolicy(stream, "AWS::Events::EventBus", aws.StringValue(bus.Arn), aws.StringValue(bus.Name), bus.Policy)
+			if err != nil || !more {
+				return err
+			}
+		}
+		if op.NextToken == nil {
+			return nil
+		}
+		input.NextToken = op.NextToken
+	}
+}
+
+func listGlacierVaultResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := GlacierService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListVaultsPages(&glacier.ListVaultsInput{AccountId: aws.String("-")}, func(page *glacier.ListVaultsOutput, lastPage bool) bool {
+		for _, vault := range page.VaultList {
+			op, err := svc.GetVaultAccessPolicy(&glacier.GetVaultAccessPolicyInput{
+				AccountId: aws.String("-"),
+				VaultName: vault.VaultName,
+			})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			if op.Policy == nil {
+				continue
+			}
+			more, err := streamResourcePolicy(stream, "AWS::Glacier::Vault", aws.StringValue(vault.VaultARN), aws.StringValue(vault.VaultName), op.Policy.Policy)
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listKmsKeyResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := KMSService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListKeysPages(&kms.ListKeysInput{}, func(page *kms.ListKeysOutput, lastPage bool) bool {
+		for _, key := range page.Keys {
+			op, err := svc.GetKeyPolicy(&kms.GetKeyPolicyInput{
+				KeyId:      key.KeyId,
+				PolicyName: aws.String("default"),
+			})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			more, err := streamResourcePolicy(stream, "AWS::KMS::Key", aws.StringValue(key.KeyArn), aws.StringValue(key.KeyId), op.Policy)
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listLambdaFunctionResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := LambdaService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListFunctionsPages(&lambda.ListFunctionsInput{}, func(page *lambda.ListFunctionsOutput, lastPage bool) bool {
+		for _, function := range page.Functions {
+			op, err := svc.GetPolicy(&lambda.GetPolicyInput{FunctionName: function.FunctionName})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			more, err := streamResourcePolicy(stream, "AWS::Lambda::Function", aws.StringValue(function.FunctionArn), aws.StringValue(function.FunctionName), op.Policy)
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listCloudWatchLogsResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := CloudWatchLogsService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	commonData, err := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)(ctx, d, nil)
+	if err != nil {
+		return err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+	// the policies have no ARN, they are identified by name in their account and region
+	arnPrefix := fmt.Sprintf("arn:%s:logs:%s:%s:resource-policy/", commonColumnData.Partition, commonColumnData.Region, commonColumnData.AccountId)
+
+	input := &cloudwatchlogs.DescribeResourcePoliciesInput{}
+	for {
+		op, err := svc.DescribeResourcePolicies(input)
+		if err != nil {
+			return err
+		}
+		for _, policy := range op.ResourcePolicies {
+			policyName := aws.StringValue(policy.PolicyName)
+			more, err := streamResourcePolicy(stream, "AWS::Logs::ResourcePolicy", arnPrefix+policyName, policyName, policy.PolicyDocument)
+			if err != nil || !more {
+				return err
+			}
+		}
+		if op.NextToken == nil {
+			return nil
+		}
+		input.NextToken = op.NextToken
+	}
+}
+
+func listS3BucketResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	region := d.KeyColumnQualString(matrixKeyRegion)
+
+	allBuckets, err := getS3Buckets(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	// buckets are global, each region lists those located in it
+	buckets, err := filterS3BucketsByRegion(ctx, d, allBuckets, []string{region})
+	if err != nil {
+		return err
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	regionalSvc, err := S3Service(ctx, d, region)
+	if err != nil {
+		return err
+	}
+	commonData, err := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)(ctx, d, nil)
+	if err != nil {
+		return err
+	}
+	partition := commonData.(*awsCommonColumnData).Partition
+
+	for _, bucket := range buckets {
+		op, err := regionalSvc.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: bucket.Name})
+		if err != nil {
+			if shouldSkipResourcePolicy(ctx, err) {
+				continue
+			}
+			return err
+		}
+		more, err := streamResourcePolicy(stream, "AWS::S3::Bucket", "arn:"+partition+":s3:::"+*bucket.Name, *bucket.Name, op.Policy)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+func listSnsTopicResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := SNSService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListTopicsPages(&sns.ListTopicsInput{}, func(page *sns.ListTopicsOutput, lastPage bool) bool {
+		for _, topic := range page.Topics {
+			op, err := svc.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: topic.TopicArn})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			topicArn := aws.StringValue(topic.TopicArn)
+			more, err := streamResourcePolicy(stream, "AWS::SNS::Topic", topicArn, topicArn[strings.LastIndex(topicArn, ":")+1:], op.Attributes["Policy"])
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listSqsQueueResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := SQSService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListQueuesPages(&sqs.ListQueuesInput{}, func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+		for _, queueURL := range page.QueueUrls {
+			op, err := svc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+				QueueUrl:       queueURL,
+				AttributeNames: aws.StringSlice([]string{"Policy", "QueueArn"}),
+			})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			queueArn := aws.StringValue(op.Attributes["QueueArn"])
+			more, err := streamResourcePolicy(stream, "AWS::SQS::Queue", queueArn, queueArn[strings.LastIndex(queueArn, ":")+1:], op.Attributes["Policy"])
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+func listSecretsManagerSecretResourcePolicies(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+	svc, err := SecretsManagerService(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	err = svc.ListSecretsPages(&secretsmanager.ListSecretsInput{}, func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
+		for _, secret := range page.SecretList {
+			op, err := svc.GetResourcePolicy(&secretsmanager.GetResourcePolicyInput{SecretId: secret.ARN})
+			if err != nil {
+				if shouldSkipResourcePolicy(ctx, err) {
+					continue
+				}
+				streamErr = err
+				return false
+			}
+			more, err := streamResourcePolicy(stream, "AWS::SecretsManager::Secret", aws.StringValue(secret.ARN), aws.StringValue(secret.Name), op.ResourcePolicy)
+			if err != nil || !more {
+				streamErr = err
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if err != nil {
+		return err
+	}
+	return streamErr
+}
+
+// streamResourcePolicy streams the policy of a resource, unless it has none
+func streamResourcePolicy(stream func(awsResourcePolicy) (bool, error), resourceType string, resourceArn string, resourceName string, policy *string) (bool, error) {
+	if aws.StringValue(policy) == "" {
+		return true, nil
+	}
+	return stream(awsResourcePolicy{
+		ResourceType: resourceType,
+		ResourceArn:  resourceArn,
+		ResourceName: resourceName,
+		Policy:       *policy,
+	})
+}
+
+// shouldSkipResourcePolicy returns true if the policy of a resource can't be read, because the
+// resource has none, was deleted since it was listed, or its policy denies access to it
+func shouldSkipResourcePolicy(ctx context.Context, err error) bool {
+	if isNotFoundError(resourcePolicyNotFoundCodes)(err) {
+		return true
+	}
+	if isNotFoundError(resourcePolicyAccessDeniedCodes)(err) {
+		plugin.Logger(ctx).Warn("shouldSkipResourcePolicy", "ignored_error", err)
+		return true
+	}
+	return false
+}
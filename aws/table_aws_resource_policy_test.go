@@ -0,0 +1,47 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+)
+
+func TestListAwsResourcePoliciesSkipsUnparsablePolicies(t *testing.T) {
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sqs:SendMessage","Resource":"*"}]}`
+	items := []awsResourcePolicy{
+		{ResourceType: "AWS::SQS::Queue", ResourceArn: "arn:aws:sqs:us-east-1:123456789012:first", ResourceName: "first", Policy: policy},
+		{ResourceType: "AWS::SQS::Queue", ResourceArn: "arn:aws:sqs:us-east-1:123456789012:broken", ResourceName: "broken", Policy: `{"Statement":`},
+		{ResourceType: "AWS::SQS::Queue", ResourceArn: "arn:aws:sqs:us-east-1:123456789012:last", ResourceName: "last", Policy: policy},
+	}
+
+	listers := resourcePolicyListers
+	defer func() { resourcePolicyListers = listers }()
+	resourcePolicyListers = []struct {
+		resourceType string
+		list         resourcePolicyLister
+	}{
+		{"AWS::SQS::Queue", func(ctx context.Context, d *plugin.QueryData, stream func(awsResourcePolicy) (bool, error)) error {
+			for _, item := range items {
+				if more, err := stream(item); err != nil || !more {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+	defer registerServiceClient("ec2", &fakeEC2{Regions: []string{"us-east-1"}})()
+	defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "123456789012")})()
+
+	p := newFakeTableTestPlugin(t, "test_resource_policy_unparsable")
+	stream := executeTableTestQuery(t, p, "aws_resource_policy", []string{"resource_name"}, nil, 0)
+
+	var names []string
+	for _, row := range stream.rows {
+		names = append(names, row["resource_name"].(string))
+	}
+	if expected := []string{"first", "last"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the resources %v, got %v", expected, names)
+	}
+}
@@ -210,7 +210,9 @@ func listRoute53Domains(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		&route53domains.ListDomainsInput{},
 		func(page *route53domains.ListDomainsOutput, isLast bool) bool {
 			for _, domain := range page.Domains {
-				d.StreamListItem(ctx, domain)
+				if !streamListItem(ctx, d, domain) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -281,7 +283,7 @@ func getRoute53DomainARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 
 	name := domainName(h.Item)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -146,7 +146,9 @@ func listRoute53Records(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		},
 		func(page *route53.ListResourceRecordSetsOutput, isLast bool) bool {
 			for _, record := range page.ResourceRecordSets {
-				d.StreamListItem(ctx, &recordInfo{&hostedZoneID, record})
+				if !streamListItem(ctx, d, &recordInfo{&hostedZoneID, record}) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -183,7 +185,7 @@ func flattenResourceRecords(_ context.Context, d *transform.TransformData) (inte
 func getRoute53RecordSetAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getRoute53RecordSetAkas")
 	recordData := h.Item.(*recordInfo)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -140,7 +140,9 @@ func listHostedZones(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		&route53.ListHostedZonesInput{},
 		func(page *route53.ListHostedZonesOutput, isLast bool) bool {
 			for _, hostedZone := range page.HostedZones {
-				d.StreamListItem(ctx, hostedZone)
+				if !streamListItem(ctx, d, hostedZone) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -261,7 +263,7 @@ func getHostedZoneDNSSEC(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 func getRoute53HostedZoneTurbotAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getRoute53HostedZoneTurbotAkas")
 	hostedZone := h.Item.(*route53.HostedZone)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
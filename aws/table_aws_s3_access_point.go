@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3control"
 
 	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
@@ -20,6 +21,9 @@ func tableAwsS3AccessPoint(_ context.Context) *plugin.Table {
 		Description: "AWS S3 Access Point",
 		List: &plugin.ListConfig{
 			Hydrate: listS3AccessPoints,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "bucket_name", Require: plugin.Optional},
+			},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns:        plugin.AllColumns([]string{"name", "region"}),
@@ -139,13 +143,32 @@ func listS3AccessPoints(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
 	}
 	commonColumnData := commonData.(*awsCommonColumnData)
 
+	input := &s3control.ListAccessPointsInput{
+		AccountId: aws.String(commonColumnData.AccountId),
+	}
+
+	// access points are in the region of their bucket, so only that region is listed
+	if bucketName := d.KeyColumnQuals["bucket_name"].GetStringValue(); bucketName != "" {
+		bucketRegion, err := getS3BucketRegion(ctx, d, bucketName)
+		if err != nil {
+			if a, ok := err.(awserr.Error); ok && a.Code() == s3.ErrCodeNoSuchBucket {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if bucketRegion != region {
+			return nil, nil
+		}
+		input.Bucket = aws.String(bucketName)
+	}
+
 	// Create Session
 	svc, err := S3ControlService(ctx, d, region)
 	if err != nil {
@@ -153,12 +176,12 @@ func listS3AccessPoints(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	}
 
 	err = svc.ListAccessPointsPages(
-		&s3control.ListAccessPointsInput{
-			AccountId: aws.String(commonColumnData.AccountId),
-		},
+		input,
 		func(page *s3control.ListAccessPointsOutput, isLast bool) bool {
 			for _, accessPoint := range page.AccessPointList {
-				d.StreamListItem(ctx, accessPoint)
+				if !streamListItem(ctx, d, accessPoint) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -173,7 +196,7 @@ func getS3AccessPoint(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	matrixRegion := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -220,7 +243,7 @@ func getS3AccessPointPolicyStatus(ctx context.Context, d *plugin.QueryData, h *p
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -259,7 +282,7 @@ func getS3AccessPointPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -298,7 +321,7 @@ func getAccessPointArn(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
 	// Get account details
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
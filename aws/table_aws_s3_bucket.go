@@ -21,6 +21,9 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 		},
 		List: &plugin.ListConfig{
 			Hydrate: listS3Buckets,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "region", Require: plugin.Optional},
+			},
 		},
 		HydrateDependencies: []plugin.HydrateDependencies{
 			{
@@ -248,11 +251,27 @@ func listS3Buckets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateDa
 		return nil, err
 	}
 
-	for _, bucket := range bucketsResult.Buckets {
-		d.StreamListItem(ctx, bucket)
+	buckets := bucketsResult.Buckets
+
+	// only the buckets in the regions of the quals are hydrated, e.g. where region = 'eu-west-1'
+	if value := d.KeyColumnQuals["region"]; value != nil {
+		regions := []string{value.GetStringValue()}
+		if value.GetListValue() != nil {
+			regions = aws.StringValueSlice(getListValues(value.GetListValue()))
+		}
+		buckets, err = filterS3BucketsByRegion(ctx, d, buckets, regions)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return nil, err
+	for _, bucket := range buckets {
+		if !streamListItem(ctx, d, bucket) {
+			break
+		}
+	}
+
+	return nil, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -289,33 +308,15 @@ func getS3Bucket(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 func getBucketLocation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	plugin.Logger(ctx).Trace("getBucketLocation")
 	bucket := h.Item.(*s3.Bucket)
-	defaultRegion := GetDefaultAwsRegion(d)
 
-	// Create Session
-	svc, err := S3Service(ctx, d, defaultRegion)
+	// the hydrate calls of the bucket use clients of this region
+	region, err := getS3BucketRegion(ctx, d, *bucket.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	params := &s3.GetBucketLocationInput{
-		Bucket: bucket.Name,
-	}
-
-	// Specifies the Region where the bucket resides. For a list of all the Amazon
-	// S3 supported location constraints by Region, see Regions and Endpoints (https://docs.aws.amazon.com/general/latest/gr/rande.html#s3_region).
-
-	location, err := svc.GetBucketLocation(params)
-	if err != nil {
-		return nil, err
-	}
-
-	if location != nil && location.LocationConstraint != nil {
-		return location, nil
-	}
-
-	// Buckets in Region us-east-1 have a LocationConstraint of null.
 	return &s3.GetBucketLocationOutput{
-		LocationConstraint: aws.String("us-east-1"),
+		LocationConstraint: aws.String(region),
 	}, nil
 }
 
@@ -589,7 +590,7 @@ func getBucketARN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	plugin.Logger(ctx).Trace("getAwsS3BucketArn")
 	bucket := h.Item.(*s3.Bucket)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestListS3Buckets(t *testing.T) {
+	creationDate := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	buckets := []*s3.Bucket{
+		{Name: aws.String("test-bucket-one"), CreationDate: aws.Time(creationDate)},
+		{Name: aws.String("test-bucket-two"), CreationDate: aws.Time(creationDate)},
+		{Name: aws.String("test-bucket-three"), CreationDate: aws.Time(creationDate)},
+	}
+	bucketRegions := map[string]string{"test-bucket-two": "eu-west-1", "test-bucket-three": "ap-east-1"}
+
+	for _, testCase := range []struct {
+		name                  string
+		quals                 map[string]string
+		noBucketRegionHeader  bool
+		expected              map[string]string
+		expectedLocationCalls int
+	}{
+		{
+			name: "all buckets",
+			// buckets in us-east-1 have no location constraint
+			expected: map[string]string{"test-bucket-one": "us-east-1", "test-bucket-two": "eu-west-1", "test-bucket-three": "ap-east-1"},
+		},
+		{
+			name:     "filtered by region",
+			quals:    map[string]string{"region": "ap-east-1"},
+			expected: map[string]string{"test-bucket-three": "ap-east-1"},
+		},
+		{
+			name:                  "no bucket region header",
+			noBucketRegionHeader:  true,
+			expected:              map[string]string{"test-bucket-one": "us-east-1", "test-bucket-two": "eu-west-1", "test-bucket-three": "ap-east-1"},
+			expectedLocationCalls: 3,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			fakeS3 := &fakeS3{Buckets: buckets, BucketRegions: bucketRegions, NoBucketRegionHeader: testCase.noBucketRegionHeader}
+			defer registerServiceClient("s3", fakeS3)()
+			defer registerServiceClient("ec2", &fakeEC2{Regions: []string{"us-east-1"}})()
+			defer registerServiceClient("sts", &fakeSTS{Identity: newFakeCallerIdentity("aws", "123456789012")})()
+
+			p := newFakeTableTestPlugin(t, "test_list_s3_buckets_"+t.Name())
+			stream := executeTableTestQuery(t, p, "aws_s3_bucket", []string{"name", "creation_date", "region"}, testCase.quals, 0)
+
+			regions := map[string]string{}
+			for _, row := range stream.rows {
+				if row["creation_date"] != "2021-06-01T12:00:00Z" {
+					t.Errorf("unexpected row %v", row)
+				}
+				regions[row["name"].(string)] = row["region"].(string)
+			}
+			if !reflect.DeepEqual(regions, testCase.expected) {
+				t.Errorf("expected bucket regions %v, got %v", testCase.expected, regions)
+			}
+
+			// the region of each bucket is resolved once, for the filter and the region column
+			if calls := fakeS3.Calls("HeadBucket"); calls != len(buckets) {
+				t.Errorf("expected a HeadBucket call per bucket, got %d", calls)
+			}
+			if calls := fakeS3.Calls("GetBucketLocation"); calls != testCase.expectedLocationCalls {
+				t.Errorf("expected %d GetBucketLocation calls, got %d", testCase.expectedLocationCalls, calls)
+			}
+		})
+	}
+}
+
+func TestGetS3BucketsOncePerConnection(t *testing.T) {
+	fakeS3 := &fakeS3{Buckets: []*s3.Bucket{{Name: aws.String("test-bucket-one")}, {Name: aws.String("test-bucket-two")}}}
+	defer registerServiceClient("s3", fakeS3)()
+
+	// the tables listing the buckets of each region call it in parallel
+	regions := []string{"us-east-1", "eu-west-1", "ap-east-1"}
+	d := getConnectionQueryData(newTestConnection("test_get_s3_buckets", "AKIDGETS3BUCKETS", "http://localhost", regions))
+	var wg sync.WaitGroup
+	errs := make(chan error, len(regions))
+	for range regions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buckets, err := getS3Buckets(newTestContext(), d)
+			if err == nil && len(buckets) != 2 {
+				err = fmt.Errorf("expected 2 buckets, got %d", len(buckets))
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls := fakeS3.Calls("ListBuckets"); calls != 1 {
+		t.Errorf("expected a ListBuckets call per connection, got %d", calls)
+	}
+}
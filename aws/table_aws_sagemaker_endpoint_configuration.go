@@ -108,7 +108,9 @@ func listSagemakerEndpointConfigurations(ctx context.Context, d *plugin.QueryDat
 		&sagemaker.ListEndpointConfigsInput{},
 		func(page *sagemaker.ListEndpointConfigsOutput, isLast bool) bool {
 			for _, config := range page.EndpointConfigs {
-				d.StreamListItem(ctx, config)
+				if !streamListItem(ctx, d, config) {
+					return false
+				}
 			}
 			return !isLast
 		},
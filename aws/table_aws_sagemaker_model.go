@@ -127,7 +127,9 @@ func listAwsSageMakerModels(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		&sagemaker.ListModelsInput{},
 		func(page *sagemaker.ListModelsOutput, isLast bool) bool {
 			for _, model := range page.Models {
-				d.StreamListItem(ctx, model)
+				if !streamListItem(ctx, d, model) {
+					return false
+				}
 			}
 			return !isLast
 		},
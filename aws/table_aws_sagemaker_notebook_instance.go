@@ -187,7 +187,9 @@ func listAwsSageMakerNotebookInstances(ctx context.Context, d *plugin.QueryData,
 		&sagemaker.ListNotebookInstancesInput{},
 		func(page *sagemaker.ListNotebookInstancesOutput, isLast bool) bool {
 			for _, notebookInstance := range page.NotebookInstances {
-				d.StreamListItem(ctx, notebookInstance)
+				if !streamListItem(ctx, d, notebookInstance) {
+					return false
+				}
 			}
 			return !isLast
 		},
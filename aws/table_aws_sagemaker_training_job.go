@@ -302,7 +302,9 @@ func listAwsSageMakerTrainingJobs(ctx context.Context, d *plugin.QueryData, _ *p
 		&sagemaker.ListTrainingJobsInput{},
 		func(page *sagemaker.ListTrainingJobsOutput, isLast bool) bool {
 			for _, job := range page.TrainingJobSummaries {
-				d.StreamListItem(ctx, job)
+				if !streamListItem(ctx, d, job) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -173,7 +173,9 @@ func listSecretsManagerSecrets(ctx context.Context, d *plugin.QueryData, _ *plug
 		&secretsmanager.ListSecretsInput{},
 		func(page *secretsmanager.ListSecretsOutput, lastPage bool) bool {
 			for _, secret := range page.SecretList {
-				d.StreamListItem(ctx, secret)
+				if !streamListItem(ctx, d, secret) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -106,7 +106,9 @@ func listSecurityHubProducts(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&securityhub.DescribeProductsInput{},
 		func(page *securityhub.DescribeProductsOutput, isLast bool) bool {
 			for _, product := range page.Products {
-				d.StreamListItem(ctx, product)
+				if !streamListItem(ctx, d, product) {
+					return false
+				}
 			}
 			return !isLast
 		},
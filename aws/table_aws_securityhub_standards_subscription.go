@@ -93,7 +93,9 @@ func listSecurityHubStandardsSubcriptions(ctx context.Context, d *plugin.QueryDa
 		&securityhub.DescribeStandardsInput{},
 		func(page *securityhub.DescribeStandardsOutput, isLast bool) bool {
 			for _, standards := range page.Standards {
-				d.StreamListItem(ctx, standards)
+				if !streamListItem(ctx, d, standards) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -121,7 +121,9 @@ func listStepFunctionsStateManchines(ctx context.Context, d *plugin.QueryData, _
 		&sfn.ListStateMachinesInput{},
 		func(page *sfn.ListStateMachinesOutput, isLast bool) bool {
 			for _, stateMachine := range page.StateMachines {
-				d.StreamListItem(ctx, stateMachine)
+				if !streamListItem(ctx, d, stateMachine) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -120,7 +120,9 @@ func listStepFunctionsStateMachineExecutions(ctx context.Context, d *plugin.Quer
 		},
 		func(page *sfn.ListExecutionsOutput, isLast bool) bool {
 			for _, execution := range page.Executions {
-				d.StreamListItem(ctx, execution)
+				if !streamListItem(ctx, d, execution) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -201,7 +201,9 @@ func listAwsSSMAssociations(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		&ssm.ListAssociationsInput{},
 		func(page *ssm.ListAssociationsOutput, isLast bool) bool {
 			for _, association := range page.Associations {
-				d.StreamListItem(ctx, association)
+				if !streamListItem(ctx, d, association) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -246,7 +248,7 @@ func getSSMAssociationARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	plugin.Logger(ctx).Trace("getSSMAssociationARN")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	associationData := associationID(h.Item)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	c, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
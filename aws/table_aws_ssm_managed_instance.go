@@ -154,7 +154,9 @@ func listSsmManagedInstances(ctx context.Context, d *plugin.QueryData, _ *plugin
 		&ssm.DescribeInstanceInformationInput{},
 		func(page *ssm.DescribeInstanceInformationOutput, isLast bool) bool {
 			for _, managedInstance := range page.InstanceInformationList {
-				d.StreamListItem(ctx, managedInstance)
+				if !streamListItem(ctx, d, managedInstance) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -170,7 +172,7 @@ func getSsmManagedInstanceARN(ctx context.Context, d *plugin.QueryData, h *plugi
 	data := h.Item.(*ssm.InstanceInformation)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
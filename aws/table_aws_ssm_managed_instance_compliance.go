@@ -112,7 +112,9 @@ func listSsmManagedInstanceCompliances(ctx context.Context, d *plugin.QueryData,
 		params,
 		func(page *ssm.ListComplianceItemsOutput, isLast bool) bool {
 			for _, item := range page.ComplianceItems {
-				d.StreamListItem(ctx, item)
+				if !streamListItem(ctx, d, item) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -131,7 +133,7 @@ func getSSMManagedInstanceComplianceAkas(ctx context.Context, d *plugin.QueryDat
 	data := h.Item.(*ssm.ComplianceItem)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -16,7 +16,7 @@ func tableAwsSsoAdminInstance(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listSsoAdminInstances,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(ssoadmin.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "arn",
@@ -62,7 +62,9 @@ func listSsoAdminInstances(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		&ssoadmin.ListInstancesInput{},
 		func(page *ssoadmin.ListInstancesOutput, isLast bool) bool {
 			for _, instance := range page.Instances {
-				d.StreamListItem(ctx, instance)
+				if !streamListItem(ctx, d, instance) {
+					return false
+				}
 			}
 			return !isLast
 		},
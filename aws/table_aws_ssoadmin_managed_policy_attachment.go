@@ -20,7 +20,7 @@ func tableAwsSsoAdminManagedPolicyAttachment(_ context.Context) *plugin.Table {
 			KeyColumns: plugin.AllColumns([]string{"permission_set_arn"}),
 			Hydrate:    listSsoAdminManagedPolicyAttachments,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(ssoadmin.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "permission_set_arn",
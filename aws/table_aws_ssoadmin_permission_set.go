@@ -18,7 +18,7 @@ func tableAwsSsoAdminPermissionSet(_ context.Context) *plugin.Table {
 			ParentHydrate: listSsoAdminInstances,
 			Hydrate:       listSsoAdminPermissionSets,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(ssoadmin.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "name",
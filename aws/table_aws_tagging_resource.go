@@ -99,7 +99,9 @@ func listTaggingResources(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		&resourcegroupstaggingapi.GetResourcesInput{},
 		func(page *resourcegroupstaggingapi.GetResourcesOutput, isLast bool) bool {
 			for _, resource := range page.ResourceTagMappingList {
-				d.StreamListItem(ctx, resource)
+				if !streamListItem(ctx, d, resource) {
+					return false
+				}
 			}
 			return !isLast
 		},
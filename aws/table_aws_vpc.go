@@ -20,7 +20,8 @@ func tableAwsVpc(_ context.Context) *plugin.Table {
 			Hydrate:           getVpc,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcs,
+			Hydrate:    listVpcs,
+			KeyColumns: ec2FilterKeyColumns(vpcFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -107,6 +108,16 @@ func tableAwsVpc(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVpcs
+var vpcFilterColumns = []ec2FilterColumn{
+	{Column: "cidr_block", Filter: "cidr", Type: proto.ColumnType_CIDR},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "is_default", Filter: "is-default", Type: proto.ColumnType_BOOL},
+	{Column: "dhcp_options_id", Filter: "dhcp-options-id", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -119,12 +130,20 @@ func listVpcs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (
 		return nil, err
 	}
 
+	input := &ec2.DescribeVpcsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeVpcsPages(
-		&ec2.DescribeVpcsInput{},
+		input,
 		func(page *ec2.DescribeVpcsOutput, isLast bool) bool {
 			for _, vpc := range page.Vpcs {
-				d.StreamListItem(ctx, vpc)
+				if !streamListItem(ctx, d, vpc) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -172,7 +191,7 @@ func getVpcARN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData)
 	vpc := h.Item.(*ec2.Vpc)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcCustomerGateway(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcCustomerGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcCustomerGateways,
+			Hydrate:    listVpcCustomerGateways,
+			KeyColumns: ec2FilterKeyColumns(vpcCustomerGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -88,6 +89,15 @@ func tableAwsVpcCustomerGateway(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeCustomerGateways
+var vpcCustomerGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "type", Filter: "type", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "bgp_asn", Filter: "bgp-asn", Type: proto.ColumnType_STRING},
+	{Column: "ip_address", Filter: "ip-address", Type: proto.ColumnType_IPADDR},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcCustomerGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -99,8 +109,14 @@ func listVpcCustomerGateways(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	input := &ec2.DescribeCustomerGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcCustomerGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
-	resp, err := svc.DescribeCustomerGateways(&ec2.DescribeCustomerGatewaysInput{})
+	resp, err := svc.DescribeCustomerGateways(input)
 	for _, customerGateway := range resp.CustomerGateways {
 		d.StreamListItem(ctx, customerGateway)
 	}
@@ -144,7 +160,7 @@ func getVpcCustomerGatewayTurbotAkas(ctx context.Context, d *plugin.QueryData, h
 	plugin.Logger(ctx).Trace("getVpcCustomerGatewayTurbotAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	customerGateway := h.Item.(*ec2.CustomerGateway)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
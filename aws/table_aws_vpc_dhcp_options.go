@@ -20,7 +20,8 @@ func tableAwsVpcDhcpOptions(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcDhcpOption,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcDhcpOptions,
+			Hydrate:    listVpcDhcpOptions,
+			KeyColumns: ec2FilterKeyColumns(vpcDhcpOptionsFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -94,6 +95,12 @@ func tableAwsVpcDhcpOptions(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeDhcpOptions
+var vpcDhcpOptionsFilterColumns = []ec2FilterColumn{
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcDhcpOptions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -106,8 +113,14 @@ func listVpcDhcpOptions(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
+	input := &ec2.DescribeDhcpOptionsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcDhcpOptionsFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	err = svc.DescribeDhcpOptionsPages(
-		&ec2.DescribeDhcpOptionsInput{},
+		input,
 		func(page *ec2.DescribeDhcpOptionsOutput, lastPage bool) bool {
 			for _, item := range page.DhcpOptions {
 				plugin.Logger(ctx).Trace("listVpcDhcpOptions", "Data", item)
@@ -157,7 +170,7 @@ func getVpcDhcpOptionAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	plugin.Logger(ctx).Trace("getVpcDhcpOptionAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	dhcpOption := h.Item.(*ec2.DhcpOptions)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcEgressOnlyIGW(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcEgressOnlyInternetGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcEgressOnlyInternetGateways,
+			Hydrate:    listVpcEgressOnlyInternetGateways,
+			KeyColumns: ec2FilterKeyColumns(vpcEgressOnlyInternetGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -64,6 +65,11 @@ func tableAwsVpcEgressOnlyIGW(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeEgressOnlyInternetGateways
+var vpcEgressOnlyInternetGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcEgressOnlyInternetGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -76,12 +82,20 @@ func listVpcEgressOnlyInternetGateways(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
+	input := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcEgressOnlyInternetGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeEgressOnlyInternetGatewaysPages(
-		&ec2.DescribeEgressOnlyInternetGatewaysInput{},
+		input,
 		func(page *ec2.DescribeEgressOnlyInternetGatewaysOutput, isLast bool) bool {
 			for _, egressOnlyInternetGateway := range page.EgressOnlyInternetGateways {
-				d.StreamListItem(ctx, egressOnlyInternetGateway)
+				if !streamListItem(ctx, d, egressOnlyInternetGateway) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -127,7 +141,7 @@ func getVpcEgressOnlyInternetGatewayTurbotAkas(ctx context.Context, d *plugin.Qu
 	plugin.Logger(ctx).Trace("getVpcEgressOnlyInternetGatewayTurbotAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	egw := h.Item.(*ec2.EgressOnlyInternetGateway)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcEip(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcEip,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcEips,
+			Hydrate:    listVpcEips,
+			KeyColumns: ec2FilterKeyColumns(vpcEipFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -127,6 +128,19 @@ func tableAwsVpcEip(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeAddresses
+var vpcEipFilterColumns = []ec2FilterColumn{
+	{Column: "public_ip", Filter: "public-ip", Type: proto.ColumnType_IPADDR},
+	{Column: "domain", Filter: "domain", Type: proto.ColumnType_STRING},
+	{Column: "association_id", Filter: "association-id", Type: proto.ColumnType_STRING},
+	{Column: "instance_id", Filter: "instance-id", Type: proto.ColumnType_STRING},
+	{Column: "network_border_group", Filter: "network-border-group", Type: proto.ColumnType_STRING},
+	{Column: "network_interface_id", Filter: "network-interface-id", Type: proto.ColumnType_STRING},
+	{Column: "network_interface_owner_id", Filter: "network-interface-owner-id", Type: proto.ColumnType_STRING},
+	{Column: "private_ip_address", Filter: "private-ip-address", Type: proto.ColumnType_IPADDR},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcEips(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -139,8 +153,14 @@ func listVpcEips(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData
 		return nil, err
 	}
 
+	input := &ec2.DescribeAddressesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcEipFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
-	resp, err := svc.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	resp, err := svc.DescribeAddresses(input)
 	for _, address := range resp.Addresses {
 		d.StreamListItem(ctx, address)
 	}
@@ -184,7 +204,7 @@ func getVpcEipARN(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDat
 	plugin.Logger(ctx).Trace("getVpcEipARN")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	eip := h.Item.(*ec2.Address)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
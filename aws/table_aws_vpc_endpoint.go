@@ -20,7 +20,8 @@ func tableAwsVpcEndpoint(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcEndpoint,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcEndpoints,
+			Hydrate:    listVpcEndpoints,
+			KeyColumns: ec2FilterKeyColumns(vpcEndpointFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -132,6 +133,14 @@ func tableAwsVpcEndpoint(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVpcEndpoints
+var vpcEndpointFilterColumns = []ec2FilterColumn{
+	{Column: "service_name", Filter: "service-name", Type: proto.ColumnType_STRING},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "vpc-endpoint-state", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcEndpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -144,11 +153,19 @@ func listVpcEndpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		return nil, err
 	}
 
+	input := &ec2.DescribeVpcEndpointsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcEndpointFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	err = svc.DescribeVpcEndpointsPages(
-		&ec2.DescribeVpcEndpointsInput{},
+		input,
 		func(page *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
 			for _, item := range page.VpcEndpoints {
-				d.StreamListItem(ctx, item)
+				if !streamListItem(ctx, d, item) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -193,7 +210,7 @@ func getVpcEndpointAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 	plugin.Logger(ctx).Trace("getVpcEndpointAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	vpcEndpoint := h.Item.(*ec2.VpcEndpoint)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
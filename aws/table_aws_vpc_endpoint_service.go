@@ -21,7 +21,8 @@ func tableAwsVpcEndpointService(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcEndpointService,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcEndpointServices,
+			Hydrate:    listVpcEndpointServices,
+			KeyColumns: ec2FilterKeyColumns(vpcEndpointServiceFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -109,6 +110,13 @@ func tableAwsVpcEndpointService(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVpcEndpointServices
+var vpcEndpointServiceFilterColumns = []ec2FilterColumn{
+	{Column: "service_name", Filter: "service-name", Type: proto.ColumnType_STRING},
+	{Column: "owner", Filter: "owner", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcEndpointServices(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -123,6 +131,10 @@ func listVpcEndpointServices(ctx context.Context, d *plugin.QueryData, _ *plugin
 
 	pagesLeft := true
 	params := &ec2.DescribeVpcEndpointServicesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcEndpointServiceFilterColumns)
+	if len(filters) != 0 {
+		params.Filters = filters
+	}
 
 	// List call
 	for pagesLeft {
@@ -182,7 +194,7 @@ func getVpcEndpointServiceAkas(ctx context.Context, d *plugin.QueryData, h *plug
 	plugin.Logger(ctx).Trace("getVpcEndpointServiceAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	endpointService := h.Item.(*ec2.ServiceDetail)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
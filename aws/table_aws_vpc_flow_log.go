@@ -23,7 +23,8 @@ func tableAwsVpcFlowlog(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcFlowlog,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcFlowlogs,
+			Hydrate:    listVpcFlowlogs,
+			KeyColumns: ec2FilterKeyColumns(vpcFlowLogFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -130,6 +131,16 @@ func tableAwsVpcFlowlog(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeFlowLogs
+var vpcFlowLogFilterColumns = []ec2FilterColumn{
+	{Column: "deliver_logs_status", Filter: "deliver-log-status", Type: proto.ColumnType_STRING},
+	{Column: "log_group_name", Filter: "log-group-name", Type: proto.ColumnType_STRING},
+	{Column: "resource_id", Filter: "resource-id", Type: proto.ColumnType_STRING},
+	{Column: "traffic_type", Filter: "traffic-type", Type: proto.ColumnType_STRING},
+	{Column: "log_destination_type", Filter: "log-destination-type", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcFlowlogs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -142,11 +153,19 @@ func listVpcFlowlogs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
+	input := &ec2.DescribeFlowLogsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcFlowLogFilterColumns)
+	if len(filters) != 0 {
+		input.Filter = filters
+	}
+
 	err = svc.DescribeFlowLogsPages(
-		&ec2.DescribeFlowLogsInput{},
+		input,
 		func(page *ec2.DescribeFlowLogsOutput, lastPage bool) bool {
 			for _, item := range page.FlowLogs {
-				d.StreamListItem(ctx, item)
+				if !streamListItem(ctx, d, item) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -194,7 +213,7 @@ func getVpcFlowlogAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	plugin.Logger(ctx).Trace("getVpcFlowlogAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	vpcFlowlog := h.Item.(*ec2.FlowLog)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcInternetGateway(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcInternetGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcInternetGateways,
+			Hydrate:    listVpcInternetGateways,
+			KeyColumns: ec2FilterKeyColumns(vpcInternetGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -68,6 +69,12 @@ func tableAwsVpcInternetGateway(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeInternetGateways
+var vpcInternetGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcInternetGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -80,12 +87,20 @@ func listVpcInternetGateways(ctx context.Context, d *plugin.QueryData, _ *plugin
 		return nil, err
 	}
 
+	input := &ec2.DescribeInternetGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcInternetGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeInternetGatewaysPages(
-		&ec2.DescribeInternetGatewaysInput{},
+		input,
 		func(page *ec2.DescribeInternetGatewaysOutput, isLast bool) bool {
 			for _, internetGateway := range page.InternetGateways {
-				d.StreamListItem(ctx, internetGateway)
+				if !streamListItem(ctx, d, internetGateway) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -131,7 +146,7 @@ func getVpcInternetGatewayTurbotAkas(ctx context.Context, d *plugin.QueryData, h
 	plugin.Logger(ctx).Trace("getVpcInternetGatewayTurbotAkas")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	internetGateway := h.Item.(*ec2.InternetGateway)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcNatGateway(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcNatGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcNatGateways,
+			Hydrate:    listVpcNatGateways,
+			KeyColumns: ec2FilterKeyColumns(vpcNatGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -111,6 +112,14 @@ func tableAwsVpcNatGateway(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeNatGateways
+var vpcNatGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "subnet_id", Filter: "subnet-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcNatGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -123,12 +132,20 @@ func listVpcNatGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
+	input := &ec2.DescribeNatGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcNatGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filter = filters
+	}
+
 	// List call
 	err = svc.DescribeNatGatewaysPages(
-		&ec2.DescribeNatGatewaysInput{},
+		input,
 		func(page *ec2.DescribeNatGatewaysOutput, isLast bool) bool {
 			for _, securityGroup := range page.NatGateways {
-				d.StreamListItem(ctx, securityGroup)
+				if !streamListItem(ctx, d, securityGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -173,7 +190,7 @@ func getVpcNatGatewayARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	plugin.Logger(ctx).Trace("getVpcNatGatewayARN")
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	natGateway := h.Item.(*ec2.NatGateway)
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcNetworkACL(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcNetworkACL,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcNetworkACLs,
+			Hydrate:    listVpcNetworkACLs,
+			KeyColumns: ec2FilterKeyColumns(vpcNetworkAclFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -90,6 +91,14 @@ func tableAwsVpcNetworkACL(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeNetworkAcls
+var vpcNetworkAclFilterColumns = []ec2FilterColumn{
+	{Column: "is_default", Filter: "default", Type: proto.ColumnType_BOOL},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcNetworkACLs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -102,12 +111,20 @@ func listVpcNetworkACLs(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
+	input := &ec2.DescribeNetworkAclsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcNetworkAclFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeNetworkAclsPages(
-		&ec2.DescribeNetworkAclsInput{},
+		input,
 		func(page *ec2.DescribeNetworkAclsOutput, isLast bool) bool {
 			for _, networkACL := range page.NetworkAcls {
-				d.StreamListItem(ctx, networkACL)
+				if !streamListItem(ctx, d, networkACL) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -153,7 +170,7 @@ func getVpcNetworkACLARN(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	networkACL := h.Item.(*ec2.NetworkAcl)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
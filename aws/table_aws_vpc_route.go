@@ -167,7 +167,7 @@ func getAwsVpcRouteTurbotData(ctx context.Context, d *plugin.QueryData, h *plugi
 	routeData := h.Item.(*routeTableRoute)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -22,7 +22,8 @@ func tableAwsVpcRouteTable(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcRouteTable,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcRouteTables,
+			Hydrate:    listVpcRouteTables,
+			KeyColumns: ec2FilterKeyColumns(vpcRouteTableFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -87,6 +88,13 @@ func tableAwsVpcRouteTable(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeRouteTables
+var vpcRouteTableFilterColumns = []ec2FilterColumn{
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcRouteTables(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -99,12 +107,20 @@ func listVpcRouteTables(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
+	input := &ec2.DescribeRouteTablesInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcRouteTableFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeRouteTablesPages(
-		&ec2.DescribeRouteTablesInput{},
+		input,
 		func(page *ec2.DescribeRouteTablesOutput, isLast bool) bool {
 			for _, routeTable := range page.RouteTables {
-				d.StreamListItem(ctx, routeTable)
+				if !streamListItem(ctx, d, routeTable) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -149,8 +165,8 @@ func getVpcRouteTableAkas(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 	plugin.Logger(ctx).Trace("getVpcRouteTableTurbotAkas")
 	routeTable := h.Item.(*ec2.RouteTable)
 	region := d.KeyColumnQualString(matrixKeyRegion)
-	
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
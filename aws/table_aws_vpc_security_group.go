@@ -20,7 +20,8 @@ func tableAwsVpcSecurityGroup(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcSecurityGroup,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcSecurityGroups,
+			Hydrate:    listVpcSecurityGroups,
+			KeyColumns: ec2FilterKeyColumns(vpcSecurityGroupFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -97,6 +98,15 @@ func tableAwsVpcSecurityGroup(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeSecurityGroups
+var vpcSecurityGroupFilterColumns = []ec2FilterColumn{
+	{Column: "group_name", Filter: "group-name", Type: proto.ColumnType_STRING},
+	{Column: "description", Filter: "description", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcSecurityGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -109,12 +119,20 @@ func listVpcSecurityGroups(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		return nil, err
 	}
 
+	input := &ec2.DescribeSecurityGroupsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcSecurityGroupFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeSecurityGroupsPages(
-		&ec2.DescribeSecurityGroupsInput{},
+		input,
 		func(page *ec2.DescribeSecurityGroupsOutput, isLast bool) bool {
 			for _, securityGroup := range page.SecurityGroups {
-				d.StreamListItem(ctx, securityGroup)
+				if !streamListItem(ctx, d, securityGroup) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -160,7 +178,7 @@ func getVpcSecurityGroupARN(ctx context.Context, d *plugin.QueryData, h *plugin.
 	securityGroup := h.Item.(*ec2.SecurityGroup)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
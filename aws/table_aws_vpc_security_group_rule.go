@@ -167,7 +167,7 @@ func getSecurityGroupRuleTurbotData(ctx context.Context, d *plugin.QueryData, h
 	sgRule := h.Item.(*vpcSecurityGroupRulesRowData)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
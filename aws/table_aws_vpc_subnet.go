@@ -20,7 +20,8 @@ func tableAwsVpcSubnet(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcSubnet,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcSubnets,
+			Hydrate:    listVpcSubnets,
+			KeyColumns: ec2FilterKeyColumns(vpcSubnetFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -134,6 +135,24 @@ func tableAwsVpcSubnet(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeSubnets
+var vpcSubnetFilterColumns = []ec2FilterColumn{
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "availability_zone_id", Filter: "availability-zone-id", Type: proto.ColumnType_STRING},
+	{Column: "available_ip_address_count", Filter: "available-ip-address-count", Type: proto.ColumnType_INT},
+	{Column: "cidr_block", Filter: "cidr-block", Type: proto.ColumnType_CIDR},
+	{Column: "customer_owned_ipv4_pool", Filter: "customer-owned-ipv4-pool", Type: proto.ColumnType_STRING},
+	{Column: "default_for_az", Filter: "default-for-az", Type: proto.ColumnType_BOOL},
+	{Column: "map_customer_owned_ip_on_launch", Filter: "map-customer-owned-ip-on-launch", Type: proto.ColumnType_BOOL},
+	{Column: "map_public_ip_on_launch", Filter: "map-public-ip-on-launch", Type: proto.ColumnType_BOOL},
+	{Column: "outpost_arn", Filter: "outpost-arn", Type: proto.ColumnType_STRING},
+	{Column: "owner_id", Filter: "owner-id", Type: proto.ColumnType_STRING},
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "subnet_arn", Filter: "subnet-arn", Type: proto.ColumnType_STRING},
+	{Column: "vpc_id", Filter: "vpc-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcSubnets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -146,12 +165,20 @@ func listVpcSubnets(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		return nil, err
 	}
 
+	input := &ec2.DescribeSubnetsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcSubnetFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
 	err = svc.DescribeSubnetsPages(
-		&ec2.DescribeSubnetsInput{},
+		input,
 		func(page *ec2.DescribeSubnetsOutput, isLast bool) bool {
 			for _, subnet := range page.Subnets {
-				d.StreamListItem(ctx, subnet)
+				if !streamListItem(ctx, d, subnet) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -22,7 +22,8 @@ func tableAwsVpcVpnConnection(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcVpnConnection,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcVpnConnections,
+			Hydrate:    listVpcVpnConnections,
+			KeyColumns: ec2FilterKeyColumns(vpcVpnConnectionFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -119,6 +120,16 @@ func tableAwsVpcVpnConnection(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVpnConnections
+var vpcVpnConnectionFilterColumns = []ec2FilterColumn{
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "type", Filter: "type", Type: proto.ColumnType_STRING},
+	{Column: "vpn_gateway_id", Filter: "vpn-gateway-id", Type: proto.ColumnType_STRING},
+	{Column: "customer_gateway_id", Filter: "customer-gateway-id", Type: proto.ColumnType_STRING},
+	{Column: "transit_gateway_id", Filter: "transit-gateway-id", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcVpnConnections(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -131,8 +142,14 @@ func listVpcVpnConnections(ctx context.Context, d *plugin.QueryData, _ *plugin.H
 		return nil, err
 	}
 
+	input := &ec2.DescribeVpnConnectionsInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcVpnConnectionFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
-	resp, err := svc.DescribeVpnConnections(&ec2.DescribeVpnConnectionsInput{})
+	resp, err := svc.DescribeVpnConnections(input)
 	for _, vpnConnection := range resp.VpnConnections {
 		d.StreamListItem(ctx, vpnConnection)
 	}
@@ -177,7 +194,7 @@ func getVpcVpnConnectionARN(ctx context.Context, d *plugin.QueryData, h *plugin.
 	vpnConnection := h.Item.(*ec2.VpnConnection)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -20,7 +20,8 @@ func tableAwsVpcVpnGateway(_ context.Context) *plugin.Table {
 			Hydrate:           getVpcVpnGateway,
 		},
 		List: &plugin.ListConfig{
-			Hydrate: listVpcVpnGateways,
+			Hydrate:    listVpcVpnGateways,
+			KeyColumns: ec2FilterKeyColumns(vpcVpnGatewayFilterColumns),
 		},
 		GetMatrixItem: BuildRegionList,
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -83,6 +84,15 @@ func tableAwsVpcVpnGateway(_ context.Context) *plugin.Table {
 	}
 }
 
+// columns of the filters of DescribeVpnGateways
+var vpcVpnGatewayFilterColumns = []ec2FilterColumn{
+	{Column: "state", Filter: "state", Type: proto.ColumnType_STRING},
+	{Column: "type", Filter: "type", Type: proto.ColumnType_STRING},
+	{Column: "amazon_side_asn", Filter: "amazon-side-asn", Type: proto.ColumnType_INT},
+	{Column: "availability_zone", Filter: "availability-zone", Type: proto.ColumnType_STRING},
+	{Column: "tags", Filter: ec2TagFilter, Type: proto.ColumnType_JSON},
+}
+
 //// LIST FUNCTION
 
 func listVpcVpnGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -95,8 +105,14 @@ func listVpcVpnGateways(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydr
 		return nil, err
 	}
 
+	input := &ec2.DescribeVpnGatewaysInput{}
+	filters := buildEc2Filters(d.KeyColumnQuals, vpcVpnGatewayFilterColumns)
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+
 	// List call
-	resp, err := svc.DescribeVpnGateways(&ec2.DescribeVpnGatewaysInput{})
+	resp, err := svc.DescribeVpnGateways(input)
 	for _, vpnGateway := range resp.VpnGateways {
 		d.StreamListItem(ctx, vpnGateway)
 	}
@@ -142,7 +158,7 @@ func getVpcVpnGatewayTurbotAkas(ctx context.Context, d *plugin.QueryData, h *plu
 	vpnGateway := h.Item.(*ec2.VpnGateway)
 	region := d.KeyColumnQualString(matrixKeyRegion)
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -24,7 +24,7 @@ func tableAwsWellArchitectedWorkload(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listWellArchitectedWorkloads,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(wellarchitected.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "workload_name",
@@ -189,7 +189,9 @@ func listWellArchitectedWorkloads(ctx context.Context, d *plugin.QueryData, _ *p
 		&wellarchitected.ListWorkloadsInput{},
 		func(page *wellarchitected.ListWorkloadsOutput, lastPage bool) bool {
 			for _, Workload := range page.WorkloadSummaries {
-				d.StreamListItem(ctx, Workload)
+				if !streamListItem(ctx, d, Workload) {
+					return false
+				}
 			}
 			return !lastPage
 		},
@@ -24,7 +24,7 @@ func tableAwsWorkspace(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listWorkspaces,
 		},
-		GetMatrixItem: BuildRegionList,
+		GetMatrixItem: BuildRegionListForService(workspaces.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
 				Name:        "workspace_id",
@@ -158,7 +158,9 @@ func listWorkspaces(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		&workspaces.DescribeWorkspacesInput{},
 		func(page *workspaces.DescribeWorkspacesOutput, isLast bool) bool {
 			for _, Workspace := range page.Workspaces {
-				d.StreamListItem(ctx, Workspace)
+				if !streamListItem(ctx, d, Workspace) {
+					return false
+				}
 			}
 			return !isLast
 		},
@@ -228,7 +230,7 @@ func getWorkspaceArn(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	region := d.KeyColumnQualString(matrixKeyRegion)
 	workspaceId := h.Item.(*workspaces.Workspace).WorkspaceId
 
-	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache()
+	getCommonColumnsCached := plugin.HydrateFunc(getCommonColumns).WithCache(getCommonColumnsCacheKey)
 	commonData, err := getCommonColumnsCached(ctx, d, h)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,263 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/connection"
+	"github.com/turbot/steampipe-plugin-sdk/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/plugin"
+	"google.golang.org/grpc"
+)
+
+// The table tests run queries against recorded AWS responses, and compare the rows with golden
+// files. Each directory in testdata/tables is named after the table it tests, and has:
+//
+//	http.json                the recorded HTTP interactions, see recordReplayTransport
+//	<name>.query.json        a query, with the columns to fetch and equality quals, e.g. for a get call
+//	<name>.golden.json       the rows the query returns
+//
+// To record new fixtures with the default AWS credentials, and write the rows they return as the
+// golden rows, run:
+//
+//	go test ./aws -run TestTables/<table> -record
+//
+// Run with -update to only rewrite the golden rows from the existing recordings.
+var (
+	recordTableFixtures = flag.Bool("record", false, "record the HTTP fixtures of the table tests from AWS, and update their golden rows")
+	updateTableGolden   = flag.Bool("update", false, "update the golden rows of the table tests")
+)
+
+// tableTestQuery is the content of a <name>.query.json file
+type tableTestQuery struct {
+	// columns to fetch, all columns of the table if empty
+	Columns []string `json:"columns"`
+	// equality quals, by column
+	Quals map[string]string `json:"quals"`
+}
+
+func TestTables(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "tables", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dir := range dirs {
+		dir := dir
+		tableName := filepath.Base(dir)
+		t.Run(tableName, func(t *testing.T) {
+			p := newTableTestPlugin(t, tableName, dir)
+
+			queryFiles, err := filepath.Glob(filepath.Join(dir, "*.query.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, queryFile := range queryFiles {
+				queryFile := queryFile
+				name := strings.TrimSuffix(filepath.Base(queryFile), ".query.json")
+				t.Run(name, func(t *testing.T) {
+					runTableTestQuery(t, p, tableName, queryFile, filepath.Join(dir, name+".golden.json"))
+				})
+			}
+		})
+	}
+}
+
+// newTableTestPlugin returns the plugin with a connection which replays (or records) the HTTP fixtures of a table
+func newTableTestPlugin(t *testing.T, tableName string, dir string) *plugin.Plugin {
+	fixtureFile, err := filepath.Abs(filepath.Join(dir, "http.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := `regions = ["us-east-1"]` + "\n"
+	if *recordTableFixtures {
+		// start from a new recording, with the default credentials
+		if err := os.Remove(fixtureFile); err != nil && !os.IsNotExist(err) {
+			t.Fatal(err)
+		}
+		config += `http_mode = "record"` + "\n"
+	} else {
+		config += `access_key = "` + scrubbedAccessKeyId + `"` + "\n"
+		config += `secret_key = "` + scrubbedSecretOrToken + `"` + "\n"
+		config += `http_mode = "replay"` + "\n"
+	}
+	config += `http_fixture_file = "` + filepath.ToSlash(fixtureFile) + `"` + "\n"
+
+	p := Plugin(context.Background())
+	p.Logger = hclog.NewNullLogger()
+	p.ConnectionManager = connection.NewManager()
+	p.SchemaMode = plugin.SchemaModeStatic
+	// each table has its own connection, so nothing is cached across tables
+	if err := p.SetConnectionConfig("table_test_"+tableName, config); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func runTableTestQuery(t *testing.T, p *plugin.Plugin, tableName string, queryFile string, goldenFile string) {
+	data, err := ioutil.ReadFile(queryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var query tableTestQuery
+	if err := json.Unmarshal(data, &query); err != nil {
+		t.Fatalf("invalid query file %s: %s", queryFile, err.Error())
+	}
+
+	stream := executeTableTestQuery(t, p, tableName, query.Columns, query.Quals, 0)
+	actual, err := stream.sortedRowsJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *recordTableFixtures || *updateTableGolden {
+		if err := ioutil.WriteFile(goldenFile, actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(expected), bytes.TrimSpace(actual)) {
+		t.Errorf("rows differ from %s, run with -update if the change is expected\nexpected:\n%s\nactual:\n%s", goldenFile, expected, actual)
+	}
+}
+
+// executeTableTestQuery runs a query of a table, with equality quals by column, and returns the stream
+// of its rows. It fetches all columns of the table if columns is empty, and all rows if limit is 0.
+func executeTableTestQuery(t *testing.T, p *plugin.Plugin, tableName string, columns []string, qualValues map[string]string, limit int64) *tableTestStream {
+	table, ok := p.TableMap[tableName]
+	if !ok {
+		t.Fatalf("table %s does not exist", tableName)
+	}
+	if len(columns) == 0 {
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+		}
+	}
+
+	quals := map[string]*proto.Quals{}
+	for column, value := range qualValues {
+		quals[column] = &proto.Quals{Quals: []*proto.Qual{{
+			FieldName: column,
+			Operator:  &proto.Qual_StringValue{StringValue: "="},
+			Value:     &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: value}},
+		}}}
+	}
+
+	queryContext := &proto.QueryContext{Columns: columns, Quals: quals}
+	if limit > 0 {
+		queryContext.Limit = &proto.NullableInt{Value: limit}
+	}
+
+	stream := &tableTestStream{ctx: context.Background()}
+	req := &proto.ExecuteRequest{
+		Table:        tableName,
+		QueryContext: queryContext,
+		Connection:   p.Connection.Name,
+	}
+	if err := p.Execute(req, stream); err != nil {
+		t.Fatal(err)
+	}
+	return stream
+}
+
+// tableTestStream collects the rows streamed by plugin.Execute
+type tableTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	mutex sync.Mutex
+	rows  []map[string]interface{}
+}
+
+func (s *tableTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tableTestStream) Send(response *proto.ExecuteResponse) error {
+	// the end of the rows is sent as a response without a row
+	if response.Row == nil {
+		return nil
+	}
+
+	row := map[string]interface{}{}
+	for name, column := range response.Row.Columns {
+		value, err := tableTestColumnValue(column)
+		if err != nil {
+			return err
+		}
+		row[name] = value
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rows = append(s.rows, row)
+	return nil
+}
+
+// sortedRowsJSON returns the rows as indented JSON, sorted as rows are streamed in any order
+func (s *tableTestStream) sortedRowsJSON() ([]byte, error) {
+	rows := make([]json.RawMessage, len(s.rows))
+	for i, row := range s.rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = data
+	}
+	sort.Slice(rows, func(i, j int) bool { return string(rows[i]) < string(rows[j]) })
+
+	data, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func tableTestColumnValue(column *proto.Column) (interface{}, error) {
+	switch value := column.Value.(type) {
+	case *proto.Column_DoubleValue:
+		return value.DoubleValue, nil
+	case *proto.Column_IntValue:
+		return value.IntValue, nil
+	case *proto.Column_StringValue:
+		return value.StringValue, nil
+	case *proto.Column_BoolValue:
+		return value.BoolValue, nil
+	case *proto.Column_JsonValue:
+		var jsonValue interface{}
+		if err := json.Unmarshal(value.JsonValue, &jsonValue); err != nil {
+			return nil, err
+		}
+		return jsonValue, nil
+	case *proto.Column_TimestampValue:
+		timestamp, err := ptypes.Timestamp(value.TimestampValue)
+		if err != nil {
+			return nil, err
+		}
+		return timestamp.UTC().Format(time.RFC3339Nano), nil
+	case *proto.Column_IpAddrValue:
+		return value.IpAddrValue, nil
+	case *proto.Column_CidrRangeValue:
+		return value.CidrRangeValue, nil
+	default:
+		return nil, nil
+	}
+}